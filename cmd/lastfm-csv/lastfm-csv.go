@@ -1,26 +1,33 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/nilsbu/lastfm/pkg/command"
 	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
 	"github.com/nilsbu/lastfm/pkg/io"
 	"github.com/nilsbu/lastfm/pkg/pipeline"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 	"github.com/nilsbu/lastfm/pkg/unpack"
 )
 
-func createStore() (io.Store, error) {
-	key, err := unpack.LoadAPIKey(io.FileIO{})
-	if err != nil {
-		return nil, err
-	}
-
+func createStore(offline *io.OfflineIO) (io.Store, error) {
 	var webIOs []rsrc.IO
-	for i := 0; i < 32; i++ {
-		webIOs = append(webIOs, io.NewWebIO(key))
+	if offline != nil {
+		webIOs = append(webIOs, *offline)
+	} else {
+		key, err := unpack.LoadAPIKey(io.FileIO{})
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < 32; i++ {
+			webIOs = append(webIOs, io.NewWebIO(key))
+		}
 	}
 
 	var fileIOs []rsrc.IO
@@ -28,7 +35,11 @@ func createStore() (io.Store, error) {
 		fileIOs = append(fileIOs, io.FileIO{})
 	}
 
-	st, err := io.NewStore([][]rsrc.IO{webIOs, fileIOs})
+	st, err := io.NewExpiringStore(
+		[][]rsrc.IO{webIOs, fileIOs},
+		[]chan<- format.Formatter{io.DumpChan(), io.DumpChan()},
+		io.DefaultFreshness,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +48,13 @@ func createStore() (io.Store, error) {
 }
 
 func main() {
-	s, err := createStore()
+	var offline *io.OfflineIO
+	if os.Getenv("LASTFM_OFFLINE") != "" {
+		off := io.NewOfflineIO()
+		offline = &off
+	}
+
+	s, err := createStore(offline)
 
 	if err != nil {
 		fmt.Println(err)
@@ -48,8 +65,19 @@ func main() {
 	pl := pipeline.New(session, s)
 	d := display.NewCSV("total.csv", ",") // TODO file name as param
 
-	err = command.Execute(os.Args, session, s, pl, d)
+	// Ctrl-C stops the command instead of killing the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = command.Execute(ctx, os.Args, session, s, pl, d)
 	if err != nil {
 		fmt.Println(err)
 	}
+
+	if offline != nil {
+		err = command.ReportMissing(offline.Missing(), s, display.NewTerminal())
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
 }
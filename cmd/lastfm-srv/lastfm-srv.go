@@ -1,31 +1,30 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/nilsbu/lastfm/pkg/command"
 	"github.com/nilsbu/lastfm/pkg/display"
 	"github.com/nilsbu/lastfm/pkg/format"
 	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/metrics"
+	"github.com/nilsbu/lastfm/pkg/organize"
 	"github.com/nilsbu/lastfm/pkg/pipeline"
 	"github.com/nilsbu/lastfm/pkg/refresh"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 	"github.com/nilsbu/lastfm/pkg/unpack"
 )
 
-func dumpChan() chan<- format.Formatter {
-	obChan := make(chan format.Formatter)
-	go func() {
-		for range obChan {
-		}
-	}()
-
-	return obChan
-}
+var requestDurations = metrics.Default.Histogram("lastfm_http_request_duration_seconds",
+	"Duration of HTTP requests by command.", metrics.DefBuckets, "command")
 
 func createStore(webObserver chan<- format.Formatter) (io.Store, error) {
 	key, err := unpack.LoadAPIKey(io.FileIO{})
@@ -43,9 +42,10 @@ func createStore(webObserver chan<- format.Formatter) (io.Store, error) {
 		fileIOs = append(fileIOs, io.FileIO{})
 	}
 
-	st, err := io.NewObservedStore(
+	st, err := io.NewExpiringStore(
 		[][]rsrc.IO{webIOs, fileIOs},
-		[]chan<- format.Formatter{webObserver, dumpChan()},
+		[]chan<- format.Formatter{webObserver, io.DumpChan()},
+		io.DefaultFreshness,
 	)
 	if err != nil {
 		return nil, err
@@ -92,11 +92,38 @@ func handleRequest(
 		args = append(args, fmt.Sprintf("-%v=%v", k, vs[0]))
 	}
 
-	err := command.Execute(args, session, s, pl, d)
+	// unknown commands share a label so that arbitrary URLs can't create
+	// new series
+	name := "other"
+	if len(a) > 0 && command.IsCommand(a[0]) {
+		name = a[0]
+	}
+	start := time.Now()
+
+	// the command is cancelled when the client disconnects
+	err := command.Execute(r.Context(), args, session, s, pl, d)
 	if err != nil {
 		fmt.Println(err)
 	}
 
+	requestDurations.Observe(time.Since(start).Seconds(), name)
+
+}
+
+// reloadOnHangup replaces the pipeline when the server receives SIGHUP so that
+// changed configuration files take effect. If the configuration is invalid, the
+// current pipeline is kept.
+func reloadOnHangup(s io.Store, trigger refresh.Trigger) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if _, err := organize.LoadTagConfig(s); err != nil {
+			fmt.Println("configuration not reloaded:", err)
+			continue
+		}
+		trigger.Refresh()
+		fmt.Println("configuration reloaded")
+	}
 }
 
 func main() {
@@ -108,7 +135,7 @@ func main() {
 	}
 	fmt.Println("Listening on port", port)
 
-	s, err := createStore(dumpChan())
+	s, err := createStore(io.DumpChan())
 
 	if err != nil {
 		fmt.Println(err)
@@ -125,12 +152,14 @@ func main() {
 	pl, trigger := refresh.WrapRefresh(s, pipeline.New(session, s), session)
 	go refresh.PeriodicRefresh(trigger, 0, 1, 0, // Every night at 01:00 (UTC)
 		func() {
-			command.Execute([]string{"lastfm-srv", "update"}, session, s, pl, display.NewNull())
+			command.Execute(context.Background(), []string{"lastfm-srv", "update"}, session, s, pl, display.NewNull())
 		}, func() {
 			args := []string{"lastfm-srv", "print", "fade", "365", "-by=super"}
-			command.Execute(args, session, s, pl, display.NewNull())
+			command.Execute(context.Background(), args, session, s, pl, display.NewNull())
 		})
+	go reloadOnHangup(s, trigger)
 
+	http.Handle("/metrics", metrics.Default)
 	http.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
 		handleRequest(session, s, pl, rw, r)
 	})
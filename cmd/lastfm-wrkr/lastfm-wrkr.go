@@ -11,16 +11,6 @@ import (
 	"github.com/nilsbu/lastfm/pkg/unpack"
 )
 
-func dumpChan() chan<- format.Formatter {
-	obChan := make(chan format.Formatter)
-	go func() {
-		for range obChan {
-		}
-	}()
-
-	return obChan
-}
-
 func createStore() (io.Store, error) {
 	key, err := unpack.LoadAPIKey(io.FileIO{})
 	if err != nil {
@@ -37,9 +27,10 @@ func createStore() (io.Store, error) {
 		fileIOs = append(fileIOs, io.FileIO{})
 	}
 
-	st, err := io.NewObservedStore(
+	st, err := io.NewExpiringStore(
 		[][]rsrc.IO{webIOs, fileIOs},
-		[]chan<- format.Formatter{dumpChan(), dumpChan()},
+		[]chan<- format.Formatter{io.DumpChan(), io.DumpChan()},
+		io.DefaultFreshness,
 	)
 	if err != nil {
 		return nil, err
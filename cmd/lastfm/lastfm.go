@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"time"
 
 	"github.com/nilsbu/lastfm/pkg/command"
@@ -14,25 +16,19 @@ import (
 	"github.com/nilsbu/lastfm/pkg/unpack"
 )
 
-func dumpChan() chan<- format.Formatter {
-	obChan := make(chan format.Formatter)
-	go func() {
-		for range obChan {
+func createStore(webObserver chan<- format.Formatter, offline *io.OfflineIO) (io.Store, error) {
+	var webIOs []rsrc.IO
+	if offline != nil {
+		webIOs = append(webIOs, *offline)
+	} else {
+		key, err := unpack.LoadAPIKey(io.FileIO{})
+		if err != nil {
+			return nil, err
 		}
-	}()
-
-	return obChan
-}
-
-func createStore(webObserver chan<- format.Formatter) (io.Store, error) {
-	key, err := unpack.LoadAPIKey(io.FileIO{})
-	if err != nil {
-		return nil, err
-	}
 
-	var webIOs []rsrc.IO
-	for i := 0; i < 1; i++ {
-		webIOs = append(webIOs, io.NewWebIO(key))
+		for i := 0; i < 1; i++ {
+			webIOs = append(webIOs, io.NewWebIO(key))
+		}
 	}
 
 	var fileIOs []rsrc.IO
@@ -40,9 +36,10 @@ func createStore(webObserver chan<- format.Formatter) (io.Store, error) {
 		fileIOs = append(fileIOs, io.FileIO{})
 	}
 
-	st, err := io.NewObservedStore(
+	st, err := io.NewExpiringStore(
 		[][]rsrc.IO{webIOs, fileIOs},
-		[]chan<- format.Formatter{webObserver, dumpChan()},
+		[]chan<- format.Formatter{webObserver, io.DumpChan()},
+		io.DefaultFreshness,
 	)
 	if err != nil {
 		return nil, err
@@ -56,7 +53,13 @@ func main() {
 	webObserver := make(chan format.Formatter)
 	d := display.NewTimedTerminal(webObserver, 1*time.Second)
 
-	s, err := createStore(webObserver)
+	var offline *io.OfflineIO
+	if os.Getenv("LASTFM_OFFLINE") != "" {
+		off := io.NewOfflineIO()
+		offline = &off
+	}
+
+	s, err := createStore(webObserver, offline)
 
 	if err != nil {
 		fmt.Println(err)
@@ -66,8 +69,18 @@ func main() {
 	session, _ := unpack.LoadSessionInfo(s)
 	pl := pipeline.New(session, s)
 
-	err = command.Execute(os.Args, session, s, pl, d)
+	// Ctrl-C stops the command instead of killing the process
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err = command.Execute(ctx, os.Args, session, s, pl, d)
 	if err != nil {
 		fmt.Println(err)
 	}
+
+	if offline != nil {
+		if err := command.ReportMissing(offline.Missing(), s, d); err != nil {
+			fmt.Println(err)
+		}
+	}
 }
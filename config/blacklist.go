@@ -2,26 +2,34 @@ package config
 
 import "strings"
 
+// BlacklistedTags are tags that don't describe a genre and are ignored when
+// artists are partitioned by their tags.
+var BlacklistedTags = []string{
+	"20s", "30s", "40s", "50s", "60s", "70s", "80s", "90s", "00s", "10s",
+	"beautiful", "sexy", "love",
+	"comedy",
+	"cover",
+	"female vocalist", "female vocalists", "male vocalist", "male vocalists",
+	"hip hop", "rap",
+	"oldies",
+	"psychedelic",
+	"seen live",
+	"soundtrack",
+}
+
 func Blacklist() map[string]interface{} {
-	list := []string{
-		"20s", "30s", "40s", "50s", "60s", "70s", "80s", "90s", "00s", "10s",
-		"beautiful", "sexy", "love",
-		"comedy",
-		"cover",
-		"female vocalist", "female vocalists", "male vocalist", "male vocalists",
-		"hip hop", "rap",
-		"oldies",
-		"psychedelic",
-		"seen live",
-		"soundtrack",
-	}
+	return NewBlacklist(BlacklistedTags, Countries)
+}
 
+// NewBlacklist returns the set of blacklisted tags. Nationalities and the names
+// of countries are blacklisted as well.
+func NewBlacklist(tags []string, countries map[string]string) map[string]interface{} {
 	blacklist := map[string]interface{}{}
-	for _, item := range list {
+	for _, item := range tags {
 		blacklist[item] = nil
 	}
 
-	for k, v := range Countries {
+	for k, v := range countries {
 		blacklist[k] = nil
 		blacklist[strings.ToLower(v)] = nil
 	}
@@ -0,0 +1,56 @@
+package config
+
+// Genres associates genres with their parent genres. Genres that have no
+// parent are at the top of the hierarchy. All supertags are included as
+// subgenres of their supertag.
+var Genres = genres()
+
+var subgenres = map[string]string{
+	"atmospheric black metal": "black metal",
+	"depressive black metal":  "black metal",
+	"melodic black metal":     "black metal",
+	"symphonic black metal":   "black metal",
+	"brutal death metal":      "death metal",
+	"melodic death metal":     "death metal",
+	"technical death metal":   "death metal",
+	"funeral doom metal":      "doom metal",
+	"sludge metal":            "doom metal",
+	"stoner metal":            "doom metal",
+
+	"deep house":        "house",
+	"progressive house": "house",
+	"tech house":        "house",
+	"liquid funk":       "drum and bass",
+	"neurofunk":         "drum and bass",
+	"minimal techno":    "techno",
+	"psytrance":         "trance",
+	"uplifting trance":  "trance",
+
+	"pop punk":   "punk",
+	"punk rock":  "punk",
+	"hardcore":   "punk",
+	"shoegaze":   "indie rock",
+	"post-rock":  "progressive rock",
+	"space rock": "progressive rock",
+
+	"bebop":       "jazz",
+	"free jazz":   "jazz",
+	"jazz fusion": "jazz",
+	"smooth jazz": "jazz",
+
+	"baroque":  "classical",
+	"romantic": "classical",
+}
+
+func genres() map[string]string {
+	genres := map[string]string{}
+	for tag, supertag := range Supertags {
+		if tag != supertag {
+			genres[tag] = supertag
+		}
+	}
+	for genre, parent := range subgenres {
+		genres[genre] = parent
+	}
+	return genres
+}
@@ -1,13 +1,15 @@
 package charts
 
 import (
+	"context"
 	"sort"
+	"sync"
 
 	"github.com/nilsbu/lastfm/pkg/info"
 )
 
 type Charts interface {
-	Data(titles []Title, begin, end int) ([][]float64, error)
+	Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error)
 
 	Titles() []Title
 	Len() int
@@ -17,6 +19,7 @@ type charts struct {
 	songs   func() ([][]info.Song, error)
 	key     func(info.Song) Title
 	value   func(info.Song) float64
+	credits func(info.Song) []credit
 	jobChan chan compileJob
 	titles  []Title
 	values  map[string][]float64
@@ -95,13 +98,12 @@ func (c *charts) compile() error {
 	// TODO can this be parallelized?
 	for d, day := range songs {
 		for _, song := range day {
-			k := c.key(song)
-			if line, ok := c.values[k.Key()]; ok {
-				line[d] += c.value(song)
+			if c.credits != nil {
+				for _, cr := range c.credits(song) {
+					c.add(d, len(songs), cr.title, cr.value)
+				}
 			} else {
-				c.titles = append(c.titles, k)
-				c.values[k.Key()] = make([]float64, len(songs))
-				c.values[k.Key()][d] = c.value(song)
+				c.add(d, len(songs), c.key(song), c.value(song))
 			}
 		}
 	}
@@ -110,6 +112,16 @@ func (c *charts) compile() error {
 	return nil
 }
 
+func (c *charts) add(d, n int, k Title, value float64) {
+	if line, ok := c.values[k.Key()]; ok {
+		line[d] += value
+	} else {
+		c.titles = append(c.titles, k)
+		c.values[k.Key()] = make([]float64, n)
+		c.values[k.Key()][d] = value
+	}
+}
+
 func (c *charts) await() error {
 	back := make(chan error)
 	c.jobChan <- back
@@ -156,7 +168,7 @@ func InOrder(data []Pair) Charts {
 	return charts
 }
 
-func (l *charts) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (l *charts) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	if l.songs != nil {
 		if err := l.await(); err != nil {
 			return nil, err
@@ -192,6 +204,49 @@ func (l *charts) Len() int {
 	return -1
 }
 
+// pie executes f in parallel n times and returns the first error that
+// occurred. Iterations that start after ctx is done or after an error return
+// without calling f. If ctx is done, ctx.Err() is returned.
+func pie(ctx context.Context, n int, f func(int) error) error {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var first error
+
+	failed := func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return first != nil
+	}
+
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			if failed() {
+				return
+			}
+
+			err := ctx.Err()
+			if err == nil {
+				err = f(i)
+			}
+			if err != nil {
+				mutex.Lock()
+				if first == nil {
+					first = err
+				}
+				mutex.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if ctxErr := ctx.Err(); first != nil && ctxErr != nil {
+		return ctxErr
+	}
+	return first
+}
+
 type chartsNode struct {
 	parent Charts
 }
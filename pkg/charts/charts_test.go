@@ -1,6 +1,7 @@
 package charts_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -48,6 +49,30 @@ func TestCharts(t *testing.T) {
 				{1, 1}, {2, 0}, {0, 1},
 			},
 		},
+		{
+			"ArtistsCredited full",
+			charts.ArtistsCredited([][]info.Song{
+				{info.Song{Artist: "A feat. B"},
+					info.Song{Artist: "B"},
+				},
+				{info.Song{Artist: "C & D"}},
+			}, charts.NewCredits([]string{"C & D"}, false)),
+			[]charts.Title{charts.ArtistTitle("A"), charts.ArtistTitle("B"), charts.ArtistTitle("C & D")},
+			[][]float64{
+				{1, 0}, {2, 0}, {0, 1},
+			},
+		},
+		{
+			"ArtistsDurationCredited fractional",
+			charts.ArtistsDurationCredited([][]info.Song{
+				{info.Song{Artist: "A & B", Duration: 2}},
+				{info.Song{Artist: "B", Duration: 1}},
+			}, charts.NewCredits(nil, true)),
+			[]charts.Title{charts.ArtistTitle("A"), charts.ArtistTitle("B")},
+			[][]float64{
+				{1, 0}, {1, 1},
+			},
+		},
 		{
 			"Songs",
 			charts.Songs([][]info.Song{
@@ -190,9 +215,9 @@ func TestCharts(t *testing.T) {
 					c.titles, c.charts.Titles())
 			}
 
-			data, _ := c.charts.Data(c.titles, 0, c.charts.Len())
+			data, _ := c.charts.Data(context.Background(), c.titles, 0, c.charts.Len())
 			for i, title := range c.titles {
-				row, _ := c.charts.Data([]charts.Title{title}, 0, c.charts.Len())
+				row, _ := c.charts.Data(context.Background(), []charts.Title{title}, 0, c.charts.Len())
 				if !reflect.DeepEqual(c.lines[i], row[0]) {
 					t.Errorf("row, '%v': %v != %v", title, c.lines[i], row[0])
 				}
@@ -203,7 +228,7 @@ func TestCharts(t *testing.T) {
 			}
 
 			for i := 0; i < c.charts.Len(); i++ {
-				col, _ := c.charts.Data(c.titles, i, i+1)
+				col, _ := c.charts.Data(context.Background(), c.titles, i, i+1)
 				for j, title := range c.titles {
 					if c.lines[j][i] != col[j][0] {
 						t.Errorf("col %v, %v: %v != %v",
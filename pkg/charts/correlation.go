@@ -0,0 +1,156 @@
+package charts
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// Fingerprints hashes the values of each title in c, so that results that
+// were derived from them can be cached until the values change.
+func Fingerprints(ctx context.Context, c Charts, titles []Title) ([]uint64, error) {
+	data, err := c.Data(ctx, titles, 0, c.Len())
+	if err != nil {
+		return nil, err
+	}
+
+	prints := make([]uint64, len(titles))
+	buf := make([]byte, 8)
+	for i, line := range data {
+		h := fnv.New64a()
+		for _, v := range line {
+			binary.LittleEndian.PutUint64(buf, math.Float64bits(v))
+			h.Write(buf)
+		}
+		prints[i] = h.Sum64()
+	}
+	return prints, nil
+}
+
+// Correlations computes the Pearson correlation between each pair of titles in
+// c. The columns are first summed up in bins of step columns, e.g. step 7 for
+// correlating weeks of daily charts. The rows and columns of the result are in
+// the order of titles. Titles whose binned values are constant have a
+// correlation of 0 with all titles including themselves.
+func Correlations(ctx context.Context, c Charts, titles []Title, step int,
+) ([][]float64, error) {
+	if step < 1 {
+		step = 1
+	}
+
+	data, err := c.Data(ctx, titles, 0, c.Len())
+	if err != nil {
+		return nil, err
+	}
+
+	bins := (c.Len() + step - 1) / step
+	z := make([][]float64, len(titles))
+	err = pie(ctx, len(titles), func(i int) error {
+		z[i] = standardize(data[i], step, bins)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	corr := make([][]float64, len(titles))
+	for i := range corr {
+		corr[i] = make([]float64, len(titles))
+	}
+	err = pie(ctx, len(titles), func(i int) error {
+		for j := i; j < len(titles); j++ {
+			v := 0.0
+			for k := range z[i] {
+				v += z[i][k] * z[j][k]
+			}
+			corr[i][j], corr[j][i] = v, v
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return corr, nil
+}
+
+// standardize sums up line in bins of step values and scales the bins to a
+// mean of 0 and a norm of 1, so that the dot product of two of them is their
+// correlation.
+func standardize(line []float64, step, bins int) []float64 {
+	z := make([]float64, bins)
+	for i, v := range line {
+		z[i/step] += v
+	}
+
+	mean := 0.0
+	for _, v := range z {
+		mean += v
+	}
+	mean /= float64(bins)
+
+	norm := 0.0
+	for i := range z {
+		z[i] -= mean
+		norm += z[i] * z[i]
+	}
+	norm = math.Sqrt(norm)
+
+	for i := range z {
+		if norm > 0 {
+			z[i] /= norm
+		} else {
+			z[i] = 0
+		}
+	}
+	return z
+}
+
+// Clusters groups indices of a correlation matrix into k clusters by
+// average-linkage agglomerative clustering, i.e. the two clusters with the
+// highest average correlation between their members are merged until k are
+// left. The clusters are ordered by size, then by their lowest index. Indices
+// in a cluster are in ascending order.
+func Clusters(corr [][]float64, k int) [][]int {
+	clusters := make([][]int, len(corr))
+	for i := range clusters {
+		clusters[i] = []int{i}
+	}
+
+	for len(clusters) > k && len(clusters) > 1 {
+		bestI, bestJ := 0, 1
+		best := math.Inf(-1)
+		for i := range clusters {
+			for j := i + 1; j < len(clusters); j++ {
+				if link := linkage(corr, clusters[i], clusters[j]); link > best {
+					best, bestI, bestJ = link, i, j
+				}
+			}
+		}
+
+		clusters[bestI] = append(clusters[bestI], clusters[bestJ]...)
+		clusters = append(clusters[:bestJ], clusters[bestJ+1:]...)
+	}
+
+	for _, cluster := range clusters {
+		sort.Ints(cluster)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if len(clusters[i]) != len(clusters[j]) {
+			return len(clusters[i]) > len(clusters[j])
+		}
+		return clusters[i][0] < clusters[j][0]
+	})
+	return clusters
+}
+
+func linkage(corr [][]float64, a, b []int) float64 {
+	sum := 0.0
+	for _, i := range a {
+		for _, j := range b {
+			sum += corr[i][j]
+		}
+	}
+	return sum / float64(len(a)*len(b))
+}
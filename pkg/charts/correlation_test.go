@@ -0,0 +1,122 @@
+package charts_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestCorrelations(t *testing.T) {
+	c := charts.FromMap(map[string][]float64{
+		"A": {1, 0, 1, 0, 2, 0},
+		"B": {2, 0, 2, 0, 4, 0},
+		"C": {0, 1, 0, 1, 0, 2},
+		"D": {1, 1, 1, 1, 1, 1},
+	})
+	titles := []charts.Title{
+		charts.KeyTitle("A"), charts.KeyTitle("B"), charts.KeyTitle("C"), charts.KeyTitle("D"),
+	}
+
+	for _, c := range []struct {
+		name   string
+		charts charts.Charts
+		step   int
+		corr   [][]float64
+	}{
+		{
+			"days",
+			c, 1,
+			[][]float64{
+				{1, 1, -.8, 0},
+				{1, 1, -.8, 0},
+				{-.8, -.8, 1, 0},
+				{0, 0, 0, 0},
+			},
+		},
+		{
+			"bins of two days",
+			c, 2,
+			[][]float64{
+				{1, 1, 1, 0},
+				{1, 1, 1, 0},
+				{1, 1, 1, 0},
+				{0, 0, 0, 0},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			corr, err := charts.Correlations(context.Background(), c.charts, titles, c.step)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			for i := range c.corr {
+				for j := range c.corr[i] {
+					if math.Abs(corr[i][j]-c.corr[i][j]) > 1e-9 {
+						t.Errorf("correlation of %v and %v: has %v, want %v", i, j, corr[i][j], c.corr[i][j])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClusters(t *testing.T) {
+	corr := [][]float64{
+		{1, .9, .1, .0, .8},
+		{.9, 1, .2, .1, .7},
+		{.1, .2, 1, .9, .0},
+		{.0, .1, .9, 1, .1},
+		{.8, .7, .0, .1, 1},
+	}
+
+	for _, c := range []struct {
+		name     string
+		k        int
+		clusters [][]int
+	}{
+		{"two", 2, [][]int{{0, 1, 4}, {2, 3}}},
+		{"three", 3, [][]int{{0, 1}, {2, 3}, {4}}},
+		{"one", 1, [][]int{{0, 1, 2, 3, 4}}},
+		{"more than titles", 7, [][]int{{0}, {1}, {2}, {3}, {4}}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			clusters := charts.Clusters(corr, c.k)
+			if !reflect.DeepEqual(clusters, c.clusters) {
+				t.Errorf("has %v, want %v", clusters, c.clusters)
+			}
+		})
+	}
+}
+
+func TestFingerprints(t *testing.T) {
+	c := charts.InOrder([]charts.Pair{
+		{Title: charts.KeyTitle("A"), Values: []float64{1, 0, 2}},
+		{Title: charts.KeyTitle("B"), Values: []float64{1, 0, 2}},
+		{Title: charts.KeyTitle("C"), Values: []float64{1, 2, 0}},
+	})
+	longer := charts.InOrder([]charts.Pair{
+		{Title: charts.KeyTitle("A"), Values: []float64{1, 0, 2, 0}},
+	})
+
+	prints, err := charts.Fingerprints(context.Background(), c, c.Titles())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	more, err := charts.Fingerprints(context.Background(), longer, longer.Titles())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if prints[0] != prints[1] {
+		t.Error("equal values must have the same fingerprint")
+	}
+	if prints[0] == prints[2] {
+		t.Error("reordered values must have a different fingerprint")
+	}
+	if prints[0] == more[0] {
+		t.Error("an additional day must change the fingerprint")
+	}
+}
@@ -0,0 +1,181 @@
+package charts
+
+import (
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+)
+
+type credit struct {
+	title Title
+	value float64
+}
+
+// Credits splits artist names of collaborations like "A feat. B" or "A & B"
+// into the credited artists. Names in the exceptions, e.g. bands whose names
+// contain "&", are never split. If Fractional is set, a play is shared equally
+// between the credited artists, otherwise each of them gets the full play.
+type Credits struct {
+	Fractional bool
+	exceptions map[string]bool
+}
+
+// NewCredits returns Credits with a list of exceptions. The exceptions are
+// matched ignoring case.
+func NewCredits(exceptions []string, fractional bool) Credits {
+	c := Credits{
+		Fractional: fractional,
+		exceptions: make(map[string]bool, len(exceptions)),
+	}
+	for _, name := range exceptions {
+		c.exceptions[foldASCII(name)] = true
+	}
+	return c
+}
+
+var featureMarkers = []string{
+	" (feat. ", " (ft. ", " (featuring ",
+	" feat. ", " feat ", " ft. ", " ft ", " featuring ",
+}
+
+var creditSeparators = []string{" & ", ", ", " vs. ", " vs "}
+
+// Split returns the artists that are credited in an artist name. The main
+// artists come first, followed by the featured ones.
+func (c Credits) Split(artist string) []string {
+	if c.exceptions[foldASCII(artist)] {
+		return []string{artist}
+	}
+
+	main, featured := cutFeature(artist)
+	names := c.splitNames(main)
+	if featured != "" {
+		names = append(names, c.splitNames(featured)...)
+	}
+
+	unique := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+	if len(unique) == 0 {
+		return []string{artist}
+	}
+	return unique
+}
+
+func (c Credits) credits(key func(string) Title, value func(info.Song) float64,
+) func(info.Song) []credit {
+	return func(s info.Song) []credit {
+		names := c.Split(s.Artist)
+		v := value(s)
+		if c.Fractional {
+			v /= float64(len(names))
+		}
+
+		credits := make([]credit, len(names))
+		for i, name := range names {
+			credits[i] = credit{key(name), v}
+		}
+		return credits
+	}
+}
+
+// cutFeature separates the featured artists from the main ones.
+func cutFeature(artist string) (main, featured string) {
+	folded := foldASCII(artist)
+	idx, length := -1, 0
+	for _, marker := range featureMarkers {
+		if i := strings.Index(folded, marker); i >= 0 && (idx < 0 || i < idx) {
+			idx, length = i, len(marker)
+		}
+	}
+	if idx < 0 {
+		return artist, ""
+	}
+
+	main = artist[:idx]
+	featured = artist[idx+length:]
+	if strings.HasPrefix(artist[idx:], " (") {
+		featured = strings.TrimSuffix(featured, ")")
+	}
+	return strings.TrimSpace(main), strings.TrimSpace(featured)
+}
+
+// splitNames splits a list of artists at the separators. Consecutive parts
+// that form an exception are kept together.
+func (c Credits) splitNames(s string) []string {
+	if c.exceptions[foldASCII(s)] {
+		return []string{s}
+	}
+
+	parts, seps := []string{}, []string{}
+	rest := s
+	for {
+		folded := foldASCII(rest)
+		idx, sep := -1, ""
+		for _, separator := range creditSeparators {
+			if i := strings.Index(folded, separator); i >= 0 && (idx < 0 || i < idx) {
+				idx, sep = i, separator
+			}
+		}
+		if idx < 0 {
+			parts = append(parts, rest)
+			break
+		}
+		parts = append(parts, rest[:idx])
+		seps = append(seps, rest[idx:idx+len(sep)])
+		rest = rest[idx+len(sep):]
+	}
+
+	names := []string{}
+	for i := 0; i < len(parts); {
+		j := len(parts) - 1
+		for ; j > i; j-- {
+			joined := parts[i]
+			for k := i + 1; k <= j; k++ {
+				joined += seps[k-1] + parts[k]
+			}
+			if c.exceptions[foldASCII(joined)] {
+				names = append(names, strings.TrimSpace(joined))
+				break
+			}
+		}
+		if j == i {
+			names = append(names, strings.TrimSpace(parts[i]))
+		}
+		i = j + 1
+	}
+	return names
+}
+
+// foldASCII converts ASCII letters to lower case. Unlike strings.ToLower it
+// keeps the byte offsets intact.
+func foldASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if c >= 'A' && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+	return string(b)
+}
+
+// ArtistsCredited compiles charts like Artists in which the plays of
+// collaborations count for each credited artist.
+func ArtistsCredited(songs [][]info.Song, credits Credits) Charts {
+	c := new(func() ([][]info.Song, error) { return songs, nil }, nil, nil)
+	c.credits = credits.credits(ArtistTitle, func(s info.Song) float64 { return 1.0 })
+	return c
+}
+
+// ArtistsDurationCredited compiles charts like ArtistsDuration in which the
+// plays of collaborations count for each credited artist.
+func ArtistsDurationCredited(songs [][]info.Song, credits Credits) Charts {
+	c := new(func() ([][]info.Song, error) { return songs, nil }, nil, nil)
+	c.credits = credits.credits(ArtistTitle, fDuration)
+	return c
+}
@@ -0,0 +1,42 @@
+package charts_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestCreditsSplit(t *testing.T) {
+	credits := charts.NewCredits([]string{"Simon & Garfunkel", "Earth, Wind & Fire"}, false)
+
+	cases := []struct {
+		artist  string
+		artists []string
+	}{
+		{"A", []string{"A"}},
+		{"A feat. B", []string{"A", "B"}},
+		{"A Feat. B & C", []string{"A", "B", "C"}},
+		{"A ft. B", []string{"A", "B"}},
+		{"A featuring B", []string{"A", "B"}},
+		{"A (feat. B)", []string{"A", "B"}},
+		{"A & B", []string{"A", "B"}},
+		{"A, B & C", []string{"A", "B", "C"}},
+		{"A vs. B", []string{"A", "B"}},
+		{"A & A", []string{"A"}},
+		{"A ft", []string{"A ft"}},
+		{"Simon & Garfunkel", []string{"Simon & Garfunkel"}},
+		{"simon & garfunkel", []string{"simon & garfunkel"}},
+		{"Simon & Garfunkel feat. A", []string{"Simon & Garfunkel", "A"}},
+		{"A & Earth, Wind & Fire", []string{"A", "Earth, Wind & Fire"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.artist, func(t *testing.T) {
+			artists := credits.Split(c.artist)
+			if !reflect.DeepEqual(artists, c.artists) {
+				t.Errorf("wrong artists:\n has:  %v\nwant: %v", artists, c.artists)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package charts
 
-import "sort"
+import (
+	"context"
+	"sort"
+)
 
 type DiffCharts interface {
 	// Data(titles []Title, begin, end int) ([][]float64, error)
@@ -10,7 +13,7 @@ type DiffCharts interface {
 
 	Charts
 
-	Previous(title Title) (place int, value float64, err error)
+	Previous(ctx context.Context, title Title) (place int, value float64, err error)
 }
 
 type diffCharts struct {
@@ -30,8 +33,8 @@ func NewDiffCharts(c Charts, prevIdx int) DiffCharts {
 	}
 }
 
-func (l *diffCharts) Data(titles []Title, begin, end int) ([][]float64, error) {
-	return l.c.Data(titles, begin, end)
+func (l *diffCharts) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
+	return l.c.Data(ctx, titles, begin, end)
 }
 
 func (l *diffCharts) Titles() []Title {
@@ -42,14 +45,14 @@ func (l *diffCharts) Len() int {
 	return l.c.Len()
 }
 
-func (l *diffCharts) Previous(title Title) (place int, value float64, err error) {
+func (l *diffCharts) Previous(ctx context.Context, title Title) (place int, value float64, err error) {
 	titles := l.Titles()
 	if l.prev == nil {
 		l.prev = make([]struct {
 			title Title
 			value float64
 		}, len(titles))
-		if prevData, err := l.c.Data(titles, l.prevIdx, l.prevIdx+1); err != nil {
+		if prevData, err := l.c.Data(ctx, titles, l.prevIdx, l.prevIdx+1); err != nil {
 			return -1, 0, err
 		} else {
 			for i, prev := range prevData {
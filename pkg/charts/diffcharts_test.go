@@ -1,6 +1,7 @@
 package charts_test
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -53,8 +54,8 @@ func TestDiffCharts(t *testing.T) {
 					}
 				}
 
-				expect, _ := c.charts.Data(ch.Titles(), 0, ch.Len())
-				actual, err := ch.Data(ch.Titles(), 0, ch.Len())
+				expect, _ := c.charts.Data(context.Background(), ch.Titles(), 0, ch.Len())
+				actual, err := ch.Data(context.Background(), ch.Titles(), 0, ch.Len())
 				if err != nil {
 					t.Errorf("data: %v", err)
 				} else if !reflect.DeepEqual(actual, expect) {
@@ -62,7 +63,7 @@ func TestDiffCharts(t *testing.T) {
 				}
 
 				for _, title := range ch.Titles() {
-					if place, value, err := ch.Previous(title); err != nil {
+					if place, value, err := ch.Previous(context.Background(), title); err != nil {
 						t.Errorf("previous: %v", err)
 					} else {
 						for _, prev := range c.prev {
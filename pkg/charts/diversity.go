@@ -0,0 +1,113 @@
+package charts
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// diversityColumns is the number of columns that are loaded from the parent at
+// once. It limits the memory that is needed for charts with many titles.
+const diversityColumns = 64
+
+type diversity struct {
+	chartsNode
+	n int
+}
+
+// Diversity computes per column how evenly the values of the parent are spread
+// across its titles. Titles with values that are not positive are ignored. The
+// titles of the resulting charts are the measures:
+//   - "entropy": Shannon entropy of the shares in bits
+//   - "gini": Gini coefficient, 0 if all are equal, close to 1 if one dominates
+//   - "effective": effective number of titles, i.e. 2 to the power of entropy
+//   - "top<n>": combined share of the n titles with the highest values
+func Diversity(parent Charts, n int) Charts {
+	return &diversity{chartsNode: chartsNode{parent: parent}, n: n}
+}
+
+func (c *diversity) Titles() []Title {
+	return []Title{
+		KeyTitle("entropy"),
+		KeyTitle("gini"),
+		KeyTitle("effective"),
+		KeyTitle(fmt.Sprintf("top%v", c.n)),
+	}
+}
+
+func (c *diversity) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
+	measures := map[string][]float64{}
+	for _, title := range c.Titles() {
+		measures[title.Key()] = make([]float64, end-begin)
+	}
+
+	parentTitles := c.parent.Titles()
+	for b := begin; b < end; b += diversityColumns {
+		e := b + diversityColumns
+		if e > end {
+			e = end
+		}
+		data, err := c.parent.Data(ctx, parentTitles, b, e)
+		if err != nil {
+			return nil, err
+		}
+
+		values := make([]float64, 0, len(data))
+		for j := 0; j < e-b; j++ {
+			values = values[:0]
+			for _, line := range data {
+				if line[j] > 0 {
+					values = append(values, line[j])
+				}
+			}
+			entropy, gini, effective, top := diversityOf(values, c.n)
+			measures["entropy"][b-begin+j] = entropy
+			measures["gini"][b-begin+j] = gini
+			measures["effective"][b-begin+j] = effective
+			measures[fmt.Sprintf("top%v", c.n)][b-begin+j] = top
+		}
+	}
+
+	result := make([][]float64, len(titles))
+	for i, title := range titles {
+		if line, ok := measures[title.Key()]; ok {
+			result[i] = line
+		} else {
+			result[i] = make([]float64, end-begin)
+		}
+	}
+	return result, nil
+}
+
+// diversityOf computes the entropy, the Gini coefficient, the effective number
+// and the share of the n highest of positive values. values are sorted in the
+// process.
+func diversityOf(values []float64, n int) (entropy, gini, effective, top float64) {
+	if len(values) == 0 {
+		return 0, 0, 0, 0
+	}
+	sort.Float64s(values)
+
+	sum := 0.0
+	weighted := 0.0
+	for i, v := range values {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+
+	for _, v := range values {
+		p := v / sum
+		entropy -= p * math.Log2(p)
+	}
+
+	m := float64(len(values))
+	gini = 2*weighted/(m*sum) - (m+1)/m
+
+	for i := len(values) - 1; i >= 0 && i >= len(values)-n; i-- {
+		top += values[i]
+	}
+	top /= sum
+
+	return entropy, gini, math.Pow(2, entropy), top
+}
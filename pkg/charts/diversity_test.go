@@ -0,0 +1,54 @@
+package charts_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestDiversity(t *testing.T) {
+	long := map[string][]float64{"A": make([]float64, 100), "B": make([]float64, 100)}
+	longExpect := []charts.Pair{
+		{Title: charts.KeyTitle("entropy"), Values: make([]float64, 100)},
+		{Title: charts.KeyTitle("gini"), Values: make([]float64, 100)},
+		{Title: charts.KeyTitle("effective"), Values: make([]float64, 100)},
+		{Title: charts.KeyTitle("top1"), Values: make([]float64, 100)},
+	}
+	for i := range long["A"] {
+		long["A"][i], long["B"][i] = 1, 1
+		longExpect[0].Values[i] = 1
+		longExpect[2].Values[i] = 2
+		longExpect[3].Values[i] = .5
+	}
+
+	for _, c := range []struct {
+		name           string
+		actual, expect charts.Charts
+	}{
+		{
+			"columns",
+			charts.Diversity(charts.FromMap(map[string][]float64{
+				"A": {1, 4, 0, 1},
+				"B": {1, 0, 0, 1},
+				"C": {2, 0, 0, 1},
+				"D": {0, 0, 0, 1},
+			}), 2),
+			charts.InOrder([]charts.Pair{
+				{Title: charts.KeyTitle("entropy"), Values: []float64{1.5, 0, 0, 2}},
+				{Title: charts.KeyTitle("gini"), Values: []float64{1.0 / 6, 0, 0, 0}},
+				{Title: charts.KeyTitle("effective"), Values: []float64{math.Pow(2, 1.5), 1, 0, 4}},
+				{Title: charts.KeyTitle("top2"), Values: []float64{.75, 1, 0, .5}},
+			}),
+		},
+		{
+			"more columns than are loaded at once",
+			charts.Diversity(charts.FromMap(long), 1),
+			charts.InOrder(longExpect),
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			checkLazyCharts(t, c.expect, c.actual, 5)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package charts
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// Era is a span of columns in which the same titles dominated, from Begin to
+// End exclusively. Titles are the titles with the highest mean share in the
+// era, Shares are those shares.
+type Era struct {
+	Begin, End int
+	Titles     []Title
+	Shares     []float64
+}
+
+// Eras segments c into eras. Each column is compared to the mean shares of the
+// titles in the current era, a new era begins once their cosine similarity
+// falls below similarity and the current era is at least minLen columns long.
+// Columns without values belong to the current era. Up to n titles with the
+// highest shares define each era.
+func Eras(ctx context.Context, c Charts, similarity float64, minLen, n int) ([]Era, error) {
+	titles := c.Titles()
+	data, err := c.Data(ctx, titles, 0, c.Len())
+	if err != nil {
+		return nil, err
+	}
+
+	eras := []Era{}
+	profile := make([]float64, len(titles))
+	begin := 0
+	for j := 0; j < c.Len(); j++ {
+		shares := make([]float64, len(titles))
+		sum := 0.0
+		for i := range titles {
+			sum += data[i][j]
+		}
+		if sum > 0 {
+			for i := range titles {
+				shares[i] = data[i][j] / sum
+			}
+		}
+
+		if sum > 0 && j > begin && j-begin >= minLen && cosine(shares, profile) < similarity {
+			eras = append(eras, newEra(titles, profile, begin, j, n))
+			profile = make([]float64, len(titles))
+			begin = j
+		}
+		for i := range titles {
+			profile[i] += shares[i]
+		}
+	}
+	if c.Len() > begin {
+		eras = append(eras, newEra(titles, profile, begin, c.Len(), n))
+	}
+	return eras, nil
+}
+
+func cosine(a, b []float64) float64 {
+	dot, na, nb := 0.0, 0.0, 0.0
+	for i := range a {
+		dot += a[i] * b[i]
+		na += a[i] * a[i]
+		nb += b[i] * b[i]
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func newEra(titles []Title, profile []float64, begin, end, n int) Era {
+	idxs := []int{}
+	for i, v := range profile {
+		if v > 0 {
+			idxs = append(idxs, i)
+		}
+	}
+	sort.SliceStable(idxs, func(a, b int) bool {
+		return profile[idxs[a]] > profile[idxs[b]]
+	})
+	if len(idxs) > n {
+		idxs = idxs[:n]
+	}
+
+	era := Era{Begin: begin, End: end, Titles: []Title{}, Shares: []float64{}}
+	for _, i := range idxs {
+		era.Titles = append(era.Titles, titles[i])
+		era.Shares = append(era.Shares, profile[i]/float64(end-begin))
+	}
+	return era
+}
@@ -0,0 +1,84 @@
+package charts_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestEras(t *testing.T) {
+	c := charts.InOrder([]charts.Pair{
+		{Title: charts.KeyTitle("A"), Values: []float64{3, 3, 0, 3, 0, 0, 0}},
+		{Title: charts.KeyTitle("B"), Values: []float64{1, 1, 0, 0, 0, 1, 0}},
+		{Title: charts.KeyTitle("C"), Values: []float64{0, 0, 0, 0, 4, 3, 4}},
+	})
+
+	type era struct {
+		begin, end int
+		titles     []string
+		shares     []float64
+	}
+
+	for _, c := range []struct {
+		name   string
+		charts charts.Charts
+		minLen int
+		n      int
+		eras   []era
+	}{
+		{
+			"two eras",
+			c, 1, 2,
+			[]era{
+				{0, 4, []string{"A", "B"}, []float64{.625, .125}},
+				{4, 7, []string{"C", "B"}, []float64{11.0 / 12, 1.0 / 12}},
+			},
+		},
+		{
+			"minimum length",
+			c, 5, 1,
+			[]era{
+				{0, 5, []string{"A"}, []float64{.5}},
+				{5, 7, []string{"C"}, []float64{.875}},
+			},
+		},
+		{
+			"empty",
+			charts.FromMap(map[string][]float64{}), 1, 2,
+			[]era{},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			eras, err := charts.Eras(context.Background(), c.charts, .5, c.minLen, c.n)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			has := make([]era, len(eras))
+			for i, e := range eras {
+				has[i] = era{e.Begin, e.End, []string{}, e.Shares}
+				for _, title := range e.Titles {
+					has[i].titles = append(has[i].titles, title.String())
+				}
+			}
+			if len(has) != len(c.eras) {
+				t.Fatalf("has %v eras, want %v: %v", len(has), len(c.eras), has)
+			}
+			for i := range has {
+				if has[i].begin != c.eras[i].begin || has[i].end != c.eras[i].end ||
+					!reflect.DeepEqual(has[i].titles, c.eras[i].titles) {
+					t.Errorf("era %v: has %v, want %v", i, has[i], c.eras[i])
+				}
+				for j := range c.eras[i].shares {
+					if math.Abs(has[i].shares[j]-c.eras[i].shares[j]) > 1e-9 {
+						t.Errorf("era %v: has shares %v, want %v", i, has[i].shares, c.eras[i].shares)
+						break
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package charts
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nilsbu/async"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// GenreTree is a hierarchy of genres in which each genre has at most one
+// parent. Genres are lower case tags.
+type GenreTree struct {
+	parents  map[string]string
+	children map[string][]string
+}
+
+// NewGenreTree builds a genre tree from a map of genres to their parents.
+// Genres that are their own parent, like supertags, are at the top. An error is
+// returned if the hierarchy contains a cycle.
+func NewGenreTree(parents map[string]string) (*GenreTree, error) {
+	t := &GenreTree{
+		parents:  map[string]string{},
+		children: map[string][]string{},
+	}
+	for genre, parent := range parents {
+		if genre != parent {
+			t.parents[genre] = parent
+		}
+	}
+
+	for genre := range t.parents {
+		seen := map[string]bool{genre: true}
+		for g, ok := t.parents[genre]; ok; g, ok = t.parents[g] {
+			if seen[g] {
+				return nil, fmt.Errorf("genre '%v' is a subgenre of itself", genre)
+			}
+			seen[g] = true
+		}
+	}
+
+	roots := map[string]bool{}
+	for genre, parent := range t.parents {
+		t.children[parent] = append(t.children[parent], genre)
+		if _, ok := t.parents[parent]; !ok {
+			roots[parent] = true
+		}
+	}
+	for root := range roots {
+		t.children[""] = append(t.children[""], root)
+	}
+	for _, children := range t.children {
+		sort.Strings(children)
+	}
+	return t, nil
+}
+
+// Contains returns whether a genre is part of the tree.
+func (t *GenreTree) Contains(genre string) bool {
+	if _, ok := t.parents[genre]; ok {
+		return true
+	}
+	_, ok := t.children[genre]
+	return ok && genre != ""
+}
+
+// Children returns the direct subgenres of a genre in alphabetical order. The
+// children of "" are the genres at the top of the hierarchy.
+func (t *GenreTree) Children(genre string) []string {
+	return t.children[genre]
+}
+
+// Path returns the genres from the top of the hierarchy down to genre.
+func (t *GenreTree) Path(genre string) []string {
+	path := []string{genre}
+	for g, ok := t.parents[genre]; ok; g, ok = t.parents[g] {
+		path = append([]string{g}, path...)
+	}
+	return path
+}
+
+// Genre returns the first of the tags that is a genre in the tree. If none of
+// them is, "" is returned.
+func (t *GenreTree) Genre(tags []string) string {
+	for _, tag := range tags {
+		if name := strings.ToLower(tag); t.Contains(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// Level returns the genre directly below root that genre belongs to. If genre
+// is root, root is returned. The root "" is above the top of the hierarchy.
+// Genres that are not below root yield false.
+func (t *GenreTree) Level(genre, root string) (string, bool) {
+	if genre == "" {
+		return "", false
+	}
+	path := t.Path(genre)
+	if root == "" {
+		return path[0], true
+	}
+	for i, g := range path {
+		if g == root {
+			if i == len(path)-1 {
+				return root, true
+			}
+			return path[i+1], true
+		}
+	}
+	return "", false
+}
+
+// GenreNode is a genre with the plays of the artists in it. Plays are those of
+// artists that belong to the genre itself, Total includes all subgenres.
+type GenreNode struct {
+	Genre        string
+	Plays, Total float64
+	Children     []GenreNode
+}
+
+// Sum arranges the plays per genre in the subtree below root. Only depth levels
+// below root are shown, the plays of deeper genres are added to their
+// ancestor on the last level. Genres without plays are left out and children
+// are sorted by their total. For the root "", plays of artists without genre
+// are listed under "-".
+func (t *GenreTree) Sum(plays map[string]float64, root string, depth int) GenreNode {
+	node := t.sum(plays, root, depth)
+	if root == "" {
+		node.Plays = 0
+		if v := plays[""]; v > 0 {
+			node.Children = append(node.Children, GenreNode{Genre: "-", Plays: v, Total: v, Children: []GenreNode{}})
+		}
+		node.Total = 0
+		for _, child := range node.Children {
+			node.Total += child.Total
+		}
+	}
+	sortGenreNodes(node.Children)
+	return node
+}
+
+func (t *GenreTree) sum(plays map[string]float64, genre string, depth int) GenreNode {
+	node := GenreNode{Genre: genre, Plays: plays[genre], Children: []GenreNode{}}
+	node.Total = node.Plays
+	for _, child := range t.children[genre] {
+		c := t.sum(plays, child, depth-1)
+		if c.Total == 0 {
+			continue
+		}
+		if depth > 0 {
+			node.Children = append(node.Children, c)
+		} else {
+			node.Plays += c.Total
+		}
+		node.Total += c.Total
+	}
+	sortGenreNodes(node.Children)
+	return node
+}
+
+func sortGenreNodes(nodes []GenreNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Total != nodes[j].Total {
+			return nodes[i].Total > nodes[j].Total
+		}
+		return nodes[i].Genre < nodes[j].Genre
+	})
+}
+
+type genrePartition struct {
+	titles      func() []Title
+	tree        *GenreTree
+	root        string
+	corrections map[string]string
+	r           rsrc.Reader
+
+	once       sync.Once
+	partitions map[string][]Title
+}
+
+// GenrePartition partitions artists by the genres directly below root in the
+// genre tree. An artist's genre is the first of its tags that is in the tree,
+// unless it is corrected. Artists that belong to root itself form the
+// partition root. For the root "", artists without a genre are in "-", for
+// other roots, artists outside of the subtree aren't part of any partition.
+func GenrePartition(parent Charts, tree *GenreTree, root string,
+	corrections map[string]string, r rsrc.Reader) Partition {
+	return &genrePartition{
+		titles:      parent.Titles,
+		tree:        tree,
+		root:        root,
+		corrections: corrections,
+		r:           r,
+	}
+}
+
+func (p *genrePartition) assign() {
+	titles := p.titles()
+	genres := make([]string, len(titles))
+	loader := unpack.NewCacheless(p.r)
+
+	// Artists whose tags cannot be loaded have no genre, like in tagPartition.
+	async.Pie(len(titles), func(i int) error {
+		if genre, ok := p.corrections[titles[i].Artist()]; ok && p.tree.Contains(genre) {
+			genres[i] = genre
+			return nil
+		}
+		tags, err := unpack.LoadArtistTags(titles[i].Artist(), loader)
+		if err != nil {
+			return err
+		}
+		names := make([]string, len(tags))
+		for j, tag := range tags {
+			names[j] = tag.Name
+		}
+		genres[i] = p.tree.Genre(names)
+		return nil
+	})
+
+	p.partitions = map[string][]Title{}
+	for i, title := range titles {
+		if level, ok := p.tree.Level(genres[i], p.root); ok {
+			p.partitions[level] = append(p.partitions[level], title)
+		} else if p.root == "" {
+			p.partitions["-"] = append(p.partitions["-"], title)
+		}
+	}
+}
+
+func (p *genrePartition) Titles(partition Title) ([]Title, error) {
+	p.once.Do(p.assign)
+	titles := p.partitions[partition.Key()]
+	if titles == nil {
+		titles = []Title{}
+	}
+	return titles, nil
+}
+
+func (p *genrePartition) Partitions() ([]Title, error) {
+	partitions := []Title{}
+	for _, genre := range p.tree.Children(p.root) {
+		partitions = append(partitions, KeyTitle(genre))
+	}
+	if p.root == "" {
+		partitions = append(partitions, KeyTitle("-"))
+	} else {
+		partitions = append(partitions, KeyTitle(p.root))
+	}
+	return partitions, nil
+}
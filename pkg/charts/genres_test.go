@@ -0,0 +1,151 @@
+package charts_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestGenreTree(t *testing.T) {
+	if _, err := charts.NewGenreTree(map[string]string{"a": "b", "b": "c", "c": "a"}); err == nil {
+		t.Error("expected error for cyclic genres")
+	}
+
+	tree, err := charts.NewGenreTree(map[string]string{
+		"metal":                   "metal",
+		"black metal":             "metal",
+		"atmospheric black metal": "black metal",
+		"death metal":             "metal",
+		"house":                   "electronic",
+	})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if children := tree.Children(""); !reflect.DeepEqual(children, []string{"electronic", "metal"}) {
+		t.Errorf("wrong top genres: %v", children)
+	}
+	if path := tree.Path("atmospheric black metal"); !reflect.DeepEqual(path,
+		[]string{"metal", "black metal", "atmospheric black metal"}) {
+		t.Errorf("wrong path: %v", path)
+	}
+	if genre := tree.Genre([]string{"german", "Black Metal", "metal"}); genre != "black metal" {
+		t.Errorf("wrong genre: '%v'", genre)
+	}
+
+	for _, c := range []struct {
+		genre, root, level string
+		ok                 bool
+	}{
+		{"atmospheric black metal", "", "metal", true},
+		{"atmospheric black metal", "metal", "black metal", true},
+		{"black metal", "black metal", "black metal", true},
+		{"house", "metal", "", false},
+		{"", "", "", false},
+	} {
+		level, ok := tree.Level(c.genre, c.root)
+		if level != c.level || ok != c.ok {
+			t.Errorf("level of '%v' below '%v': has '%v', %v; want '%v', %v",
+				c.genre, c.root, level, ok, c.level, c.ok)
+		}
+	}
+
+	sum := tree.Sum(map[string]float64{
+		"atmospheric black metal": 3,
+		"black metal":             2,
+		"metal":                   1,
+		"house":                   4,
+		"":                        5,
+	}, "", 2)
+	expected := charts.GenreNode{Genre: "", Total: 15, Children: []charts.GenreNode{
+		{Genre: "metal", Plays: 1, Total: 6, Children: []charts.GenreNode{
+			{Genre: "black metal", Plays: 5, Total: 5, Children: []charts.GenreNode{}},
+		}},
+		{Genre: "-", Plays: 5, Total: 5, Children: []charts.GenreNode{}},
+		{Genre: "electronic", Plays: 0, Total: 4, Children: []charts.GenreNode{
+			{Genre: "house", Plays: 4, Total: 4, Children: []charts.GenreNode{}},
+		}},
+	}}
+	if !reflect.DeepEqual(sum, expected) {
+		t.Errorf("wrong sum:\nhas:  %v\nwant: %v", sum, expected)
+	}
+}
+
+func TestGenrePartition(t *testing.T) {
+	tree, _ := charts.NewGenreTree(map[string]string{
+		"black metal":             "metal",
+		"atmospheric black metal": "black metal",
+		"death metal":             "metal",
+	})
+
+	r, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.ArtistTags("A"): []byte(`{"toptags":{"tag":[{"name":"Atmospheric Black Metal","count":100}]}}`),
+		rsrc.ArtistTags("B"): []byte(`{"toptags":{"tag":[{"name":"german","count":100},{"name":"death metal","count":50}]}}`),
+		rsrc.ArtistTags("C"): []byte(`{"toptags":{"tag":[{"name":"metal","count":100}]}}`),
+		rsrc.ArtistTags("D"): []byte(`{"toptags":{"tag":[{"name":"pop","count":100}]}}`),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+
+	artists := func(names ...string) []charts.Title {
+		titles := []charts.Title{}
+		for _, name := range names {
+			titles = append(titles, charts.ArtistTitle(name))
+		}
+		return titles
+	}
+	pairs := []charts.Pair{}
+	for _, title := range artists("A", "B", "C", "D") {
+		pairs = append(pairs, charts.Pair{Title: title, Values: []float64{1}})
+	}
+	parent := charts.InOrder(pairs)
+
+	for _, c := range []struct {
+		name            string
+		root            string
+		corrections     map[string]string
+		partitionTitles []partitionTitles
+		partitions      []charts.Title
+	}{
+		{
+			"top",
+			"",
+			map[string]string{},
+			[]partitionTitles{
+				{charts.KeyTitle("metal"), artists("A", "B", "C")},
+				{charts.KeyTitle("-"), artists("D")},
+			},
+			[]charts.Title{charts.KeyTitle("metal"), charts.KeyTitle("-")},
+		},
+		{
+			"metal",
+			"metal",
+			map[string]string{"D": "black metal"},
+			[]partitionTitles{
+				{charts.KeyTitle("black metal"), artists("A", "D")},
+				{charts.KeyTitle("death metal"), artists("B")},
+				{charts.KeyTitle("metal"), artists("C")},
+			},
+			[]charts.Title{charts.KeyTitle("black metal"), charts.KeyTitle("death metal"), charts.KeyTitle("metal")},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			p := charts.GenrePartition(parent, tree, c.root, c.corrections, r)
+			for _, pt := range c.partitionTitles {
+				titles, _ := p.Titles(pt.partition)
+				if !areTitlesSame(pt.titles, titles) {
+					t.Errorf("for partition '%v', titles unequal: %v != %v", pt.partition, pt.titles, titles)
+				}
+			}
+
+			partitions, _ := p.Partitions()
+			if !areTitlesSame(c.partitions, partitions) {
+				t.Errorf("partitions unequal: %v != %v", c.partitions, partitions)
+			}
+		})
+	}
+}
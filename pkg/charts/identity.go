@@ -0,0 +1,71 @@
+package charts
+
+import "github.com/nilsbu/lastfm/pkg/info"
+
+// IdentifyByMBID returns a copy of songs in which all artists, songs and albums
+// that share a MusicBrainz ID carry the same name, so that charts group them
+// by ID. The name of an ID is the one it was played with most often. Songs
+// without ID keep their names, i.e. they are grouped by name.
+func IdentifyByMBID(songs [][]info.Song) [][]info.Song {
+	artists := nameVotes{}
+	tracks := nameVotes{}
+	albums := nameVotes{}
+	for _, day := range songs {
+		for _, song := range day {
+			artists.add(song.ArtistMBID, song.Artist)
+			tracks.add(song.TrackMBID, song.Title)
+			albums.add(song.AlbumMBID, song.Album)
+		}
+	}
+
+	artistNames := artists.names()
+	trackNames := tracks.names()
+	albumNames := albums.names()
+
+	identified := make([][]info.Song, len(songs))
+	for i, day := range songs {
+		identified[i] = make([]info.Song, len(day))
+		for j, song := range day {
+			if name, ok := artistNames[song.ArtistMBID]; ok {
+				song.Artist = name
+			}
+			if name, ok := trackNames[song.TrackMBID]; ok {
+				song.Title = name
+			}
+			if name, ok := albumNames[song.AlbumMBID]; ok {
+				song.Album = name
+			}
+			identified[i][j] = song
+		}
+	}
+
+	return identified
+}
+
+type nameVotes map[string]map[string]int
+
+func (v nameVotes) add(mbid, name string) {
+	if mbid == "" {
+		return
+	}
+	if _, ok := v[mbid]; !ok {
+		v[mbid] = map[string]int{}
+	}
+	v[mbid][name]++
+}
+
+// names returns the most frequent name of every ID. Ties are broken
+// alphabetically so that the result doesn't depend on the order of plays.
+func (v nameVotes) names() map[string]string {
+	names := make(map[string]string, len(v))
+	for mbid, counts := range v {
+		best, bestCount := "", 0
+		for name, count := range counts {
+			if count > bestCount || (count == bestCount && name < best) {
+				best, bestCount = name, count
+			}
+		}
+		names[mbid] = best
+	}
+	return names
+}
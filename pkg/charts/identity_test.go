@@ -0,0 +1,44 @@
+package charts_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/info"
+)
+
+func TestIdentifyByMBID(t *testing.T) {
+	songs := [][]info.Song{
+		{
+			{Artist: "Beyonce", Title: "Halo", ArtistMBID: "b", TrackMBID: "h"},
+			{Artist: "Beyoncé", Title: "Halo", ArtistMBID: "b", TrackMBID: "h"},
+		},
+		{
+			{Artist: "Beyoncé", Title: "HALO", ArtistMBID: "b", TrackMBID: "h", Album: "I Am", AlbumMBID: "i"},
+			{Artist: "Beyonce", Title: "Single Ladies"},
+			{Artist: "X", Title: "x", Album: "I am...", AlbumMBID: "i"},
+		},
+	}
+
+	expected := [][]info.Song{
+		{
+			{Artist: "Beyoncé", Title: "Halo", ArtistMBID: "b", TrackMBID: "h"},
+			{Artist: "Beyoncé", Title: "Halo", ArtistMBID: "b", TrackMBID: "h"},
+		},
+		{
+			{Artist: "Beyoncé", Title: "Halo", ArtistMBID: "b", TrackMBID: "h", Album: "I Am", AlbumMBID: "i"},
+			{Artist: "Beyonce", Title: "Single Ladies"},
+			{Artist: "X", Title: "x", Album: "I Am", AlbumMBID: "i"},
+		},
+	}
+
+	identified := charts.IdentifyByMBID(songs)
+	if !reflect.DeepEqual(identified, expected) {
+		t.Errorf("wrong songs:\n has: %v\nwant: %v", identified, expected)
+	}
+
+	if songs[0][0].Artist != "Beyonce" {
+		t.Error("input was modified")
+	}
+}
@@ -1,13 +1,13 @@
 package charts
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
 	"time"
 
-	"github.com/nilsbu/async"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
 
@@ -134,11 +134,11 @@ func (c interval) Len() int {
 	return c.end - c.begin
 }
 
-func (c interval) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (c interval) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	data := make([][]float64, len(titles))
 
-	err := async.Pie(len(titles), func(i int) error {
-		res, err := c.parent.Data([]Title{titles[i]}, begin+c.begin, end+c.begin)
+	err := pie(ctx, len(titles), func(i int) error {
+		res, err := c.parent.Data(ctx, []Title{titles[i]}, begin+c.begin, end+c.begin)
 		if err != nil {
 			return err
 		} else {
@@ -245,7 +245,7 @@ func (c intervals) Len() int {
 	return len(c.delims) - 1
 }
 
-func (c intervals) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (c intervals) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	lines := make([][]float64, len(titles))
 	for j := range titles {
 		lines[j] = make([]float64, end-begin)
@@ -255,7 +255,7 @@ func (c intervals) Data(titles []Title, begin, end int) ([][]float64, error) {
 	// This may have to do with the fact that following calls are less "related", causing more cache misses.
 	for i := begin; i < end; i++ {
 		cha := c.f(Crop(c.parent, c.delims[i], c.delims[i+1]))
-		cdata, err := cha.Data(titles, cha.Len()-1, cha.Len())
+		cdata, err := cha.Data(ctx, titles, cha.Len()-1, cha.Len())
 		if err != nil {
 			return nil, err
 		} else {
@@ -1,9 +1,8 @@
 package charts
 
 import (
+	"context"
 	"math"
-
-	"github.com/nilsbu/async"
 )
 
 type lineMapCharts struct {
@@ -90,6 +89,33 @@ func Max(parent Charts) Charts {
 	}
 }
 
+// EMA is the exponential moving average of the parent charts. Span is the
+// number of days over which the average is taken, the weight of a new value
+// is 2/(span+1).
+func EMA(parent Charts, span float64) Charts {
+	alpha := 2 / (span + 1)
+	return &lineMapCharts{
+		chartsNode: chartsNode{parent: parent},
+		mapF: func(in []float64) []float64 {
+			out := make([]float64, len(in))
+			acc := 0.0
+			for i := range in {
+				acc = alpha*in[i] + (1-alpha)*acc
+				out[i] = acc
+			}
+			return out
+		},
+		foldF: func(i int, line []float64) float64 {
+			acc := 0.0
+			for j := 0; j <= i; j++ {
+				acc = alpha*line[j] + (1-alpha)*acc
+			}
+			return acc
+		},
+		rangeF: fromBeginRange,
+	}
+}
+
 // Gaussian blurs the data with a Gaussian kernel.
 func Gaussian(
 	parent Charts,
@@ -103,6 +129,37 @@ func Gaussian(
 		gaussian[i] = fac * math.Exp(-.5*float64(i*i)/sigma/sigma)
 	}
 
+	return convolve(parent, gaussian, mirrorBegin, mirrorEnd)
+}
+
+// Triangular blurs the data with a triangular kernel whose weights decrease
+// linearly up to a distance of width days.
+func Triangular(parent Charts, width int, mirrorBegin, mirrorEnd bool) Charts {
+	triangle := make([]float64, width+1)
+	fac := 1 / float64((width+1)*(width+1))
+	for i := 0; i <= width; i++ {
+		triangle[i] = fac * float64(width+1-i)
+	}
+
+	return convolve(parent, triangle, mirrorBegin, mirrorEnd)
+}
+
+// Box blurs the data with a box kernel, i.e. a centered moving average over
+// 2*width+1 days.
+func Box(parent Charts, width int, mirrorBegin, mirrorEnd bool) Charts {
+	box := make([]float64, width+1)
+	for i := range box {
+		box[i] = 1 / float64(2*width+1)
+	}
+
+	return convolve(parent, box, mirrorBegin, mirrorEnd)
+}
+
+// convolve applies a symmetric kernel to the data. kernel[i] is the weight of
+// values at a distance of i. Values beyond the beginning or end are mirrored
+// if the respective flag is set and dropped otherwise.
+func convolve(parent Charts, kernel []float64, mirrorBegin, mirrorEnd bool) Charts {
+	width := len(kernel) - 1
 	f := func(i int, line []float64) float64 {
 		acc := 0.0
 		b := i - width
@@ -113,7 +170,7 @@ func Gaussian(
 					ee = len(line)
 				}
 				for j := 0; j < ee; j++ {
-					acc += gaussian[i+j+1] * line[j]
+					acc += kernel[i+j+1] * line[j]
 				}
 			}
 
@@ -127,7 +184,7 @@ func Gaussian(
 					bb = 0
 				}
 				for j := len(line) - 1; j >= bb; j-- {
-					acc += gaussian[2*len(line)-(i+j+1)] * line[j]
+					acc += kernel[2*len(line)-(i+j+1)] * line[j]
 				}
 			}
 
@@ -139,7 +196,7 @@ func Gaussian(
 			if idx < 0 {
 				idx = -idx
 			}
-			acc += gaussian[idx] * line[j]
+			acc += kernel[idx] * line[j]
 		}
 		return acc
 	}
@@ -181,14 +238,14 @@ func Multiply(parent Charts, s float64) Charts {
 	}
 }
 
-func (l *lineMapCharts) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (l *lineMapCharts) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	data := make([][]float64, len(titles))
 	rb, re := l.rangeF(l.parent.Len(), begin, end)
 
 	var err error
 	if end-begin == 1 && false {
-		err = async.Pie(len(titles), func(i int) error {
-			if in, err := l.parent.Data([]Title{titles[i]}, rb, re); err != nil {
+		err = pie(ctx, len(titles), func(i int) error {
+			if in, err := l.parent.Data(ctx, []Title{titles[i]}, rb, re); err != nil {
 				return err
 			} else {
 				data[i] = []float64{l.foldF(end-1, in[0])}
@@ -196,8 +253,8 @@ func (l *lineMapCharts) Data(titles []Title, begin, end int) ([][]float64, error
 			}
 		})
 	} else {
-		err = async.Pie(len(titles), func(i int) error {
-			in, err := l.parent.Data([]Title{titles[i]}, rb, re)
+		err = pie(ctx, len(titles), func(i int) error {
+			in, err := l.parent.Data(ctx, []Title{titles[i]}, rb, re)
 			if err != nil {
 				return err
 			} else {
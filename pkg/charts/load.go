@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"io/fs"
 	"strings"
 
 	"github.com/nilsbu/async"
@@ -56,6 +57,8 @@ func (w *load) load() error {
 	var user *unpack.User
 	var bookmark rsrc.Day
 	var corrections map[string]string
+	var excluded map[unpack.ExcludedPlay]bool
+	var manual map[string]*unpack.ManualPlays
 
 	// TODO there's duplication with userLoad.span()
 	err := async.Pe([]func() error{
@@ -74,6 +77,16 @@ func (w *load) load() error {
 			bookmark, err = unpack.LoadBookmark(w.user, w.r)
 			return err
 		},
+		func() error {
+			var err error
+			excluded, err = LoadExcluded(w.user, w.r)
+			return err
+		},
+		func() error {
+			var err error
+			manual, err = LoadManual(w.user, w.r)
+			return err
+		},
 	})
 	if err != nil {
 		return err
@@ -84,6 +97,8 @@ func (w *load) load() error {
 	err = async.Pie(days, func(i int) error {
 		day := user.Registered.AddDate(0, 0, i)
 		if songs, err := unpack.LoadDayHistory(w.user, day, w.r); err == nil {
+			songs = ExcludePlays(songs, excluded)
+			songs = ApplyManualPlays(songs, manual[day.String()])
 			for j, song := range songs {
 				if c, ok := corrections[song.Artist]; ok {
 					songs[j].Artist = c
@@ -98,6 +113,139 @@ func (w *load) load() error {
 	return err
 }
 
+// LoadExcluded loads the plays of a user that are left out of the charts. The
+// overlay is optional, if it doesn't exist no plays are excluded.
+func LoadExcluded(user string, r rsrc.Reader) (map[unpack.ExcludedPlay]bool, error) {
+	plays, err := unpack.LoadExcludedPlays(user, r)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to load excluded plays")
+	}
+
+	excluded := make(map[unpack.ExcludedPlay]bool, len(plays))
+	for _, play := range plays {
+		excluded[play] = true
+	}
+	return excluded, nil
+}
+
+// ExcludePlays removes the excluded plays from songs. The plays are matched by
+// the artist name as it was scrobbled, the title and the time.
+func ExcludePlays(songs []info.Song, excluded map[unpack.ExcludedPlay]bool) []info.Song {
+	if len(excluded) == 0 {
+		return songs
+	}
+
+	kept := songs[:0]
+	for _, song := range songs {
+		if !excluded[unpack.ExcludedPlay{Artist: song.Artist, Title: song.Title, Time: song.Time}] {
+			kept = append(kept, song)
+		}
+	}
+	return kept
+}
+
+// LoadManual loads the plays of a user that were changed by hand and groups
+// them by day. The overlay is optional, if it doesn't exist no plays are
+// changed.
+func LoadManual(user string, r rsrc.Reader) (map[string]*unpack.ManualPlays, error) {
+	plays, err := unpack.LoadManualPlays(user, r)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to load manual plays")
+	}
+
+	days := map[string]*unpack.ManualPlays{}
+	get := func(day rsrc.Day) *unpack.ManualPlays {
+		if m, ok := days[day.String()]; ok {
+			return m
+		}
+		m := &unpack.ManualPlays{}
+		days[day.String()] = m
+		return m
+	}
+	for _, play := range plays.Added {
+		m := get(play.Day)
+		m.Added = append(m.Added, play)
+	}
+	for _, ref := range plays.Removed {
+		m := get(ref.Day)
+		m.Removed = append(m.Removed, ref)
+	}
+	for _, edit := range plays.Edited {
+		m := get(edit.Ref.Day)
+		m.Edited = append(m.Edited, edit)
+	}
+	return days, nil
+}
+
+// ApplyManualPlays removes, edits and adds plays of a single day by hand. The
+// days stored in manual are not checked. Removals and edits refer to the plays
+// as they were scrobbled, each of them applies to a single play. References
+// that match no play are ignored.
+func ApplyManualPlays(songs []info.Song, manual *unpack.ManualPlays) []info.Song {
+	if manual == nil {
+		return songs
+	}
+
+	taken := make([]bool, len(songs))
+	for _, ref := range manual.Removed {
+		if i := FindPlay(songs, ref, taken); i >= 0 {
+			taken[i] = true
+		}
+	}
+	removed := make([]bool, len(songs))
+	copy(removed, taken)
+
+	edits := map[int]unpack.PlayEdit{}
+	for _, edit := range manual.Edited {
+		if i := FindPlay(songs, edit.Ref, taken); i >= 0 {
+			taken[i] = true
+			edits[i] = edit
+		}
+	}
+
+	result := make([]info.Song, 0, len(songs)+len(manual.Added))
+	for i, song := range songs {
+		if removed[i] {
+			continue
+		}
+		if edit, ok := edits[i]; ok {
+			if edit.Artist != "" {
+				song.Artist = edit.Artist
+			}
+			if edit.Title != "" {
+				song.Title = edit.Title
+			}
+			if edit.Album != "" {
+				song.Album = edit.Album
+			}
+		}
+		result = append(result, song)
+	}
+	for _, play := range manual.Added {
+		result = append(result, play.Song)
+	}
+	return result
+}
+
+// FindPlay returns the index of the first play in songs that ref refers to
+// and that is not skipped. It returns -1 if there is none. skip may be nil.
+func FindPlay(songs []info.Song, ref unpack.PlayRef, skip []bool) int {
+	for i, song := range songs {
+		if skip != nil && skip[i] {
+			continue
+		}
+		if song.Artist == ref.Artist && song.Title == ref.Title &&
+			(ref.Time == 0 || song.Time == ref.Time) {
+			return i
+		}
+	}
+	return -1
+}
+
 func (w *load) songs() ([][]info.Song, error) {
 	if w.plays == nil {
 		err := w.load()
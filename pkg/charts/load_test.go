@@ -0,0 +1,149 @@
+package charts_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestExcludePlays(t *testing.T) {
+	songs := []info.Song{
+		{Artist: "A", Title: "a", Time: 100},
+		{Artist: "A", Title: "a", Time: 105},
+		{Artist: "B", Title: "a", Time: 105},
+		{Artist: "A", Title: "a"},
+	}
+	excluded := map[unpack.ExcludedPlay]bool{
+		{Artist: "A", Title: "a", Time: 105}: true,
+		{Artist: "C", Title: "c", Time: 105}: true,
+	}
+
+	kept := charts.ExcludePlays(songs, excluded)
+	expected := []info.Song{
+		{Artist: "A", Title: "a", Time: 100},
+		{Artist: "B", Title: "a", Time: 105},
+		{Artist: "A", Title: "a"},
+	}
+	if !reflect.DeepEqual(kept, expected) {
+		t.Errorf("wrong plays:\n has:  %v\nwant: %v", kept, expected)
+	}
+}
+
+func TestLoadExcluded(t *testing.T) {
+	play := unpack.ExcludedPlay{Artist: "A", Title: "a", Time: 105}
+
+	for _, c := range []struct {
+		name     string
+		data     []byte
+		write    bool
+		excluded map[unpack.ExcludedPlay]bool
+		ok       bool
+	}{
+		{"no overlay", nil, false, nil, true},
+		{"overlay", nil, true, map[unpack.ExcludedPlay]bool{play: true}, true},
+		{"corrupt overlay", []byte(`{"plays":`), false, nil, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r, _ := mock.IO(map[rsrc.Locator][]byte{rsrc.ExcludedPlays("user"): c.data}, mock.Path)
+			if c.write {
+				unpack.WriteExcludedPlays([]unpack.ExcludedPlay{play}, "user", r)
+			}
+
+			excluded, err := charts.LoadExcluded("user", r)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if !reflect.DeepEqual(excluded, c.excluded) {
+				t.Errorf("wrong plays:\n has:  %v\nwant: %v", excluded, c.excluded)
+			}
+		})
+	}
+}
+
+func TestLoadManual(t *testing.T) {
+	r, _ := mock.IO(map[rsrc.Locator][]byte{rsrc.ManualPlays("user"): nil}, mock.Path)
+	if manual, err := charts.LoadManual("user", r); err != nil || manual != nil {
+		t.Errorf("expected no plays and no error for missing overlay, got %v, '%v'", manual, err)
+	}
+
+	r.Write([]byte(`{"added":`), rsrc.ManualPlays("user"))
+	if _, err := charts.LoadManual("user", r); err == nil {
+		t.Error("expected error for corrupt overlay but none occurred")
+	}
+}
+
+func TestApplyManualPlays(t *testing.T) {
+	day := rsrc.ParseDay("2018-01-01")
+	songs := []info.Song{
+		{Artist: "A", Title: "a", Time: 100},
+		{Artist: "A", Title: "a", Time: 200},
+		{Artist: "B", Title: "b"},
+		{Artist: "C", Title: "c", Album: "x"},
+	}
+
+	cases := []struct {
+		name   string
+		manual *unpack.ManualPlays
+		result []info.Song
+	}{
+		{
+			"no changes",
+			nil,
+			songs,
+		},
+		{
+			"remove and add",
+			&unpack.ManualPlays{
+				Added: []unpack.PlayAddition{
+					{Day: day, Song: info.Song{Artist: "D", Title: "d", Duration: 3}},
+				},
+				Removed: []unpack.PlayRef{
+					{Day: day, Artist: "A", Title: "a", Time: 200},
+					{Day: day, Artist: "B", Title: "b"},
+					{Day: day, Artist: "X", Title: "x"},
+				},
+			},
+			[]info.Song{
+				{Artist: "A", Title: "a", Time: 100},
+				{Artist: "C", Title: "c", Album: "x"},
+				{Artist: "D", Title: "d", Duration: 3},
+			},
+		},
+		{
+			"edits apply to one play each",
+			&unpack.ManualPlays{
+				Removed: []unpack.PlayRef{
+					{Day: day, Artist: "A", Title: "a"},
+				},
+				Edited: []unpack.PlayEdit{
+					{Ref: unpack.PlayRef{Day: day, Artist: "A", Title: "a"}, Title: "aa"},
+					{Ref: unpack.PlayRef{Day: day, Artist: "C", Title: "c"}, Artist: "E", Album: "y"},
+				},
+			},
+			[]info.Song{
+				{Artist: "A", Title: "aa", Time: 200},
+				{Artist: "B", Title: "b"},
+				{Artist: "E", Title: "c", Album: "y"},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			in := make([]info.Song, len(songs))
+			copy(in, songs)
+
+			result := charts.ApplyManualPlays(in, c.manual)
+			if !reflect.DeepEqual(result, c.result) {
+				t.Errorf("wrong plays:\n has:  %v\nwant: %v", result, c.result)
+			}
+		})
+	}
+}
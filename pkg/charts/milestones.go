@@ -0,0 +1,71 @@
+package charts
+
+import (
+	"context"
+	"math"
+)
+
+// Reached returns the index of the first column in which title reaches
+// threshold in sums. The values of sums must not decrease, like those of
+// Sum(). ok is false if the threshold is not reached.
+func Reached(ctx context.Context, sums Charts, title Title, threshold float64,
+) (idx int, ok bool, err error) {
+	value := func(i int) (float64, error) {
+		data, err := sums.Data(ctx, []Title{title}, i, i+1)
+		if err != nil {
+			return 0, err
+		}
+		return data[0][0], nil
+	}
+
+	n := sums.Len()
+	if n <= 0 {
+		return 0, false, nil
+	}
+	if last, err := value(n - 1); err != nil || last < threshold {
+		return 0, false, err
+	}
+
+	lo, hi := 0, n-1
+	for lo < hi {
+		mid := (lo + hi) / 2
+		v, err := value(mid)
+		if err != nil {
+			return 0, false, err
+		}
+		if v >= threshold {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, true, nil
+}
+
+// Projected returns in how many columns after the last one title is expected
+// to reach threshold in sums if it continues at the rate of the last window
+// columns. ok is false if the rate is zero.
+func Projected(ctx context.Context, sums Charts, title Title, threshold float64, window int,
+) (cols int, ok bool, err error) {
+	n := sums.Len()
+	if window >= n {
+		window = n - 1
+	}
+	if window <= 0 {
+		return 0, false, nil
+	}
+
+	data, err := sums.Data(ctx, []Title{title}, n-1-window, n)
+	if err != nil {
+		return 0, false, err
+	}
+	first, last := data[0][0], data[0][window]
+	if last >= threshold {
+		return 0, true, nil
+	}
+	rate := (last - first) / float64(window)
+	if rate <= 0 {
+		return 0, false, nil
+	}
+	return int(math.Ceil((threshold - last) / rate)), true, nil
+}
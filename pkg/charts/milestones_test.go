@@ -0,0 +1,67 @@
+package charts_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestReached(t *testing.T) {
+	sums := charts.FromMap(map[string][]float64{
+		"A": {0, 1, 1, 3, 7, 7, 10},
+	})
+
+	for _, c := range []struct {
+		threshold float64
+		idx       int
+		ok        bool
+	}{
+		{0, 0, true},
+		{1, 1, true},
+		{2, 3, true},
+		{7, 4, true},
+		{10, 6, true},
+		{11, 0, false},
+	} {
+		idx, ok, err := charts.Reached(context.Background(), sums, charts.KeyTitle("A"), c.threshold)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if idx != c.idx || ok != c.ok {
+			t.Errorf("threshold %v: has (%v, %v), want (%v, %v)", c.threshold, idx, ok, c.idx, c.ok)
+		}
+	}
+}
+
+func TestProjected(t *testing.T) {
+	sums := charts.FromMap(map[string][]float64{
+		"A": {0, 0, 2, 4, 6},
+		"B": {5, 5, 5, 5, 5},
+	})
+
+	for _, c := range []struct {
+		name      string
+		title     string
+		threshold float64
+		window    int
+		cols      int
+		ok        bool
+	}{
+		{"steady rate", "A", 10, 2, 2, true},
+		{"partial column is rounded up", "A", 9, 2, 2, true},
+		{"long window", "A", 9, 10, 2, true},
+		{"already reached", "A", 6, 2, 0, true},
+		{"no plays", "B", 6, 2, 0, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			cols, ok, err := charts.Projected(context.Background(), sums, charts.KeyTitle(c.title), c.threshold, c.window)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if cols != c.cols || ok != c.ok {
+				t.Errorf("has (%v, %v), want (%v, %v)", cols, ok, c.cols, c.ok)
+			}
+		})
+	}
+}
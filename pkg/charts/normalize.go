@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"runtime"
 )
 
@@ -11,6 +12,7 @@ type normalizer struct {
 }
 
 type normalizerJob struct {
+	ctx        context.Context
 	in, out    []float64
 	begin, end int
 	back       chan error
@@ -36,9 +38,14 @@ func newNormalizer(parent Charts, totals Charts) *normalizer {
 	workers := runtime.NumCPU()
 	for i := 0; i < workers; i++ {
 		go func() {
-			// TODO is there a way to not query the entire length of the totals?
-			totals, err := n.totals.Data([]Title{KeyTitle("total")}, 0, parent.Len())
+			// totals are loaded with the context of the first job that succeeds
+			var totals [][]float64
 			for job := range n.lineChan {
+				var err error
+				if totals == nil {
+					// TODO is there a way to not query the entire length of the totals?
+					totals, err = n.totals.Data(job.ctx, []Title{KeyTitle("total")}, 0, parent.Len())
+				}
 				if err == nil {
 					f(job.in, job.out, totals[0], job.begin, job.end)
 				}
@@ -55,19 +62,20 @@ func Normalize(c Charts) Charts {
 	return newNormalizer(c, ColumnSum(c))
 }
 
-func (c *normalizer) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (c *normalizer) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	data := make([][]float64, len(titles))
 	back := make(chan error, len(titles))
 
 	for i, title := range titles {
 		out := make([]float64, end-begin)
 		data[i] = out
-		res, err := c.parent.Data([]Title{title}, begin, end)
+		res, err := c.parent.Data(ctx, []Title{title}, begin, end)
 		if err != nil {
 			return nil, err
 		}
 
 		c.lineChan <- normalizerJob{
+			ctx:   ctx,
 			in:    res[0],
 			out:   out,
 			begin: begin,
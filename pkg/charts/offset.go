@@ -1,6 +1,7 @@
 package charts
 
 import (
+	"context"
 	"github.com/nilsbu/async"
 )
 
@@ -31,11 +32,11 @@ func (c *offset) Titles() []Title {
 	return c.titles
 }
 
-func (c *offset) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (c *offset) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	data := make([][]float64, len(titles))
 	length := c.parent.Len()
 
-	async.Pie(len(titles), func(i int) error {
+	err := pie(ctx, len(titles), func(i int) error {
 		offset := c.offsets[titles[i].Key()]
 		line := make([]float64, end-begin)
 
@@ -49,7 +50,7 @@ func (c *offset) Data(titles []Title, begin, end int) ([][]float64, error) {
 			o = length - 1
 		}
 
-		d, err := c.parent.Data([]Title{titles[i]}, o, e)
+		d, err := c.parent.Data(ctx, []Title{titles[i]}, o, e)
 		if err != nil {
 			return err
 		}
@@ -64,16 +65,19 @@ func (c *offset) Data(titles []Title, begin, end int) ([][]float64, error) {
 		data[i] = line
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 	return data, nil
 }
 
-func EntryDates(gaussian, sums Charts) (map[string]int, error) {
+func EntryDates(ctx context.Context, gaussian, sums Charts) (map[string]int, error) {
 	titles := sums.Titles()
-	s, err := sums.Data(titles, 0, sums.Len())
+	s, err := sums.Data(ctx, titles, 0, sums.Len())
 	if err != nil {
 		return nil, err
 	}
-	g, err := gaussian.Data(titles, 0, gaussian.Len())
+	g, err := gaussian.Data(ctx, titles, 0, gaussian.Len())
 	if err != nil {
 		return nil, err
 	}
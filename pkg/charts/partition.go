@@ -1,12 +1,13 @@
 package charts
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
-	"github.com/nilsbu/async"
 	"github.com/nilsbu/lastfm/pkg/info"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
@@ -122,7 +123,7 @@ func FirstTagPartition(
 // YearPartition creates a partition based on when artists have passsed a threshold.
 // gaussian is a the charts normalized by a gaussian.
 // sums is a normalized sum of the charts.
-func YearPartition(gaussian, sums Charts, registered rsrc.Day) (Partition, error) {
+func YearPartition(ctx context.Context, gaussian, sums Charts, registered rsrc.Day) (Partition, error) {
 	first := registered.Time().Year()
 	last := registered.AddDate(0, 0, sums.Len()).Time().Year()
 
@@ -137,9 +138,9 @@ func YearPartition(gaussian, sums Charts, registered rsrc.Day) (Partition, error
 	yearIdxs := getYearIdxs(registered, sums.Len())
 	titles := sums.Titles()
 	ts := make([]Title, len(titles))
-	err := async.Pie(len(titles), func(ii int) error {
+	err := pie(ctx, len(titles), func(ii int) error {
 		title := titles[ii]
-		last, err := sums.Data([]Title{title}, sums.Len()-1, sums.Len())
+		last, err := sums.Data(ctx, []Title{title}, sums.Len()-1, sums.Len())
 		if err != nil || last[0][0] < 2 {
 			return nil
 		}
@@ -150,7 +151,7 @@ func YearPartition(gaussian, sums Charts, registered rsrc.Day) (Partition, error
 		maxI := 0
 		for i, idx := range yearIdxs {
 			// TODO use Column if you decide to keep that method
-			vs, err := sums.Data([]Title{title}, idx, idx+1)
+			vs, err := sums.Data(ctx, []Title{title}, idx, idx+1)
 			if err != nil {
 				return err
 			}
@@ -163,7 +164,7 @@ func YearPartition(gaussian, sums Charts, registered rsrc.Day) (Partition, error
 				Begin:      registered.AddDate(0, 0, prev),
 				End:        registered.AddDate(0, 0, idx+1),
 				Registered: registered,
-			})).Data([]Title{title}, idx-prev, idx-prev+1)
+			})).Data(ctx, []Title{title}, idx-prev, idx-prev+1)
 			if err != nil {
 				return err
 			}
@@ -202,6 +203,75 @@ func YearPartition(gaussian, sums Charts, registered rsrc.Day) (Partition, error
 	}, nil
 }
 
+// FirstPlayPartition partitions titles by the year in which they were first
+// played, e.g. "2018", or by quarter, e.g. "2018-Q1". first holds the day of
+// each title's first play or nil if it was never played, those titles are in
+// the partition "-". The partitions are in chronological order.
+func FirstPlayPartition(titles []Title, first []rsrc.Day, quarters bool) Partition {
+	partitionTitles := map[string][]Title{}
+	names := []string{}
+	for i, title := range titles {
+		name := "-"
+		if first[i] != nil {
+			t := first[i].Time()
+			if quarters {
+				name = fmt.Sprintf("%v-Q%v", t.Year(), (int(t.Month())-1)/3+1)
+			} else {
+				name = fmt.Sprintf("%v", t.Year())
+			}
+		}
+		if _, ok := partitionTitles[name]; !ok && name != "-" {
+			names = append(names, name)
+		}
+		partitionTitles[name] = append(partitionTitles[name], title)
+	}
+	sort.Strings(names)
+
+	partitions := make([]Title, len(names)+1)
+	for i, name := range names {
+		partitions[i] = KeyTitle(name)
+	}
+	partitions[len(names)] = KeyTitle("-")
+
+	return biMapPartition{
+		partitionTitles: partitionTitles,
+		partitions:      partitions,
+		key:             func(t Title) string { return t.Key() },
+	}
+}
+
+var tiers = []string{"1-9", "10-99", "100-999", "1000+"}
+
+// TierPartition partitions titles by their number of plays into the tiers
+// "1-9", "10-99", "100-999" and "1000+". Titles without plays are in the
+// partition "-".
+func TierPartition(titles []Title, plays []float64) Partition {
+	partitionTitles := map[string][]Title{}
+	for i, title := range titles {
+		name := "-"
+		if plays[i] >= 1 {
+			tier := 0
+			for limit := 10.0; plays[i] >= limit && tier < len(tiers)-1; limit *= 10 {
+				tier++
+			}
+			name = tiers[tier]
+		}
+		partitionTitles[name] = append(partitionTitles[name], title)
+	}
+
+	partitions := make([]Title, len(tiers)+1)
+	for i, tier := range tiers {
+		partitions[i] = KeyTitle(tier)
+	}
+	partitions[len(tiers)] = KeyTitle("-")
+
+	return biMapPartition{
+		partitionTitles: partitionTitles,
+		partitions:      partitions,
+		key:             func(t Title) string { return t.Key() },
+	}
+}
+
 func getYearIdxs(registered rsrc.Day, len int) (idxs []int) {
 	t := registered.Time()
 	pre := rsrc.DayFromTime(time.Date(
@@ -1,6 +1,7 @@
 package charts_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/nilsbu/lastfm/pkg/charts"
@@ -109,7 +110,7 @@ func TestPartiton(t *testing.T) {
 		{
 			"year partition with no eligible artists",
 			func() charts.Partition {
-				p, _ := charts.YearPartition(
+				p, _ := charts.YearPartition(context.Background(),
 					charts.FromMap(map[string][]float64{"not": {0, 1}}),
 					charts.FromMap(map[string][]float64{"not": {0, 1}}),
 					rsrc.ParseDay("2019-12-31"),
@@ -125,7 +126,7 @@ func TestPartiton(t *testing.T) {
 		{
 			"year partition with values",
 			func() charts.Partition {
-				p, _ := charts.YearPartition(
+				p, _ := charts.YearPartition(context.Background(),
 					charts.FromMap(map[string][]float64{
 						"not":    {0, 0, 1, 0},
 						"first":  {0, 4, 10, 0}, // higher value irrelevant since 4 is reached in 2019
@@ -150,6 +151,74 @@ func TestPartiton(t *testing.T) {
 			},
 			[]charts.Title{charts.KeyTitle("2019"), charts.KeyTitle("2020")},
 		},
+		{
+			"first play partition by year",
+			charts.FirstPlayPartition(
+				[]charts.Title{
+					charts.ArtistTitle("old"),
+					charts.ArtistTitle("new"),
+					charts.ArtistTitle("old2"),
+					charts.ArtistTitle("never"),
+				},
+				[]rsrc.Day{
+					rsrc.ParseDay("2018-12-31"),
+					rsrc.ParseDay("2020-01-01"),
+					rsrc.ParseDay("2018-03-01"),
+					nil,
+				},
+				false),
+			[]partitionTitles{
+				{charts.KeyTitle("2018"), []charts.Title{charts.ArtistTitle("old"), charts.ArtistTitle("old2")}},
+				{charts.KeyTitle("2019"), []charts.Title{}},
+				{charts.KeyTitle("2020"), []charts.Title{charts.ArtistTitle("new")}},
+				{charts.KeyTitle("-"), []charts.Title{charts.ArtistTitle("never")}},
+			},
+			[]charts.Title{charts.KeyTitle("2018"), charts.KeyTitle("2020"), charts.KeyTitle("-")},
+		},
+		{
+			"first play partition by quarter",
+			charts.FirstPlayPartition(
+				[]charts.Title{
+					charts.ArtistTitle("a"),
+					charts.ArtistTitle("b"),
+					charts.ArtistTitle("c"),
+				},
+				[]rsrc.Day{
+					rsrc.ParseDay("2018-12-31"),
+					rsrc.ParseDay("2018-01-01"),
+					rsrc.ParseDay("2018-03-31"),
+				},
+				true),
+			[]partitionTitles{
+				{charts.KeyTitle("2018-Q1"), []charts.Title{charts.ArtistTitle("b"), charts.ArtistTitle("c")}},
+				{charts.KeyTitle("2018-Q4"), []charts.Title{charts.ArtistTitle("a")}},
+			},
+			[]charts.Title{charts.KeyTitle("2018-Q1"), charts.KeyTitle("2018-Q4"), charts.KeyTitle("-")},
+		},
+		{
+			"tier partition",
+			charts.TierPartition(
+				[]charts.Title{
+					charts.ArtistTitle("a"),
+					charts.ArtistTitle("b"),
+					charts.ArtistTitle("c"),
+					charts.ArtistTitle("d"),
+					charts.ArtistTitle("e"),
+					charts.ArtistTitle("f"),
+				},
+				[]float64{9, 10, 999, 1000, 123456, 0}),
+			[]partitionTitles{
+				{charts.KeyTitle("1-9"), []charts.Title{charts.ArtistTitle("a")}},
+				{charts.KeyTitle("10-99"), []charts.Title{charts.ArtistTitle("b")}},
+				{charts.KeyTitle("100-999"), []charts.Title{charts.ArtistTitle("c")}},
+				{charts.KeyTitle("1000+"), []charts.Title{charts.ArtistTitle("d"), charts.ArtistTitle("e")}},
+				{charts.KeyTitle("-"), []charts.Title{charts.ArtistTitle("f")}},
+			},
+			[]charts.Title{
+				charts.KeyTitle("1-9"), charts.KeyTitle("10-99"), charts.KeyTitle("100-999"),
+				charts.KeyTitle("1000+"), charts.KeyTitle("-"),
+			},
+		},
 		{
 			"tag weight partition",
 			charts.TagWeightPartition(
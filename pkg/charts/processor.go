@@ -1,7 +1,7 @@
 package charts
 
 import (
-	"github.com/nilsbu/async"
+	"context"
 )
 
 func fromBeginRange(size, begin, end int) (b, e int) {
@@ -24,10 +24,10 @@ func Group(
 	}
 }
 
-func (l *partitionSum) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (l *partitionSum) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	data := make([][]float64, len(titles))
 
-	err := async.Pie(len(titles), func(i int) error {
+	err := pie(ctx, len(titles), func(i int) error {
 		line := make([]float64, end-begin)
 
 		titlesX, err := l.partition.Titles(titles[i])
@@ -35,7 +35,7 @@ func (l *partitionSum) Data(titles []Title, begin, end int) ([][]float64, error)
 			return err
 		}
 		for _, key := range titlesX {
-			res, err := l.parent.Data([]Title{key}, begin, end)
+			res, err := l.parent.Data(ctx, []Title{key}, begin, end)
 			if err != nil {
 				return err
 			}
@@ -93,6 +93,7 @@ type cacheRow struct {
 }
 
 type cacheRowRequest struct {
+	ctx        context.Context
 	back       chan cacheRowAnswer
 	begin, end int
 }
@@ -119,49 +120,7 @@ func Cache(parent Charts) Charts {
 
 		go func(title Title, row *cacheRow, parent Charts) {
 			for request := range row.channel {
-				if row.begin > -1 {
-					if row.begin <= request.begin && row.begin+len(row.data) >= request.end {
-						request.back <- cacheRowAnswer{
-							data: row.data[request.begin-row.begin : request.end-row.begin],
-							err:  nil,
-						}
-					} else {
-						var res [][]float64
-						var err error
-
-						if request.begin < row.begin {
-							res, err = parent.Data([]Title{title}, request.begin, row.begin)
-							newData := []float64{}
-							newData = append(newData, res[0]...)
-							newData = append(newData, row.data...)
-							row.data = newData
-
-							row.begin = request.begin
-						}
-						if row.begin+len(row.data) < request.end {
-							res, err = parent.Data([]Title{title}, row.begin+len(row.data), request.end)
-							row.data = append(
-								row.data,
-								res[0]...)
-						}
-
-						request.back <- cacheRowAnswer{
-							data: row.data[request.begin-row.begin : request.end-row.begin],
-							err:  err,
-						}
-						continue
-					}
-				} else {
-					data, err := parent.Data([]Title{title}, request.begin, request.end)
-					if err == nil {
-						row.data = data[0]
-						row.begin = request.begin
-					}
-					request.back <- cacheRowAnswer{
-						data: data[0],
-						err:  err,
-					}
-				}
+				request.back <- row.serve(request, title, parent)
 			}
 		}(k, row, parent)
 	}
@@ -172,22 +131,61 @@ func Cache(parent Charts) Charts {
 	}
 }
 
-func (c *cache) row(title Title, begin, end int) ([]float64, error) {
+// serve answers a request, loading the data that isn't cached yet from the
+// parent. The row remains unchanged if loading fails, e.g. because the context
+// of the request is done.
+func (row *cacheRow) serve(request cacheRowRequest, title Title, parent Charts) cacheRowAnswer {
+	if row.begin == -1 {
+		data, err := parent.Data(request.ctx, []Title{title}, request.begin, request.end)
+		if err != nil {
+			return cacheRowAnswer{err: err}
+		}
+		row.data = data[0]
+		row.begin = request.begin
+		return cacheRowAnswer{data: data[0]}
+	}
+
+	if request.begin < row.begin {
+		res, err := parent.Data(request.ctx, []Title{title}, request.begin, row.begin)
+		if err != nil {
+			return cacheRowAnswer{err: err}
+		}
+		newData := []float64{}
+		newData = append(newData, res[0]...)
+		newData = append(newData, row.data...)
+		row.data = newData
+
+		row.begin = request.begin
+	}
+	if row.begin+len(row.data) < request.end {
+		res, err := parent.Data(request.ctx, []Title{title}, row.begin+len(row.data), request.end)
+		if err != nil {
+			return cacheRowAnswer{err: err}
+		}
+		row.data = append(row.data, res[0]...)
+	}
+
+	return cacheRowAnswer{
+		data: row.data[request.begin-row.begin : request.end-row.begin],
+	}
+}
+
+func (c *cache) row(ctx context.Context, title Title, begin, end int) ([]float64, error) {
 	row := c.rows[title.Key()]
 
 	back := make(chan cacheRowAnswer)
 
-	row.channel <- cacheRowRequest{back, begin, end}
+	row.channel <- cacheRowRequest{ctx, back, begin, end}
 	answer := <-back
 	close(back)
 	return answer.data, answer.err
 }
 
-func (c *cache) Data(titles []Title, begin, end int) ([][]float64, error) {
+func (c *cache) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
 	data := make([][]float64, len(titles))
 
-	err := async.Pie(len(titles), func(i int) error {
-		row, err := c.row(titles[i], begin, end)
+	err := pie(ctx, len(titles), func(i int) error {
+		row, err := c.row(ctx, titles[i], begin, end)
 		if err == nil {
 			data[i] = row
 		}
@@ -218,13 +216,13 @@ func (c *only) Titles() []Title {
 	return c.titles
 }
 
-func (c *only) Data(titles []Title, begin, end int) ([][]float64, error) {
-	return c.parent.Data(titles, begin, end)
+func (c *only) Data(ctx context.Context, titles []Title, begin, end int) ([][]float64, error) {
+	return c.parent.Data(ctx, titles, begin, end)
 }
 
-func Top(c Charts, n int) ([]Title, error) {
+func Top(ctx context.Context, c Charts, n int) ([]Title, error) {
 	fullTitles := c.Titles()
-	col, err := c.Data(fullTitles, c.Len()-1, c.Len())
+	col, err := c.Data(ctx, fullTitles, c.Len()-1, c.Len())
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package charts_test
 
 import (
+	"context"
 	"math"
 	"math/rand"
 	"reflect"
@@ -151,19 +152,19 @@ func TestLazyChartsPartial(t *testing.T) {
 	for _, c := range cs {
 		t.Run(c.name, func(t *testing.T) {
 			{
-				row, _ := c.lc.Data([]charts.Title{charts.KeyTitle("A")}, 0, 4)
+				row, _ := c.lc.Data(context.Background(), []charts.Title{charts.KeyTitle("A")}, 0, 4)
 				if !reflect.DeepEqual(row[0], c.rowA04) {
 					t.Error("row A 0-4 not equal:", row[0], "!=", c.rowA04)
 				}
 			}
 			{
-				row, _ := c.lc.Data([]charts.Title{charts.KeyTitle("B")}, 1, 3)
+				row, _ := c.lc.Data(context.Background(), []charts.Title{charts.KeyTitle("B")}, 1, 3)
 				if !reflect.DeepEqual(row[0], c.rowB13) {
 					t.Error("row B 1-3 not equal:", row[0], "!=", c.rowB13)
 				}
 			}
 			{
-				col_, _ := c.lc.Data([]charts.Title{charts.KeyTitle("A"), charts.KeyTitle("B")}, 1, 2)
+				col_, _ := c.lc.Data(context.Background(), []charts.Title{charts.KeyTitle("A"), charts.KeyTitle("B")}, 1, 2)
 				col := make([]float64, len(col_))
 				for i, c := range col_ {
 					col[i] = c[0]
@@ -173,7 +174,7 @@ func TestLazyChartsPartial(t *testing.T) {
 				}
 			}
 			{
-				col_, _ := c.lc.Data([]charts.Title{charts.KeyTitle("B")}, 3, 4)
+				col_, _ := c.lc.Data(context.Background(), []charts.Title{charts.KeyTitle("B")}, 3, 4)
 				col := make([]float64, len(col_))
 				for i, c := range col_ {
 					col[i] = c[0]
@@ -183,7 +184,7 @@ func TestLazyChartsPartial(t *testing.T) {
 				}
 			}
 			{
-				data, _ := c.lc.Data([]charts.Title{charts.KeyTitle("A"), charts.KeyTitle("B")}, 1, 4)
+				data, _ := c.lc.Data(context.Background(), []charts.Title{charts.KeyTitle("A"), charts.KeyTitle("B")}, 1, 4)
 				if !reflect.DeepEqual(c.dataAB14, data) {
 					t.Error("data A,B 1-4 not equal:", c.dataAB14, "!=", data)
 				}
@@ -289,8 +290,8 @@ func checkRows(t *testing.T, expect, actual charts.Charts, ranges [][2]int) {
 	// Since Rows() doesn't exist anymore, this is just another Data() test
 	for _, be := range ranges {
 		for _, title := range expect.Titles() {
-			xs, _ := expect.Data([]charts.Title{title}, be[0], be[1])
-			as, _ := actual.Data([]charts.Title{title}, be[0], be[1])
+			xs, _ := expect.Data(context.Background(), []charts.Title{title}, be[0], be[1])
+			as, _ := actual.Data(context.Background(), []charts.Title{title}, be[0], be[1])
 			x, a := xs[0], as[0]
 
 			if len(a) != be[1]-be[0] {
@@ -339,8 +340,8 @@ func checkCols(t *testing.T, expect, actual charts.Charts, sets [][]charts.Title
 
 	for _, set := range sets {
 		for i := 0; i < expect.Len(); i++ {
-			x, _ := expect.Data(set, i, i+1)
-			a, _ := actual.Data(set, i, i+1)
+			x, _ := expect.Data(context.Background(), set, i, i+1)
+			a, _ := actual.Data(context.Background(), set, i, i+1)
 
 			if len(a) != len(set) {
 				t.Fatalf("col length: expect=%v, actual=%v",
@@ -368,8 +369,8 @@ func checkData(t *testing.T, expect, actual charts.Charts,
 		set := sets[i]
 		b, e := ranges[i][0], ranges[i][1]
 
-		x, _ := expect.Data(set, b, e)
-		a, _ := actual.Data(set, b, e)
+		x, _ := expect.Data(context.Background(), set, b, e)
+		a, _ := actual.Data(context.Background(), set, b, e)
 
 		for k := range x {
 			rowX := x[k]
@@ -444,6 +445,30 @@ func TestLazyCharts(t *testing.T) {
 				"B": {m[1] + m[2], m[2] + m[0], 2 * m[1], m[2] + m[0], m[1] + m[2]},
 			}),
 		},
+		{
+			"Triangular mirror begin",
+			charts.Triangular(root, 1, true, false),
+			charts.FromMap(map[string][]float64{
+				"A": {0, 0, .25, .5, .25},
+				"B": {.25, .5, .5, .5, .25},
+			}),
+		},
+		{
+			"Box mirror none",
+			charts.Box(root, 1, false, false),
+			charts.FromMap(map[string][]float64{
+				"A": {0, 0, 1.0 / 3, 1.0 / 3, 1.0 / 3},
+				"B": {1.0 / 3, 1.0 / 3, 2.0 / 3, 1.0 / 3, 1.0 / 3},
+			}),
+		},
+		{
+			"EMA",
+			charts.EMA(root, 3),
+			charts.FromMap(map[string][]float64{
+				"A": {0, 0, 0, .5, .25},
+				"B": {0, .5, .25, .625, .3125},
+			}),
+		},
 		{
 			"ColumnSum",
 			charts.ColumnSum(charts.FromMap(map[string][]float64{
@@ -585,7 +610,7 @@ func TestTop(t *testing.T) {
 		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
-			titles, _ := charts.Top(c.charts, c.n)
+			titles, _ := charts.Top(context.Background(), c.charts, c.n)
 			if !areTitlesSame(c.titles, titles) {
 				t.Errorf("expect: %v\nactual: %v", c.titles, titles)
 			}
@@ -593,3 +618,37 @@ func TestTop(t *testing.T) {
 	}
 
 }
+
+func TestDataCancelled(t *testing.T) {
+	base := charts.FromMap(map[string][]float64{
+		"A": {1, 2, 3, 4},
+		"B": {0, 1, 0, 1},
+	})
+
+	cases := []struct {
+		name   string
+		charts charts.Charts
+	}{
+		{"sum", charts.Sum(base)},
+		{"cache", charts.Cache(charts.Sum(base))},
+		{"normalize", charts.Normalize(base)},
+		{"interval", charts.Column(base, 2)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			titles := c.charts.Titles()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := c.charts.Data(ctx, titles, 0, c.charts.Len()); err != context.Canceled {
+				t.Errorf("expected '%v' but got '%v'", context.Canceled, err)
+			}
+
+			// a cancelled request must not leave the charts in a broken state
+			if _, err := c.charts.Data(context.Background(), titles, 0, c.charts.Len()); err != nil {
+				t.Error("unexpected error:", err)
+			}
+		})
+	}
+}
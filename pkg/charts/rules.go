@@ -0,0 +1,126 @@
+package charts
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// TitleFacts are what partition rules know about a title. Songs and Albums are
+// the songs and albums the title was played as, Tags are the lower case tags
+// of its artist, Country is the artist's country and FirstYear is the year of
+// the first listen, zero if unknown.
+type TitleFacts struct {
+	Songs     []string
+	Albums    []string
+	Tags      []string
+	Country   string
+	FirstYear int
+}
+
+type rule struct {
+	unpack.PartitionRule
+	artist, song, album *regexp.Regexp
+}
+
+// RulePartition partitions titles by rules. The rules are evaluated in order
+// and the first one that matches a title decides its partition. Titles that
+// match no rule are in the partition "-". facts must have the same length as
+// titles.
+func RulePartition(titles []Title, facts []TitleFacts, rules []unpack.PartitionRule,
+) (Partition, error) {
+	compiled := make([]rule, len(rules))
+	for i, r := range rules {
+		compiled[i].PartitionRule = r
+		var err error
+		if compiled[i].artist, err = compile(r.Artist); err != nil {
+			return nil, err
+		}
+		if compiled[i].song, err = compile(r.Song); err != nil {
+			return nil, err
+		}
+		if compiled[i].album, err = compile(r.Album); err != nil {
+			return nil, err
+		}
+	}
+
+	partitions := []Title{}
+	partitionTitles := map[string][]Title{}
+	for _, r := range rules {
+		if _, ok := partitionTitles[r.Name]; !ok {
+			partitions = append(partitions, KeyTitle(r.Name))
+			partitionTitles[r.Name] = []Title{}
+		}
+	}
+	partitions = append(partitions, KeyTitle("-"))
+
+	for i, title := range titles {
+		partition := "-"
+		for _, r := range compiled {
+			if r.matches(title, facts[i]) {
+				partition = r.Name
+				break
+			}
+		}
+		partitionTitles[partition] = append(partitionTitles[partition], title)
+	}
+
+	return biMapPartition{
+		partitionTitles: partitionTitles,
+		partitions:      partitions,
+		key:             func(t Title) string { return t.Key() },
+	}, nil
+}
+
+func compile(expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	return regexp.Compile(expr)
+}
+
+func (r rule) matches(title Title, facts TitleFacts) bool {
+	if r.artist != nil && !r.artist.MatchString(title.Artist()) {
+		return false
+	}
+	if r.song != nil && !matchesAny(r.song, facts.Songs) {
+		return false
+	}
+	if r.album != nil && !matchesAny(r.album, facts.Albums) {
+		return false
+	}
+	for _, tag := range r.Tags {
+		if !containsTag(facts.Tags, tag) {
+			return false
+		}
+	}
+	if r.Country != "" && !strings.EqualFold(r.Country, facts.Country) {
+		return false
+	}
+	if r.FromYear != 0 && (facts.FirstYear == 0 || facts.FirstYear < r.FromYear) {
+		return false
+	}
+	if r.ToYear != 0 && (facts.FirstYear == 0 || facts.FirstYear > r.ToYear) {
+		return false
+	}
+	return true
+}
+
+func matchesAny(expr *regexp.Regexp, values []string) bool {
+	for _, value := range values {
+		if expr.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
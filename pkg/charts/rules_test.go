@@ -0,0 +1,102 @@
+package charts_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+func TestRulePartition(t *testing.T) {
+	titles := []charts.Title{
+		charts.ArtistTitle("Opeth"),
+		charts.ArtistTitle("Metallica"),
+		charts.ArtistTitle("ABBA"),
+		charts.SongTitle(info.Song{Artist: "ABBA", Title: "Waterloo"}),
+	}
+	facts := []charts.TitleFacts{
+		{Tags: []string{"progressive metal", "metal"}, Country: "Sweden", FirstYear: 1995},
+		{Tags: []string{"metal"}, Country: "USA", FirstYear: 1991},
+		{Songs: []string{"Waterloo", "SOS"}, Albums: []string{"Gold"}, Country: "Sweden", FirstYear: 2005},
+		{Songs: []string{"Waterloo"}, Albums: []string{"Waterloo"}, Country: "Sweden", FirstYear: 2007},
+	}
+
+	for _, c := range []struct {
+		name       string
+		rules      []unpack.PartitionRule
+		partitions []string
+		expected   map[string][]charts.Title
+	}{
+		{
+			"no rules",
+			[]unpack.PartitionRule{},
+			[]string{"-"},
+			map[string][]charts.Title{"-": titles},
+		},
+		{
+			"tags, country and years",
+			[]unpack.PartitionRule{
+				{Name: "90s Swedish metal", Tags: []string{"Metal"}, Country: "sweden", FromYear: 1990, ToYear: 1999},
+			},
+			[]string{"90s Swedish metal", "-"},
+			map[string][]charts.Title{
+				"90s Swedish metal": {titles[0]},
+				"-":                 {titles[1], titles[2], titles[3]},
+			},
+		},
+		{
+			"first matching rule wins",
+			[]unpack.PartitionRule{
+				{Name: "metal", Tags: []string{"metal"}},
+				{Name: "swedish", Country: "Sweden"},
+				{Name: "metal", Artist: "^Opeth$"},
+			},
+			[]string{"metal", "swedish", "-"},
+			map[string][]charts.Title{
+				"metal":   {titles[0], titles[1]},
+				"swedish": {titles[2], titles[3]},
+				"-":       {},
+			},
+		},
+		{
+			"songs and albums",
+			[]unpack.PartitionRule{
+				{Name: "compilations", Artist: "ABBA", Album: "^Gold$"},
+				{Name: "sos", Song: "SOS"},
+				{Name: "late", FromYear: 2006},
+			},
+			[]string{"compilations", "sos", "late", "-"},
+			map[string][]charts.Title{
+				"compilations": {titles[2]},
+				"sos":          {},
+				"late":         {titles[3]},
+				"-":            {titles[0], titles[1]},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			p, err := charts.RulePartition(titles, facts, c.rules)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			partitions, _ := p.Partitions()
+			names := make([]string, len(partitions))
+			for i, partition := range partitions {
+				names[i] = partition.Key()
+			}
+			if !reflect.DeepEqual(names, c.partitions) {
+				t.Errorf("wrong partitions\nhas:  %v\nwant: %v", names, c.partitions)
+			}
+
+			for name, expected := range c.expected {
+				has, _ := p.Titles(charts.KeyTitle(name))
+				if !reflect.DeepEqual(has, expected) {
+					t.Errorf("wrong titles in '%v'\nhas:  %v\nwant: %v", name, has, expected)
+				}
+			}
+		})
+	}
+}
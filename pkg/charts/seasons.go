@@ -0,0 +1,109 @@
+package charts
+
+import (
+	"context"
+	"math"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// Season is the seasonal pattern of a title. Profile holds the title's values
+// per month or weekday relative to the values of all titles in it, scaled to
+// a sum of 1. Score is 0 if the title is as evenly spread as all titles
+// together and 1 if it only occurs in a single month or weekday. Months or
+// weekdays without any values are not taken into account. Total is the sum of
+// the title's values and Years is the number of calendar years in which they
+// occur.
+type Season struct {
+	Title   Title
+	Profile []float64
+	Score   float64
+	Total   float64
+	Years   int
+}
+
+// Seasons computes the seasonal patterns of the titles in c, whose columns are
+// days beginning at registered. The days are folded by month, or by weekday
+// beginning on Monday if weekdays is true. The lines are loaded one by one
+// so that the whole day matrix is never in memory at once.
+func Seasons(ctx context.Context, c Charts, registered rsrc.Day, weekdays bool,
+) ([]Season, error) {
+	buckets := 12
+	if weekdays {
+		buckets = 7
+	}
+	bucket := make([]int, c.Len())
+	year := make([]int, c.Len())
+	for i := range bucket {
+		t := registered.AddDate(0, 0, i).Time()
+		if weekdays {
+			bucket[i] = (int(t.Weekday()) + 6) % 7
+		} else {
+			bucket[i] = int(t.Month()) - 1
+		}
+		year[i] = t.Year()
+	}
+
+	titles := c.Titles()
+	seasons := make([]Season, len(titles))
+	err := pie(ctx, len(titles), func(i int) error {
+		data, err := c.Data(ctx, []Title{titles[i]}, 0, c.Len())
+		if err != nil {
+			return err
+		}
+
+		fold := make([]float64, buckets)
+		total := 0.0
+		years := map[int]bool{}
+		for j, v := range data[0] {
+			fold[bucket[j]] += v
+			total += v
+			if v > 0 {
+				years[year[j]] = true
+			}
+		}
+		seasons[i] = Season{Title: titles[i], Profile: fold, Total: total, Years: len(years)}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make([]float64, buckets)
+	for _, season := range seasons {
+		for k, v := range season.Profile {
+			totals[k] += v
+		}
+	}
+	covered := 0
+	for _, v := range totals {
+		if v > 0 {
+			covered++
+		}
+	}
+
+	for i := range seasons {
+		profile := seasons[i].Profile
+		sum := 0.0
+		for k := range profile {
+			if totals[k] > 0 {
+				profile[k] /= totals[k]
+			}
+			sum += profile[k]
+		}
+		if sum == 0 || covered < 2 {
+			continue
+		}
+
+		uniform := 1 / float64(covered)
+		distance := 0.0
+		for k := range profile {
+			profile[k] /= sum
+			if totals[k] > 0 {
+				distance += math.Abs(profile[k] - uniform)
+			}
+		}
+		seasons[i].Score = distance / 2 / (1 - uniform)
+	}
+	return seasons, nil
+}
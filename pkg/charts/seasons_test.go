@@ -0,0 +1,87 @@
+package charts_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+func TestSeasons(t *testing.T) {
+	for _, c := range []struct {
+		name       string
+		charts     charts.Charts
+		registered rsrc.Day
+		weekdays   bool
+		expect     []charts.Season
+	}{
+		{
+			"weekdays",
+			charts.InOrder([]charts.Pair{
+				{Title: charts.KeyTitle("A"), Values: []float64{1, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0}},
+				{Title: charts.KeyTitle("B"), Values: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+			}),
+			rsrc.ParseDay("2018-01-01"),
+			true,
+			[]charts.Season{
+				{Title: charts.KeyTitle("A"), Profile: []float64{1, 0, 0, 0, 0, 0, 0}, Score: 1, Total: 2, Years: 1},
+				{
+					Title:   charts.KeyTitle("B"),
+					Profile: []float64{1.0 / 13, 2.0 / 13, 2.0 / 13, 2.0 / 13, 2.0 / 13, 2.0 / 13, 2.0 / 13},
+					Score:   1.0 / 13,
+					Total:   14,
+					Years:   1,
+				},
+			},
+		},
+		{
+			"months",
+			charts.InOrder([]charts.Pair{
+				{Title: charts.KeyTitle("A"), Values: []float64{1, 1}},
+				{Title: charts.KeyTitle("B"), Values: []float64{0, 1}},
+				{Title: charts.KeyTitle("C"), Values: []float64{0, 0}},
+			}),
+			rsrc.ParseDay("2018-12-31"),
+			false,
+			[]charts.Season{
+				{Title: charts.KeyTitle("A"), Profile: []float64{1.0 / 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2.0 / 3}, Score: 1.0 / 3, Total: 2, Years: 2},
+				{Title: charts.KeyTitle("B"), Profile: []float64{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}, Score: 1, Total: 1, Years: 1},
+				{Title: charts.KeyTitle("C"), Profile: make([]float64, 12), Score: 0, Years: 0},
+			},
+		},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			seasons, err := charts.Seasons(context.Background(), c.charts, c.registered, c.weekdays)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if len(seasons) != len(c.expect) {
+				t.Fatalf("expected %v seasons but got %v", len(c.expect), len(seasons))
+			}
+
+			for i, season := range seasons {
+				expect := c.expect[i]
+				if season.Title.Key() != expect.Title.Key() {
+					t.Errorf("title %v: expected '%v' but got '%v'", i, expect.Title, season.Title)
+				}
+				if season.Total != expect.Total {
+					t.Errorf("'%v': expected total %v but got %v", expect.Title, expect.Total, season.Total)
+				}
+				if season.Years != expect.Years {
+					t.Errorf("'%v': expected %v years but got %v", expect.Title, expect.Years, season.Years)
+				}
+				if math.Abs(season.Score-expect.Score) > 1e-9 {
+					t.Errorf("'%v': expected score %v but got %v", expect.Title, expect.Score, season.Score)
+				}
+				for k := range expect.Profile {
+					if math.Abs(season.Profile[k]-expect.Profile[k]) > 1e-9 {
+						t.Errorf("'%v': expected profile %v but got %v", expect.Title, expect.Profile, season.Profile)
+						break
+					}
+				}
+			}
+		})
+	}
+}
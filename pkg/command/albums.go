@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type printAlbumsCompletion struct {
+	n int
+}
+
+func (cmd printAlbumsCompletion) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	plays, err := pl.Plays(ctx)
+	if err != nil {
+		return err
+	}
+
+	completions, err := organize.CompleteAlbums(plays, s)
+	if len(completions) == 0 && err != nil {
+		return err
+	}
+
+	if cmd.n >= 0 && len(completions) > cmd.n {
+		completions = completions[:cmd.n]
+	}
+
+	albums := make([]format.Album, len(completions))
+	for i, c := range completions {
+		albums[i] = format.Album{
+			Artist:    c.Artist,
+			Name:      c.Album,
+			Heard:     c.Heard,
+			Tracks:    c.Tracks,
+			FullPlays: c.FullPlays,
+		}
+	}
+
+	return d.Display(&format.Albums{Albums: albums})
+}
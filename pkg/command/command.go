@@ -1,6 +1,8 @@
 package command
 
 import (
+	"context"
+
 	"github.com/nilsbu/lastfm/pkg/display"
 	"github.com/nilsbu/lastfm/pkg/io"
 	"github.com/nilsbu/lastfm/pkg/pipeline"
@@ -8,11 +10,13 @@ import (
 )
 
 type command interface {
-	Execute(session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error
+	Execute(ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error
 }
 
-// Execute executes the command described in the arguments.
+// Execute executes the command described in the arguments. The command stops
+// once ctx is done, the store it uses is bound to ctx.
 func Execute(
+	ctx context.Context,
 	args []string,
 	session *unpack.SessionInfo,
 	s io.Store,
@@ -23,5 +27,11 @@ func Execute(
 		return err
 	}
 
-	return cmd.Execute(session, s, pl, d)
+	return cmd.Execute(ctx, session, io.WithContext(ctx, s), pl, d)
+}
+
+// IsCommand returns whether name is a top-level command like "print".
+func IsCommand(name string) bool {
+	_, ok := cmdLastfm.nodes[name]
+	return ok
 }
@@ -0,0 +1,177 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type correctionsList struct{}
+
+func (cmd correctionsList) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	corrections, err := unpack.LoadArtistCorrections(session.User, s)
+	if err != nil {
+		return err
+	}
+
+	froms := make([]string, 0, len(corrections))
+	for from := range corrections {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	lines := make([]string, len(froms))
+	for i, from := range froms {
+		lines[i] = fmt.Sprintf("%v -> %v", from, corrections[from])
+	}
+
+	return d.Display(&format.Message{Msg: strings.Join(lines, "\n")})
+}
+
+type correctionsAdd struct {
+	from, to string
+}
+
+func (cmd correctionsAdd) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	corrections, err := unpack.LoadArtistCorrections(session.User, s)
+	if err != nil {
+		return err
+	}
+
+	if err := addCorrection(corrections, cmd.from, cmd.to); err != nil {
+		return err
+	}
+
+	return unpack.WriteArtistCorrections(corrections, session.User, s)
+}
+
+// addCorrection adds a correction from one name to another. Corrections that
+// pointed to the from name are redirected to the new target so that no chains
+// of corrections occur.
+func addCorrection(corrections map[string]string, from, to string) error {
+	if from == to {
+		return fmt.Errorf("cannot correct '%v' to itself", from)
+	}
+	if next, ok := corrections[to]; ok {
+		return fmt.Errorf("'%v' is corrected to '%v' itself", to, next)
+	}
+
+	corrections[from] = to
+	for k, v := range corrections {
+		if v == from {
+			corrections[k] = to
+		}
+	}
+	return nil
+}
+
+type correctionsRemove struct {
+	from string
+}
+
+func (cmd correctionsRemove) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	corrections, err := unpack.LoadArtistCorrections(session.User, s)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := corrections[cmd.from]; !ok {
+		return fmt.Errorf("there is no correction for '%v'", cmd.from)
+	}
+	delete(corrections, cmd.from)
+
+	return unpack.WriteArtistCorrections(corrections, session.User, s)
+}
+
+type correctionsSuggest struct {
+	accept string
+}
+
+func (cmd correctionsSuggest) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	corrections, err := unpack.LoadArtistCorrections(session.User, s)
+	if err != nil {
+		return err
+	}
+
+	plays, err := pl.Plays(ctx)
+	if err != nil {
+		return err
+	}
+
+	suggestions := organize.SuggestCorrections(plays, corrections)
+
+	accepted, err := parseAccepted(cmd.accept, len(suggestions))
+	if err != nil {
+		return err
+	}
+
+	if len(accepted) == 0 {
+		lines := make([]format.Suggestion, len(suggestions))
+		for i, s := range suggestions {
+			lines[i] = format.Suggestion{
+				From:      s.From,
+				To:        s.To,
+				FromPlays: s.FromPlays,
+				ToPlays:   s.ToPlays,
+			}
+		}
+
+		return d.Display(&format.Suggestions{Suggestions: lines})
+	}
+
+	for _, i := range accepted {
+		if err := addCorrection(corrections, suggestions[i].From, suggestions[i].To); err != nil {
+			return err
+		}
+	}
+
+	if err := unpack.WriteArtistCorrections(corrections, session.User, s); err != nil {
+		return errors.Wrap(err, "failed to write corrections")
+	}
+
+	return d.Display(&format.Message{Msg: fmt.Sprintf("%v corrections added", len(accepted))})
+}
+
+// parseAccepted parses the indices of accepted suggestions. accept is either
+// empty, "all" or a comma separated list of numbers starting at 1. The result
+// contains the indices starting at 0.
+func parseAccepted(accept string, n int) ([]int, error) {
+	switch accept {
+	case "":
+		return nil, nil
+	case "all":
+		accepted := make([]int, n)
+		for i := range accepted {
+			accepted[i] = i
+		}
+		return accepted, nil
+	}
+
+	parts := strings.Split(accept, ",")
+	accepted := make([]int, len(parts))
+	for i, part := range parts {
+		num, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("'%v' is not a suggestion number", part)
+		} else if num < 1 || num > n {
+			return nil, fmt.Errorf("there is no suggestion %v", num)
+		}
+		accepted[i] = num - 1
+	}
+	return accepted, nil
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestCorrections(t *testing.T) {
+	user := "user"
+	registered := rsrc.ParseDay("2018-01-01")
+	history := [][]info.Song{
+		{{Artist: "The B"}, {Artist: "B"}, {Artist: "The B"}},
+		{{Artist: "Ç"}, {Artist: "C"}},
+	}
+
+	cases := []struct {
+		name string
+		pre  map[string]string
+		cmd  command
+		ok   bool
+		post map[string]string
+	}{
+		{
+			"add",
+			map[string]string{"a": "A"},
+			correctionsAdd{from: "b", to: "B"},
+			true,
+			map[string]string{"a": "A", "b": "B"},
+		},
+		{
+			"add redirects existing corrections",
+			map[string]string{"a": "A"},
+			correctionsAdd{from: "A", to: "AA"},
+			true,
+			map[string]string{"a": "AA", "A": "AA"},
+		},
+		{
+			"add to itself",
+			map[string]string{},
+			correctionsAdd{from: "A", to: "A"},
+			false,
+			map[string]string{},
+		},
+		{
+			"add to corrected name",
+			map[string]string{"a": "A"},
+			correctionsAdd{from: "b", to: "a"},
+			false,
+			map[string]string{"a": "A"},
+		},
+		{
+			"remove",
+			map[string]string{"a": "A", "b": "B"},
+			correctionsRemove{from: "a"},
+			true,
+			map[string]string{"b": "B"},
+		},
+		{
+			"remove missing",
+			map[string]string{"a": "A"},
+			correctionsRemove{from: "A"},
+			false,
+			map[string]string{"a": "A"},
+		},
+		{
+			"accept all suggestions",
+			map[string]string{},
+			correctionsSuggest{accept: "all"},
+			true,
+			map[string]string{"B": "The B", "Ç": "C"},
+		},
+		{
+			"accept some suggestions",
+			map[string]string{},
+			correctionsSuggest{accept: "2"},
+			true,
+			map[string]string{"Ç": "C"},
+		},
+		{
+			"accept invalid suggestion",
+			map[string]string{},
+			correctionsSuggest{accept: "3"},
+			false,
+			map[string]string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			files := map[rsrc.Locator][]byte{
+				rsrc.ArtistCorrections(user): nil,
+				rsrc.UserInfo(user):          nil,
+				rsrc.Bookmark(user):          nil,
+			}
+			for i := range history {
+				files[rsrc.DayHistory(user, registered.AddDate(0, 0, i))] = nil
+			}
+			io0, _ := mock.IO(files, mock.Path)
+			s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+			unpack.WriteArtistCorrections(c.pre, user, s)
+			unpack.WriteUserInfo(&unpack.User{Name: user, Registered: registered}, s)
+			unpack.WriteBookmark(registered.AddDate(0, 0, len(history)), user, s)
+			for i, day := range history {
+				unpack.WriteDayHistory(day, user, registered.AddDate(0, 0, i), s)
+			}
+
+			session := &unpack.SessionInfo{User: user}
+			d := mock.NewDisplay()
+			err := c.cmd.Execute(context.Background(), session, s, pipeline.New(session, s), d)
+			if err != nil && c.ok {
+				t.Fatalf("unexpected error: %v", err)
+			} else if err == nil && !c.ok {
+				t.Fatalf("expected error but none occurred")
+			}
+
+			corrections, err := unpack.LoadArtistCorrections(user, s)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(corrections, c.post) {
+				t.Errorf("wrong corrections:\n has:  %v\nwant: %v", corrections, c.post)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// accumulate returns the steps that accumulate the plays for diversity
+// measures. A half-life of 0 uses the total plays, otherwise fading charts.
+func accumulate(hl float64) []string {
+	if hl > 0 {
+		return []string{fmt.Sprintf("fade,%v", hl), "cache"}
+	}
+	return []string{"sum", "cache"}
+}
+
+type printDiversity struct {
+	printCharts
+	hl   float64
+	top  int
+	date rsrc.Day
+}
+
+func (cmd printDiversity) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	steps, err := cmd.getSteps()
+	if err != nil {
+		return err
+	}
+
+	steps = setStep(steps, accumulate(cmd.hl)...)
+	if cmd.date != nil {
+		steps = append(steps, fmt.Sprintf("day,%v", cmd.date))
+	}
+	steps = append(steps, fmt.Sprintf("diversity,%v", cmd.top))
+
+	cha, err := pl.Execute(ctx, steps)
+	if err != nil {
+		return err
+	}
+
+	return d.Display(&format.Charts{
+		Context:   ctx,
+		Charts:    []charts.Charts{cha},
+		Precision: 2,
+	})
+}
+
+type tableDiversity struct {
+	printCharts
+	hl   float64
+	top  int
+	step int
+}
+
+func (cmd tableDiversity) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	steps, err := cmd.getSteps()
+	if err != nil {
+		return err
+	}
+
+	steps = setStep(steps, accumulate(cmd.hl)...)
+
+	cha, err := pl.Execute(ctx, steps)
+	if err != nil {
+		return err
+	}
+	ranges, _ := charts.ParseRanges(fmt.Sprintf("%vd", cmd.step), pl.Registered(), cha.Len())
+
+	steps = append(steps,
+		fmt.Sprintf("step,%vd", cmd.step),
+		fmt.Sprintf("diversity,%v", cmd.top))
+
+	cha, err = pl.Execute(ctx, steps)
+	if err != nil {
+		return err
+	}
+
+	return d.Display(&format.Table{
+		Context: ctx,
+		Charts:  cha,
+		Ranges:  ranges,
+	})
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type duplicatesList struct {
+	window int
+	share  float64
+}
+
+func (cmd duplicatesList) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	duplicates, err := findDuplicates(session.User, cmd.window, cmd.share, s, pl)
+	if err != nil {
+		return err
+	}
+
+	plays := make([]format.Duplicate, len(duplicates))
+	for i, dup := range duplicates {
+		plays[i] = format.Duplicate{
+			Artist: dup.Song.Artist,
+			Title:  dup.Song.Title,
+			Time:   dup.Song.Time,
+			Gap:    dup.Gap,
+		}
+	}
+
+	return d.Display(&format.Duplicates{Plays: plays})
+}
+
+type duplicatesExclude struct {
+	window int
+	share  float64
+}
+
+func (cmd duplicatesExclude) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	duplicates, err := findDuplicates(session.User, cmd.window, cmd.share, s, pl)
+	if err != nil {
+		return err
+	}
+
+	excluded, err := unpack.LoadExcludedPlays(session.User, s)
+	if errors.Is(err, fs.ErrNotExist) {
+		excluded = []unpack.ExcludedPlay{}
+	} else if err != nil {
+		return err
+	}
+	for _, dup := range duplicates {
+		excluded = append(excluded, unpack.ExcludedPlay{
+			Artist: dup.Song.Artist,
+			Title:  dup.Song.Title,
+			Time:   dup.Song.Time,
+		})
+	}
+
+	if err := unpack.WriteExcludedPlays(excluded, session.User, s); err != nil {
+		return err
+	}
+
+	return d.Display(&format.Message{Msg: fmt.Sprintf("%v plays excluded", len(duplicates))})
+}
+
+// findDuplicates detects duplicates in the history as it was scrobbled, i.e.
+// before artist corrections. Plays that are already excluded are skipped.
+func findDuplicates(
+	user string, window int, share float64, s io.Store, pl pipeline.Pipeline,
+) ([]organize.Duplicate, error) {
+	registered := pl.Registered()
+	if registered == nil {
+		return nil, fmt.Errorf("failed to load registration date of '%v'", user)
+	}
+
+	bookmark, err := unpack.LoadBookmark(user, s)
+	if err != nil {
+		return nil, err
+	}
+
+	plays, err := organize.LoadPreparedHistory(user, registered, bookmark, s)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded, err := charts.LoadExcluded(user, s)
+	if err != nil {
+		return nil, err
+	}
+	for i, day := range plays {
+		plays[i] = charts.ExcludePlays(day, excluded)
+	}
+
+	rules := organize.DuplicateRules{Window: int64(window), Share: share}
+	return organize.FindDuplicates(plays, registered, rules), nil
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestDuplicates(t *testing.T) {
+	user := "user"
+	registered := rsrc.ParseDay("2018-01-01")
+	t0 := registered.Midnight()
+	history := [][]info.Song{
+		{
+			{Artist: "a", Title: "x", Time: t0 + 100},
+			{Artist: "a", Title: "x", Time: t0 + 105},
+			{Artist: "B", Title: "y", Time: t0 + 110},
+		},
+		{
+			{Artist: "a", Title: "x", Time: t0 + 86400},
+		},
+	}
+
+	files := map[rsrc.Locator][]byte{
+		rsrc.ArtistCorrections(user): []byte(`{"corrections":{"a":"A"}}`),
+		rsrc.UserInfo(user):          nil,
+		rsrc.Bookmark(user):          nil,
+		rsrc.ExcludedPlays(user):     nil,
+	}
+	for i := range history {
+		files[rsrc.DayHistory(user, registered.AddDate(0, 0, i))] = nil
+	}
+	io0, _ := mock.IO(files, mock.Path)
+	s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+	unpack.WriteUserInfo(&unpack.User{Name: user, Registered: registered}, s)
+	unpack.WriteBookmark(registered.AddDate(0, 0, len(history)), user, s)
+	for i, day := range history {
+		unpack.WriteDayHistory(day, user, registered.AddDate(0, 0, i), s)
+	}
+
+	session := &unpack.SessionInfo{User: user}
+
+	d := mock.NewDisplay()
+	err := duplicatesList{window: 30}.Execute(context.Background(), session, s, pipeline.New(session, s), d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &format.Duplicates{Plays: []format.Duplicate{
+		{Artist: "a", Title: "x", Time: t0 + 105, Gap: 5},
+	}}
+	if len(d.Msgs) != 1 {
+		t.Fatalf("got %v messages but expected 1", len(d.Msgs))
+	}
+	buf0, buf1 := new(bytes.Buffer), new(bytes.Buffer)
+	expected.Plain(buf0)
+	d.Msgs[0].Plain(buf1)
+	if buf0.String() != buf1.String() {
+		t.Errorf("actual does not match expected:\n%v----------\n%v", buf1.String(), buf0.String())
+	}
+
+	d = mock.NewDisplay()
+	err = duplicatesExclude{window: 30}.Execute(context.Background(), session, s, pipeline.New(session, s), d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	excluded, err := unpack.LoadExcludedPlays(user, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedExcluded := []unpack.ExcludedPlay{{Artist: "a", Title: "x", Time: t0 + 105}}
+	if !reflect.DeepEqual(excluded, expectedExcluded) {
+		t.Errorf("wrong excluded plays:\n has:  %v\nwant: %v", excluded, expectedExcluded)
+	}
+
+	plays, err := pipeline.New(session, s).Plays(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plays[0]) != 2 || plays[0][0].Artist != "A" || plays[0][1].Artist != "B" {
+		t.Errorf("excluded play was not removed from the plays: %v", plays)
+	}
+
+	d = mock.NewDisplay()
+	err = duplicatesList{window: 30}.Execute(context.Background(), session, s, pipeline.New(session, s), d)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	buf := new(bytes.Buffer)
+	d.Msgs[0].Plain(buf)
+	if buf.String() != "" {
+		t.Errorf("excluded plays are listed again:\n%v", buf.String())
+	}
+
+	corrupt := []byte(`{"plays":`)
+	s.Write(corrupt, rsrc.ExcludedPlays(user))
+	err = duplicatesExclude{window: 30}.Execute(context.Background(), session, s, pipeline.New(session, s), mock.NewDisplay())
+	if err == nil {
+		t.Error("expected error for corrupt excluded plays but none occurred")
+	}
+	if data, _ := s.Read(rsrc.ExcludedPlays(user)); !bytes.Equal(data, corrupt) {
+		t.Errorf("corrupt excluded plays were overwritten with '%v'", string(data))
+	}
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+const (
+	// eraMinColumns is the minimum number of samples in an era.
+	eraMinColumns = 3
+	// eraGenreArtists is the number of top artists of an era whose genres
+	// make up its genre mix.
+	eraGenreArtists = 50
+	// eraGenres is the number of genres that are reported per era.
+	eraGenres = 5
+)
+
+type printEras struct {
+	curve      string
+	hl         float64
+	days       int
+	similarity float64
+	n          int
+}
+
+func (cmd printEras) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tc, err := organize.LoadTagConfig(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to load tag configuration")
+	}
+	tree, err := tc.GenreTree()
+	if err != nil {
+		return err
+	}
+
+	var steps []string
+	switch cmd.curve {
+	case "fade":
+		steps = []string{"artists", fmt.Sprintf("fade,%v", cmd.hl), "cache"}
+	case "gaussian":
+		steps = []string{"artists", "gaussian", "cache"}
+	default:
+		return fmt.Errorf("curve '%v' is unknown, use 'fade' or 'gaussian'", cmd.curve)
+	}
+	curve, err := pl.Execute(ctx, steps)
+	if err != nil {
+		return err
+	}
+	ranges, err := charts.ParseRanges(fmt.Sprintf("%vd", cmd.days), pl.Registered(), curve.Len())
+	if err != nil {
+		return err
+	}
+	sampled, err := pl.Execute(ctx, append(steps, fmt.Sprintf("step,%vd", cmd.days)))
+	if err != nil {
+		return err
+	}
+
+	n := cmd.n
+	if n < eraGenreArtists {
+		n = eraGenreArtists
+	}
+	eras, err := charts.Eras(ctx, sampled, cmd.similarity, eraMinColumns, n)
+	if err != nil {
+		return err
+	}
+
+	artists := []string{}
+	for _, era := range eras {
+		for _, title := range era.Titles {
+			artists = append(artists, title.Artist())
+		}
+	}
+	tags, _ := organize.LoadArtistTags(artists, io.WithContext(ctx, s))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	corrections, _ := unpack.LoadSupertagCorrections(session.User, s)
+
+	genreOf := func(artist string) string {
+		genre, ok := corrections[artist]
+		if !ok || !tree.Contains(genre) {
+			names := make([]string, len(tags[artist]))
+			for i, tag := range tags[artist] {
+				names[i] = tag.Name
+			}
+			genre = tree.Genre(names)
+		}
+		if top, ok := tree.Level(genre, ""); ok {
+			return top
+		}
+		return "-"
+	}
+
+	f := &format.Eras{Eras: make([]format.Era, len(eras))}
+	for i, era := range eras {
+		f.Eras[i] = format.Era{
+			Begin:   ranges.Delims[era.Begin],
+			End:     ranges.Delims[era.End],
+			Artists: []format.Share{},
+		}
+
+		genres := map[string]float64{}
+		total := 0.0
+		for j, title := range era.Titles {
+			if j < cmd.n {
+				f.Eras[i].Artists = append(f.Eras[i].Artists,
+					format.Share{Name: title.String(), Share: era.Shares[j]})
+			}
+			genres[genreOf(title.Artist())] += era.Shares[j]
+			total += era.Shares[j]
+		}
+		f.Eras[i].Genres = genreMix(genres, total)
+	}
+
+	return d.Display(f)
+}
+
+// genreMix returns the genres with the highest shares of the total.
+func genreMix(genres map[string]float64, total float64) []format.Share {
+	mix := []format.Share{}
+	for genre, v := range genres {
+		mix = append(mix, format.Share{Name: genre, Share: v / total})
+	}
+	sort.Slice(mix, func(i, j int) bool {
+		if mix[i].Share != mix[j].Share {
+			return mix[i].Share > mix[j].Share
+		}
+		return mix[i].Name < mix[j].Name
+	})
+	if len(mix) > eraGenres {
+		mix = mix[:eraGenres]
+	}
+	return mix
+}
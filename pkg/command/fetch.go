@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	async "github.com/nilsbu/async"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type fetchMissing struct{}
+
+func (cmd fetchMissing) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	resources, err := unpack.LoadMissingResources(s)
+	if errors.Is(err, fs.ErrNotExist) {
+		resources = []unpack.Resource{}
+	} else if err != nil {
+		return err
+	}
+	if len(resources) == 0 {
+		return d.Display(&format.Message{Msg: "no missing resources were recorded"})
+	}
+
+	failed := make([]bool, len(resources))
+	async.Pi(len(resources), func(i int) {
+		loc, err := rsrc.ParseLocator(resources[i].Method, resources[i].Param)
+		if err == nil {
+			_, err = s.Update(loc)
+		}
+		failed[i] = err != nil
+	})
+
+	remaining := []unpack.Resource{}
+	for i, res := range resources {
+		if failed[i] {
+			remaining = append(remaining, res)
+		}
+	}
+	if err := unpack.WriteMissingResources(remaining, s); err != nil {
+		return err
+	}
+
+	return d.Display(&format.Message{Msg: fmt.Sprintf("fetched %v of %v resources",
+		len(resources)-len(remaining), len(resources))})
+}
+
+// ReportMissing records resources that could not be loaded while working
+// offline, so that they can be downloaded later with 'fetch', and displays a
+// summary of them.
+func ReportMissing(missing []rsrc.Locator, s io.Store, d display.Display) error {
+	if len(missing) == 0 {
+		return nil
+	}
+
+	resources, err := unpack.LoadMissingResources(s)
+	if errors.Is(err, fs.ErrNotExist) {
+		resources = []unpack.Resource{}
+	} else if err != nil {
+		return err
+	}
+	known := map[unpack.Resource]bool{}
+	for _, res := range resources {
+		known[res] = true
+	}
+
+	// only resources that can be described are recorded and counted, the others
+	// like config files cannot be fetched
+	total := 0
+	counts := map[string]int{}
+	for _, loc := range missing {
+		method, param, err := rsrc.DescribeLocator(loc)
+		if err != nil {
+			continue
+		}
+		total++
+		counts[method]++
+
+		res := unpack.Resource{Method: method, Param: param}
+		if !known[res] {
+			known[res] = true
+			resources = append(resources, res)
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+	if err := unpack.WriteMissingResources(resources, s); err != nil {
+		return err
+	}
+
+	methods := make([]string, 0, len(counts))
+	for method := range counts {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	msg := fmt.Sprintf("%v resources were not available offline, results may be incomplete:", total)
+	for _, method := range methods {
+		msg += fmt.Sprintf("\n  %v: %v", method, counts[method])
+	}
+	msg += "\nrun 'lastfm fetch' to download them"
+	return d.Display(&format.Message{Msg: msg})
+}
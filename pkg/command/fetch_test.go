@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestFetchMissing(t *testing.T) {
+	cases := []struct {
+		name    string
+		missing []byte
+		msg     string
+		ok      bool
+	}{
+		{"nothing recorded", nil, "no missing resources were recorded", true},
+		{"empty", []byte(`{"resources":[]}`), "no missing resources were recorded", true},
+		{"corrupt", []byte(`{"resources":[{"method":`), "", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			session := &unpack.SessionInfo{User: "user"}
+			io0, _ := mock.IO(map[rsrc.Locator][]byte{rsrc.MissingResources(): c.missing}, mock.Path)
+			s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+			d := mock.NewDisplay()
+			err := fetchMissing{}.Execute(context.Background(), session, s, pipeline.New(session, s), d)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if err == nil {
+				if len(d.Msgs) != 1 {
+					t.Fatalf("expected 1 message but got %v", len(d.Msgs))
+				}
+				if msg := d.Msgs[0].(*format.Message).Msg; msg != c.msg {
+					t.Errorf("wrong message: has '%v', want '%v'", msg, c.msg)
+				}
+			}
+		})
+	}
+}
+
+func TestReportMissing(t *testing.T) {
+	io0, _ := mock.IO(map[rsrc.Locator][]byte{rsrc.MissingResources(): nil}, mock.Path)
+	s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+	d := mock.NewDisplay()
+	err := ReportMissing([]rsrc.Locator{
+		rsrc.ArtistInfo("X"),
+		rsrc.ArtistInfo("Y"),
+		rsrc.Supertags(),
+	}, s, d)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	resources, err := unpack.LoadMissingResources(s)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	expect := []unpack.Resource{
+		{Method: "artist.getInfo", Param: "X"},
+		{Method: "artist.getInfo", Param: "Y"},
+	}
+	if !reflect.DeepEqual(resources, expect) {
+		t.Errorf("wrong resources recorded\nhas:  %v\nwant: %v", resources, expect)
+	}
+
+	want := "2 resources were not available offline, results may be incomplete:\n" +
+		"  artist.getInfo: 2\n" +
+		"run 'lastfm fetch' to download them"
+	if len(d.Msgs) != 1 {
+		t.Fatalf("expected 1 message but got %v", len(d.Msgs))
+	}
+	if msg := d.Msgs[0].(*format.Message).Msg; msg != want {
+		t.Errorf("wrong message:\nhas:\n%v\nwant:\n%v", msg, want)
+	}
+}
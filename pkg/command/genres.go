@@ -0,0 +1,77 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type printGenres struct {
+	genre    string
+	depth    int
+	duration bool
+}
+
+func (cmd printGenres) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tc, err := organize.LoadTagConfig(s)
+	if err != nil {
+		return errors.Wrap(err, "failed to load tag configuration")
+	}
+	tree, err := tc.GenreTree()
+	if err != nil {
+		return err
+	}
+	if cmd.genre != "" && !tree.Contains(cmd.genre) {
+		return fmt.Errorf("'%v' is no genre", cmd.genre)
+	}
+
+	plays, err := pl.Plays(ctx)
+	if err != nil {
+		return err
+	}
+
+	artistPlays := map[string]float64{}
+	for _, day := range plays {
+		for _, song := range day {
+			if cmd.duration {
+				artistPlays[song.Artist] += song.Duration
+			} else {
+				artistPlays[song.Artist]++
+			}
+		}
+	}
+
+	artists := make([]string, 0, len(artistPlays))
+	for artist := range artistPlays {
+		artists = append(artists, artist)
+	}
+	tags, _ := organize.LoadArtistTags(artists, io.WithContext(ctx, s))
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	corrections, _ := unpack.LoadSupertagCorrections(session.User, s)
+
+	genrePlays := map[string]float64{}
+	for _, artist := range artists {
+		genre, ok := corrections[artist]
+		if !ok || !tree.Contains(genre) {
+			names := make([]string, len(tags[artist]))
+			for i, tag := range tags[artist] {
+				names[i] = tag.Name
+			}
+			genre = tree.Genre(names)
+		}
+		genrePlays[genre] += artistPlays[artist]
+	}
+
+	return d.Display(&format.Genres{Root: tree.Sum(genrePlays, cmd.genre, cmd.depth)})
+}
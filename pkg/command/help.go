@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"github.com/nilsbu/lastfm/pkg/display"
 	"github.com/nilsbu/lastfm/pkg/format"
 	"github.com/nilsbu/lastfm/pkg/io"
@@ -10,7 +11,7 @@ import (
 
 type help struct{}
 
-func (help) Execute(session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+func (help) Execute(ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	for _, str := range listCommands() {
 		d.Display(&format.Message{Msg: str})
 	}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type updateLoved struct{}
+
+func (cmd updateLoved) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tracks, err := organize.UpdateLovedTracks(session.User, s)
+	if err != nil {
+		return errors.Wrap(err, "failed to update loved tracks")
+	}
+
+	return d.Display(&format.Message{Msg: fmt.Sprintf("%v loved tracks", len(tracks))})
+}
+
+type printLoved struct {
+	n int
+}
+
+func (cmd printLoved) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tracks, err := unpack.LoadLovedTracks(session.User, s)
+	if err != nil {
+		return errors.Wrap(err, "failed to load loved tracks, run 'update loved' first")
+	}
+
+	corrections, err := unpack.LoadArtistCorrections(session.User, s)
+	if err != nil {
+		return err
+	}
+	for i, track := range tracks {
+		if c, ok := corrections[track.Artist]; ok {
+			tracks[i].Artist = c
+		}
+	}
+
+	plays, err := pl.Plays(ctx)
+	if err != nil {
+		return err
+	}
+
+	counts := organize.CountLovedPlays(tracks, plays, pl.Registered())
+	if cmd.n >= 0 && len(counts) > cmd.n {
+		counts = counts[:cmd.n]
+	}
+
+	lines := make([]format.LovedTrack, len(counts))
+	for i, c := range counts {
+		lines[i] = format.LovedTrack{
+			Artist: c.Artist,
+			Title:  c.Title,
+			Loved:  c.Loved,
+			Before: c.Before,
+			After:  c.After,
+		}
+	}
+
+	return d.Display(&format.Loved{Tracks: lines})
+}
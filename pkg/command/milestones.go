@@ -0,0 +1,220 @@
+package command
+
+import (
+	"context"
+	"sort"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+var (
+	playMilestones = []float64{100, 500, 1000, 2500, 5000, 10000}
+	hourMilestones = []float64{10, 50, 100, 250, 500, 1000, 2500}
+)
+
+// scrobbleMilestones returns the milestones of the user's total up to the
+// first one above total.
+func scrobbleMilestones(total float64) []float64 {
+	thresholds := []float64{10000, 50000}
+	for t := 100000.0; thresholds[len(thresholds)-1] <= total; t += 100000 {
+		thresholds = append(thresholds, t)
+	}
+	return thresholds
+}
+
+type printMilestones struct {
+	n      int
+	window int
+}
+
+func (cmd printMilestones) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	total, err := pl.Execute(ctx, []string{"artists", "sum", "cache", "group,total"})
+	if err != nil {
+		return err
+	}
+	plays, err := pl.Execute(ctx, []string{"artists", "sum", "cache"})
+	if err != nil {
+		return err
+	}
+	durations, err := pl.Execute(ctx, []string{"artistsduration", "sum", "cache"})
+	if err != nil {
+		return err
+	}
+
+	ms := &milestones{
+		ctx:        ctx,
+		registered: pl.Registered(),
+		window:     cmd.window,
+	}
+
+	title := charts.KeyTitle("total")
+	last, err := lastValues(ctx, total, []charts.Title{title})
+	if err != nil {
+		return err
+	}
+	if err := ms.title(total, title, "scrobbles", "", scrobbleMilestones(last[0]), 1, last[0]); err != nil {
+		return err
+	}
+
+	titles := plays.Titles()
+	last, err = lastValues(ctx, plays, titles)
+	if err != nil {
+		return err
+	}
+	for i, title := range titles {
+		if err := ms.title(plays, title, "plays", title.String(), playMilestones, 1, last[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := ms.first(durations, "hours", hourMilestones, 60); err != nil {
+		return err
+	}
+
+	return d.Display(&format.Milestones{Milestones: ms.result(cmd.n)})
+}
+
+func lastValues(ctx context.Context, c charts.Charts, titles []charts.Title) ([]float64, error) {
+	last := make([]float64, len(titles))
+	if c.Len() == 0 {
+		return last, nil
+	}
+	data, err := c.Data(ctx, titles, c.Len()-1, c.Len())
+	if err != nil {
+		return nil, err
+	}
+	for i := range data {
+		last[i] = data[i][0]
+	}
+	return last, nil
+}
+
+type milestones struct {
+	ctx                context.Context
+	registered         rsrc.Day
+	window             int
+	reached, projected []format.Milestone
+}
+
+// title adds the milestones of a title in sums that were reached and a
+// projection of the next one. Thresholds are multiplied by scale to compare
+// them to sums.
+func (ms *milestones) title(
+	sums charts.Charts, title charts.Title, kind, name string,
+	thresholds []float64, scale, last float64) error {
+	for _, threshold := range thresholds {
+		if last < threshold*scale {
+			cols, ok, err := charts.Projected(ms.ctx, sums, title, threshold*scale, ms.window)
+			if err != nil || !ok {
+				return err
+			}
+			ms.projected = append(ms.projected, format.Milestone{
+				Day:       ms.registered.AddDate(0, 0, sums.Len()-1+cols),
+				Kind:      kind,
+				Title:     name,
+				Threshold: threshold,
+				Projected: true,
+			})
+			return nil
+		}
+
+		idx, _, err := charts.Reached(ms.ctx, sums, title, threshold*scale)
+		if err != nil {
+			return err
+		}
+		ms.reached = append(ms.reached, format.Milestone{
+			Day:       ms.registered.AddDate(0, 0, idx),
+			Kind:      kind,
+			Title:     name,
+			Threshold: threshold,
+		})
+	}
+	return nil
+}
+
+// first adds the milestones that state which title in sums was the first to
+// reach the thresholds and a projection of which one is going to reach the
+// next one. Thresholds are multiplied by scale to compare them to sums.
+func (ms *milestones) first(
+	sums charts.Charts, kind string, thresholds []float64, scale float64) error {
+	titles := sums.Titles()
+	last, err := lastValues(ms.ctx, sums, titles)
+	if err != nil {
+		return err
+	}
+
+	for _, threshold := range thresholds {
+		var first *format.Milestone
+		for i, title := range titles {
+			if last[i] < threshold*scale {
+				continue
+			}
+			idx, _, err := charts.Reached(ms.ctx, sums, title, threshold*scale)
+			if err != nil {
+				return err
+			}
+			day := ms.registered.AddDate(0, 0, idx)
+			if first == nil || day.Midnight() < first.Day.Midnight() {
+				first = &format.Milestone{Day: day, Kind: kind, Title: title.String(), Threshold: threshold}
+			}
+		}
+		if first != nil {
+			ms.reached = append(ms.reached, *first)
+			continue
+		}
+
+		for i, title := range titles {
+			if last[i] <= 0 {
+				continue
+			}
+			cols, ok, err := charts.Projected(ms.ctx, sums, title, threshold*scale, ms.window)
+			if err != nil {
+				return err
+			}
+			day := ms.registered.AddDate(0, 0, sums.Len()-1+cols)
+			if ok && (first == nil || day.Midnight() < first.Day.Midnight()) {
+				first = &format.Milestone{
+					Day: day, Kind: kind, Title: title.String(), Threshold: threshold, Projected: true}
+			}
+		}
+		if first != nil {
+			ms.projected = append(ms.projected, *first)
+		}
+		return nil
+	}
+	return nil
+}
+
+// result returns the n most recent milestones that were reached followed by
+// the n next projected ones in chronological order.
+func (ms *milestones) result(n int) []format.Milestone {
+	sortMilestones(ms.reached)
+	sortMilestones(ms.projected)
+
+	reached, projected := ms.reached, ms.projected
+	if n >= 0 && len(reached) > n {
+		reached = reached[len(reached)-n:]
+	}
+	if n >= 0 && len(projected) > n {
+		projected = projected[:n]
+	}
+	return append(append([]format.Milestone{}, reached...), projected...)
+}
+
+func sortMilestones(ms []format.Milestone) {
+	sort.SliceStable(ms, func(i, j int) bool {
+		if a, b := ms[i].Day.Midnight(), ms[j].Day.Midnight(); a != b {
+			return a < b
+		} else if ms[i].Threshold != ms[j].Threshold {
+			return ms[i].Threshold < ms[j].Threshold
+		}
+		return ms[i].Title < ms[j].Title
+	})
+}
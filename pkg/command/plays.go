@@ -0,0 +1,214 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type playsList struct{}
+
+func (cmd playsList) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	manual, err := loadManualPlays(session.User, s)
+	if err != nil {
+		return err
+	}
+
+	lines := []string{}
+	for _, play := range manual.Added {
+		lines = append(lines, fmt.Sprintf("added %v %v - %v",
+			play.Day, play.Song.Artist, play.Song.Title))
+	}
+	for _, ref := range manual.Removed {
+		lines = append(lines, fmt.Sprintf("removed %v %v - %v",
+			ref.Day, ref.Artist, ref.Title))
+	}
+	for _, edit := range manual.Edited {
+		artist, title := edit.Ref.Artist, edit.Ref.Title
+		if edit.Artist != "" {
+			artist = edit.Artist
+		}
+		if edit.Title != "" {
+			title = edit.Title
+		}
+		lines = append(lines, fmt.Sprintf("edited %v %v - %v -> %v - %v",
+			edit.Ref.Day, edit.Ref.Artist, edit.Ref.Title, artist, title))
+	}
+
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%v: %v", i+1, lines[i])
+	}
+	return d.Display(&format.Message{Msg: strings.Join(lines, "\n")})
+}
+
+type playsAdd struct {
+	day           rsrc.Day
+	artist, title string
+	album         string
+	duration      float64
+	time          int
+}
+
+func (cmd playsAdd) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	if cmd.day == nil {
+		return errors.New("no valid day was given")
+	}
+
+	// plays outside of the history would never show up in the charts
+	registered := pl.Registered()
+	if registered == nil {
+		return fmt.Errorf("failed to load registration date of '%v'", session.User)
+	}
+	bookmark, err := unpack.LoadBookmark(session.User, s)
+	if err != nil {
+		return err
+	}
+	if cmd.day.Midnight() < registered.Midnight() || cmd.day.Midnight() >= bookmark.Midnight() {
+		return fmt.Errorf("%v is not part of the history, which spans from %v until before %v",
+			cmd.day, registered, bookmark)
+	}
+
+	manual, err := loadManualPlays(session.User, s)
+	if err != nil {
+		return err
+	}
+	manual.Added = append(manual.Added, unpack.PlayAddition{
+		Day: cmd.day,
+		Song: info.Song{
+			Artist:   cmd.artist,
+			Title:    cmd.title,
+			Album:    cmd.album,
+			Duration: cmd.duration,
+			Time:     int64(cmd.time),
+		},
+	})
+	return unpack.WriteManualPlays(manual, session.User, s)
+}
+
+type playsRemove struct {
+	day           rsrc.Day
+	artist, title string
+	time          int
+}
+
+func (cmd playsRemove) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ref, err := checkPlayRef(session.User, cmd.day, cmd.artist, cmd.title, cmd.time, s)
+	if err != nil {
+		return err
+	}
+
+	manual, err := loadManualPlays(session.User, s)
+	if err != nil {
+		return err
+	}
+	manual.Removed = append(manual.Removed, ref)
+	return unpack.WriteManualPlays(manual, session.User, s)
+}
+
+type playsEdit struct {
+	day                           rsrc.Day
+	artist, title                 string
+	time                          int
+	newArtist, newTitle, newAlbum string
+}
+
+func (cmd playsEdit) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	if cmd.newArtist == "" && cmd.newTitle == "" && cmd.newAlbum == "" {
+		return errors.New("nothing to edit, set a new artist, title or album")
+	}
+
+	ref, err := checkPlayRef(session.User, cmd.day, cmd.artist, cmd.title, cmd.time, s)
+	if err != nil {
+		return err
+	}
+
+	manual, err := loadManualPlays(session.User, s)
+	if err != nil {
+		return err
+	}
+	manual.Edited = append(manual.Edited, unpack.PlayEdit{
+		Ref:    ref,
+		Artist: cmd.newArtist,
+		Title:  cmd.newTitle,
+		Album:  cmd.newAlbum,
+	})
+	return unpack.WriteManualPlays(manual, session.User, s)
+}
+
+type playsDrop struct {
+	n int
+}
+
+func (cmd playsDrop) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	manual, err := loadManualPlays(session.User, s)
+	if err != nil {
+		return err
+	}
+
+	// the numbering is the one of 'plays list'
+	i := cmd.n - 1
+	switch {
+	case i < 0:
+		return fmt.Errorf("there is no change %v", cmd.n)
+	case i < len(manual.Added):
+		manual.Added = append(manual.Added[:i], manual.Added[i+1:]...)
+	case i < len(manual.Added)+len(manual.Removed):
+		i -= len(manual.Added)
+		manual.Removed = append(manual.Removed[:i], manual.Removed[i+1:]...)
+	case i < len(manual.Added)+len(manual.Removed)+len(manual.Edited):
+		i -= len(manual.Added) + len(manual.Removed)
+		manual.Edited = append(manual.Edited[:i], manual.Edited[i+1:]...)
+	default:
+		return fmt.Errorf("there is no change %v", cmd.n)
+	}
+	return unpack.WriteManualPlays(manual, session.User, s)
+}
+
+// loadManualPlays loads the manual plays of a user. If there are none yet, an
+// empty set of changes is returned. Other errors are returned so that the
+// existing changes aren't overwritten.
+func loadManualPlays(user string, r rsrc.Reader) (*unpack.ManualPlays, error) {
+	manual, err := unpack.LoadManualPlays(user, r)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &unpack.ManualPlays{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load manual plays: %w", err)
+	}
+	return manual, nil
+}
+
+// checkPlayRef ensures that a play exists in the history as it was scrobbled.
+func checkPlayRef(
+	user string, day rsrc.Day, artist, title string, time int, r rsrc.Reader,
+) (unpack.PlayRef, error) {
+	if day == nil {
+		return unpack.PlayRef{}, errors.New("no valid day was given")
+	}
+
+	ref := unpack.PlayRef{Day: day, Artist: artist, Title: title, Time: int64(time)}
+
+	songs, err := unpack.LoadDayHistory(user, day, r)
+	if err != nil {
+		return ref, err
+	}
+	if charts.FindPlay(songs, ref, nil) < 0 {
+		return ref, fmt.Errorf("'%v - %v' was not played on %v", artist, title, day)
+	}
+	return ref, nil
+}
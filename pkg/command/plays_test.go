@@ -0,0 +1,182 @@
+package command
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestPlays(t *testing.T) {
+	user := "user"
+	registered := rsrc.ParseDay("2018-01-01")
+	history := [][]info.Song{
+		{{Artist: "A", Title: "a"}, {Artist: "B", Title: "b", Time: 100}},
+		{{Artist: "C", Title: "c"}},
+	}
+
+	cases := []struct {
+		name  string
+		cmds  []command
+		ok    bool
+		plays [][]info.Song
+	}{
+		{
+			"no changes",
+			[]command{},
+			true,
+			history,
+		},
+		{
+			"add",
+			[]command{playsAdd{day: rsrc.ParseDay("2018-01-02"), artist: "D", title: "d", duration: 3}},
+			true,
+			[][]info.Song{
+				history[0],
+				{{Artist: "C", Title: "c"}, {Artist: "D", Title: "d", Duration: 3}},
+			},
+		},
+		{
+			"add after bookmark",
+			[]command{playsAdd{day: registered.AddDate(0, 0, 2), artist: "D", title: "d"}},
+			false,
+			history,
+		},
+		{
+			"add before registration",
+			[]command{playsAdd{day: registered.AddDate(0, 0, -1), artist: "D", title: "d"}},
+			false,
+			history,
+		},
+		{
+			"add without day",
+			[]command{playsAdd{artist: "D", title: "d"}},
+			false,
+			history,
+		},
+		{
+			"remove and edit",
+			[]command{
+				playsRemove{day: registered, artist: "A", title: "a"},
+				playsEdit{day: registered, artist: "B", title: "b", time: 100, newArtist: "A"},
+			},
+			true,
+			[][]info.Song{
+				{{Artist: "A", Title: "b", Time: 100}},
+				history[1],
+			},
+		},
+		{
+			"remove unknown play",
+			[]command{playsRemove{day: registered, artist: "B", title: "b", time: 99}},
+			false,
+			history,
+		},
+		{
+			"edit without changes",
+			[]command{playsEdit{day: registered, artist: "A", title: "a"}},
+			false,
+			history,
+		},
+		{
+			"drop",
+			[]command{
+				playsRemove{day: registered, artist: "A", title: "a"},
+				playsAdd{day: registered, artist: "D", title: "d"},
+				playsDrop{n: 2},
+			},
+			true,
+			[][]info.Song{
+				{{Artist: "A", Title: "a"}, {Artist: "B", Title: "b", Time: 100}, {Artist: "D", Title: "d"}},
+				history[1],
+			},
+		},
+		{
+			"drop unknown change",
+			[]command{playsDrop{n: 1}},
+			false,
+			history,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			files := map[rsrc.Locator][]byte{
+				rsrc.ArtistCorrections(user): []byte(`{"corrections":{}}`),
+				rsrc.UserInfo(user):          nil,
+				rsrc.Bookmark(user):          nil,
+				rsrc.ManualPlays(user):       nil,
+			}
+			for i := range history {
+				files[rsrc.DayHistory(user, registered.AddDate(0, 0, i))] = nil
+			}
+			io0, _ := mock.IO(files, mock.Path)
+			s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+			unpack.WriteUserInfo(&unpack.User{Name: user, Registered: registered}, s)
+			unpack.WriteBookmark(registered.AddDate(0, 0, len(history)), user, s)
+			for i, day := range history {
+				unpack.WriteDayHistory(day, user, registered.AddDate(0, 0, i), s)
+			}
+
+			session := &unpack.SessionInfo{User: user}
+			var err error
+			for _, cmd := range c.cmds {
+				d := mock.NewDisplay()
+				if err = cmd.Execute(context.Background(), session, s, pipeline.New(session, s), d); err != nil {
+					break
+				}
+			}
+			if err != nil && c.ok {
+				t.Fatalf("unexpected error: %v", err)
+			} else if err == nil && !c.ok {
+				t.Fatalf("expected error but none occurred")
+			}
+
+			plays, err := pipeline.New(session, s).Plays(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(plays, c.plays) {
+				t.Errorf("wrong plays:\n has:  %v\nwant: %v", plays, c.plays)
+			}
+		})
+	}
+}
+
+func TestPlaysCorrupt(t *testing.T) {
+	user := "user"
+	registered := rsrc.ParseDay("2018-01-01")
+	corrupt := []byte(`{"added":`)
+
+	io0, _ := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.UserInfo(user):    nil,
+		rsrc.Bookmark(user):    nil,
+		rsrc.ManualPlays(user): corrupt,
+	}, mock.Path)
+	s, _ := io.NewStore([][]rsrc.IO{{io0}})
+	unpack.WriteUserInfo(&unpack.User{Name: user, Registered: registered}, s)
+	unpack.WriteBookmark(registered.AddDate(0, 0, 1), user, s)
+
+	session := &unpack.SessionInfo{User: user}
+	for _, cmd := range []command{
+		playsList{},
+		playsAdd{day: registered, artist: "D", title: "d"},
+		playsDrop{n: 1},
+	} {
+		err := cmd.Execute(context.Background(), session, s, pipeline.New(session, s), mock.NewDisplay())
+		if err == nil {
+			t.Errorf("%T: expected error but none occurred", cmd)
+		}
+	}
+	if data, _ := s.Read(rsrc.ManualPlays(user)); !bytes.Equal(data, corrupt) {
+		t.Errorf("corrupt manual plays were overwritten with '%v'", string(data))
+	}
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nilsbu/lastfm/pkg/charts"
@@ -19,6 +20,9 @@ type printCharts struct {
 	percentage bool
 	normalized bool
 	duration   bool
+	identity   string
+	credits    string
+	loved      bool
 	entry      float64
 	n          int
 }
@@ -28,12 +32,32 @@ func (cmd printCharts) getSteps() ([]string, error) {
 	if cmd.duration {
 		steps[0] += "duration"
 	}
+	switch cmd.identity {
+	case "", "name":
+	case "mbid":
+		steps[0] += ",mbid"
+	default:
+		return nil, fmt.Errorf("identity '%v' is unknown, use 'name' or 'mbid'", cmd.identity)
+	}
+	switch cmd.credits {
+	case "":
+	case "full":
+		steps[0] += ",feat"
+	case "share":
+		steps[0] += ",featshare"
+	default:
+		return nil, fmt.Errorf("credits '%v' are unknown, use 'full' or 'share'", cmd.credits)
+	}
 	if cmd.normalized {
 		steps = append(steps, "gaussian", "cache", "normalize")
 	}
 
 	steps = append(steps, "*")
 
+	if cmd.loved {
+		steps = append(steps, "loved")
+	}
+
 	if cmd.percentage {
 		steps = append(steps, "normalize")
 	}
@@ -74,7 +98,7 @@ type printTotal struct {
 }
 
 func (cmd printTotal) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 
 	steps, err := cmd.getSteps()
 	if err != nil {
@@ -88,7 +112,7 @@ func (cmd printTotal) Execute(
 	}
 	steps = append(steps, fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -98,6 +122,7 @@ func (cmd printTotal) Execute(
 		prec = 2
 	}
 	f := &format.DiffCharts{
+		Context:    ctx,
 		Charts:     []charts.DiffCharts{charts.NewDiffCharts(cha, cha.Len()-7)},
 		Numbered:   true,
 		Precision:  prec,
@@ -114,7 +139,7 @@ type printFade struct {
 }
 
 func (cmd printFade) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -127,7 +152,7 @@ func (cmd printFade) Execute(
 	}
 	steps = append(steps, fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -135,6 +160,7 @@ func (cmd printFade) Execute(
 	prec := 2
 
 	f := &format.DiffCharts{
+		Context:    ctx,
 		Charts:     []charts.DiffCharts{charts.NewDiffCharts(cha, cha.Len()-7)},
 		Numbered:   true,
 		Precision:  prec,
@@ -150,7 +176,7 @@ type printPeriod struct {
 }
 
 func (cmd printPeriod) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -159,7 +185,7 @@ func (cmd printPeriod) Execute(
 	steps = setStep(steps, fmt.Sprintf("period,%v", cmd.period), "sum", "cache")
 	steps = append(steps, fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -169,6 +195,7 @@ func (cmd printPeriod) Execute(
 		prec = 2
 	}
 	f := &format.DiffCharts{
+		Context:    ctx,
 		Charts:     []charts.DiffCharts{charts.NewDiffCharts(cha, cha.Len()-7)},
 		Numbered:   true,
 		Precision:  prec,
@@ -185,7 +212,7 @@ type printInterval struct {
 }
 
 func (cmd printInterval) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -194,7 +221,7 @@ func (cmd printInterval) Execute(
 	steps = setStep(steps, fmt.Sprintf("interval,%v,%v", cmd.begin, cmd.end), "sum", "cache")
 	steps = append(steps, fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -204,6 +231,7 @@ func (cmd printInterval) Execute(
 		prec = 2
 	}
 	f := &format.DiffCharts{
+		Context:    ctx,
 		Charts:     []charts.DiffCharts{charts.NewDiffCharts(cha, cha.Len()-7)},
 		Numbered:   true,
 		Precision:  prec,
@@ -223,7 +251,7 @@ func (cmd printFadeMax) Accumulate(c charts.Charts) charts.Charts {
 }
 
 func (cmd printFadeMax) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -232,7 +260,7 @@ func (cmd printFadeMax) Execute(
 	steps = setStep(steps, fmt.Sprintf("fade,%v", cmd.hl), "cache")
 	steps = append(steps, "max", fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -240,6 +268,7 @@ func (cmd printFadeMax) Execute(
 	prec := 2
 
 	f := &format.Charts{
+		Context:    ctx,
 		Charts:     []charts.Charts{cha},
 		Numbered:   true,
 		Precision:  prec,
@@ -255,7 +284,7 @@ type printAfter struct {
 }
 
 func (cmd printAfter) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -264,7 +293,7 @@ func (cmd printAfter) Execute(
 	steps = setStep(steps, "sum", "cache", "offset")
 	steps = append(steps, fmt.Sprintf("column,%d", cmd.n), fmt.Sprintf("top,%v", cmd.printCharts.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -272,6 +301,7 @@ func (cmd printAfter) Execute(
 	prec := 2
 
 	f := &format.DiffCharts{
+		Context:    ctx,
 		Charts:     []charts.DiffCharts{charts.NewDiffCharts(cha, cha.Len()-7)},
 		Numbered:   true,
 		Precision:  prec,
@@ -286,7 +316,7 @@ type printTags struct {
 }
 
 func (cmd printTags) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 
 	tags, err := unpack.LoadArtistTags(cmd.artist, unpack.NewCacheless(s))
 	if err != nil {
@@ -299,6 +329,7 @@ func (cmd printTags) Execute(
 	}
 
 	f := &format.Charts{
+		Context:    ctx,
 		Charts:     []charts.Charts{charts.FromMap(col)},
 		Numbered:   true,
 		Precision:  0,
@@ -315,14 +346,14 @@ type printPeriods struct {
 }
 
 func (cmd printPeriods) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
 	}
 
 	steps = setStep(steps, "id")
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -338,7 +369,7 @@ func (cmd printPeriods) Execute(
 
 	steps = append(steps, fmt.Sprintf("periods,%v", cmd.period), "cache")
 
-	cha, err = pl.Execute(steps)
+	cha, err = pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -349,7 +380,7 @@ func (cmd printPeriods) Execute(
 		args = append(args, steps...)
 		args = append(args, fmt.Sprintf("column,%v", i), fmt.Sprintf("top,%v", cmd.n))
 
-		chas[i], err = pl.Execute(args)
+		chas[i], err = pl.Execute(ctx, args)
 		if err != nil {
 			return err
 		}
@@ -360,6 +391,7 @@ func (cmd printPeriods) Execute(
 		prec = 2
 	}
 	f := &format.Charts{
+		Context:    ctx,
 		Charts:     chas,
 		Ranges:     ranges,
 		Numbered:   true,
@@ -378,7 +410,7 @@ type printFades struct {
 }
 
 func (cmd printFades) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -388,7 +420,7 @@ func (cmd printFades) Execute(
 		fmt.Sprintf("fade,%v", cmd.hl),
 		"cache")
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -401,7 +433,7 @@ func (cmd printFades) Execute(
 
 	ranges, _ := charts.ParseRanges(cmd.period, interval.Begin, rsrc.Between(cmd.begin, cmd.end).Days())
 	steps = append(steps, fmt.Sprintf("step,%v", cmd.period))
-	cha, err = pl.Execute(steps)
+	cha, err = pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -412,7 +444,7 @@ func (cmd printFades) Execute(
 		args = append(args, steps...)
 		args = append(args, fmt.Sprintf("column,%v", i), fmt.Sprintf("top,%v", cmd.n))
 
-		chas[i], err = pl.Execute(args)
+		chas[i], err = pl.Execute(ctx, args)
 		if err != nil {
 			return err
 		}
@@ -423,6 +455,7 @@ func (cmd printFades) Execute(
 		prec = 2
 	}
 	f := &format.Charts{
+		Context:    ctx,
 		Charts:     chas,
 		Ranges:     ranges,
 		Numbered:   true,
@@ -439,13 +472,14 @@ type printRaw struct {
 }
 
 func (cmd printRaw) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
-	cha, err := pl.Execute(cmd.steps)
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	cha, err := pl.Execute(ctx, cmd.steps)
 	if err != nil {
 		return err
 	}
 
 	f := &format.Charts{
+		Context:    ctx,
 		Charts:     []charts.Charts{cha},
 		Numbered:   true,
 		Precision:  cmd.precision,
@@ -2,6 +2,7 @@ package command
 
 import (
 	"bytes"
+	"context"
 	"strings"
 	"testing"
 
@@ -167,6 +168,114 @@ func TestPrint(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"identity by mbid",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x", ArtistMBID: "x1"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x", ArtistMBID: "x1"}, {Artist: "Z", Title: "x", ArtistMBID: "x1"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:       "all",
+					identity: "mbid",
+					n:        10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.FromMap(map[string][]float64{
+					"X": {1, 1, 3},
+					"Y": {0, 1, 1},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"credits shared",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X feat. Y", Title: "x"}},
+				{{Artist: "X & Y", Title: "y"}, {Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:      "all",
+					credits: "share",
+					n:       10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.FromMap(map[string][]float64{
+					"X": {0.5, 2},
+					"Y": {0.5, 1},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"credit exceptions",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X & Z", Title: "x"}, {Artist: "X & Y", Title: "x"}, {Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:      "all",
+					credits: "full",
+					n:       10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.FromMap(map[string][]float64{
+					"X & Z": {1},
+					"X":     {2},
+					"Y":     {1},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"unknown credits",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:      "all",
+					credits: "half",
+					n:       10,
+				},
+			},
+			nil,
+			false,
+		},
+		{
+			"unknown identity",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:       "all",
+					identity: "isrc",
+					n:        10,
+				},
+			},
+			nil,
+			false,
+		},
 		{
 			"by super",
 			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
@@ -277,6 +386,263 @@ func TestPrint(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"by custom",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by: "custom",
+					n:  10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.KeyTitle("French pop"), Values: []float64{1, 1, 2}},
+					{Title: charts.KeyTitle("2018 rock"), Values: []float64{0, 1, 1}},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"by custom with name",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:   "custom",
+					name: "2018 rock",
+					n:    10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.ArtistTitle("Y"), Values: []float64{0, 1, 1}},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"by firstyear",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-12-31")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by: "firstyear",
+					n:  10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.KeyTitle("2018"), Values: []float64{1, 1, 2}},
+					{Title: charts.KeyTitle("2019"), Values: []float64{0, 1, 1}},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"by tier with name",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					by:   "tier",
+					name: "1-9",
+					n:    10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.ArtistTitle("X"), Values: []float64{1, 1, 2}},
+					{Title: charts.ArtistTitle("Y"), Values: []float64{0, 1, 1}},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"diversity",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			printDiversity{
+				printCharts: printCharts{by: "all"},
+				top:         1,
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.KeyTitle("entropy"), Values: []float64{0.92}},
+					{Title: charts.KeyTitle("gini"), Values: []float64{0.17}},
+					{Title: charts.KeyTitle("effective"), Values: []float64{1.89}},
+					{Title: charts.KeyTitle("top1"), Values: []float64{0.67}},
+				})},
+				Precision: 2,
+			},
+			true,
+		},
+		{
+			"milestones",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			func() [][]info.Song {
+				history := [][]info.Song{{}, {}, {{Artist: "Y", Title: "y"}}}
+				for i := 0; i < 60; i++ {
+					history[0] = append(history[0], info.Song{Artist: "X", Title: "x"})
+					history[1] = append(history[1], info.Song{Artist: "X", Title: "x"})
+				}
+				return history
+			}(),
+			printMilestones{n: 2, window: 90},
+			&format.Milestones{Milestones: []format.Milestone{
+				{Day: rsrc.ParseDay("2018-01-02"), Kind: "plays", Title: "X", Threshold: 100},
+				{Day: rsrc.ParseDay("2018-01-04"), Kind: "hours", Title: "X", Threshold: 10, Projected: true},
+				{Day: rsrc.ParseDay("2018-01-16"), Kind: "plays", Title: "X", Threshold: 500, Projected: true},
+			}},
+			true,
+		},
+		{
+			"related",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "Z", Title: "z"}},
+			},
+			printRelated{artist: "X", n: 10, top: 10, days: 1},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.ArtistTitle("Y"), Values: []float64{1}},
+					{Title: charts.ArtistTitle("Z"), Values: []float64{-1}},
+				})},
+				Numbered:  true,
+				Precision: 2,
+			},
+			true,
+		},
+		{
+			"related of unknown artist",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "Z", Title: "z"}},
+			},
+			printRelated{artist: "W", n: 10, top: 10, days: 1},
+			nil,
+			false,
+		},
+		{
+			"clusters",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "Z", Title: "z"}},
+			},
+			printClusters{k: 2, top: 10, days: 1},
+			&format.Clusters{Clusters: [][]string{{"X", "Y"}, {"Z"}}},
+			true,
+		},
+		{
+			"eras",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "Y", Title: "y"}},
+			},
+			printEras{curve: "fade", hl: 1, days: 1, similarity: .5, n: 1},
+			&format.Eras{Eras: []format.Era{
+				{
+					Begin:   rsrc.ParseDay("2018-01-01"),
+					End:     rsrc.ParseDay("2018-01-06"),
+					Artists: []format.Share{{Name: "X", Share: .897}},
+					Genres:  []format.Share{{Name: "pop", Share: .897}, {Name: "rock", Share: .103}},
+				},
+				{
+					Begin:   rsrc.ParseDay("2018-01-06"),
+					End:     rsrc.ParseDay("2018-01-09"),
+					Artists: []format.Share{{Name: "Y", Share: .862}},
+					Genres:  []format.Share{{Name: "rock", Share: .862}, {Name: "pop", Share: .138}},
+				},
+			}},
+			true,
+		},
+		{
+			"eras with unknown curve",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{{{Artist: "X", Title: "x"}}},
+			printEras{curve: "linear", hl: 1, days: 1, similarity: .5, n: 1},
+			nil,
+			false,
+		},
+		{
+			"seasons",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+			},
+			printSeasons{
+				printCharts: printCharts{keys: "artist", by: "all", n: 10},
+				fold:        "weekday",
+				years:       1,
+			},
+			&format.Seasons{
+				Buckets: []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+				Seasons: []format.Season{
+					{Name: "X", Score: .5, Profile: []float64{2.0 / 3, 0, 1.0 / 3, 0, 0, 0, 0}},
+					{Name: "Y", Score: .5, Profile: []float64{0, 2.0 / 3, 1.0 / 3, 0, 0, 0, 0}},
+				},
+			},
+			true,
+		},
+		{
+			"seasons with unknown fold",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{{{Artist: "X", Title: "x"}}},
+			printSeasons{
+				printCharts: printCharts{keys: "artist", by: "all", n: 10},
+				fold:        "year",
+			},
+			nil,
+			false,
+		},
 		{
 			"'all' with name invalid",
 			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
@@ -821,6 +1187,161 @@ func TestPrint(t *testing.T) {
 		// 	},
 		// 	true,
 		// },
+		{
+			"similar artists",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+			},
+			printSimilar{artist: "X", n: 10},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.ArtistTitle("Z"), Values: []float64{1}},
+					{Title: charts.ArtistTitle("Y"), Values: []float64{0.5}},
+				})},
+				Numbered:  true,
+				Precision: 2,
+			},
+			true,
+		},
+		{
+			"recommendations",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}},
+				{{Artist: "Y", Title: "y"}},
+			},
+			printRecommend{n: 10, maxPlays: 1},
+			&format.Charts{
+				Charts: []charts.Charts{charts.InOrder([]charts.Pair{
+					{Title: charts.ArtistTitle("Z"), Values: []float64{2}},
+					{Title: charts.ArtistTitle("Y"), Values: []float64{1}},
+				})},
+				Numbered:  true,
+				Precision: 0,
+			},
+			true,
+		},
+		{
+			"album completion",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "a", Album: "A"}, {Artist: "X", Title: "b", Album: "A"}},
+				{{Artist: "X", Title: "a", Album: "A"}, {Artist: "Y", Title: "y"}},
+			},
+			printAlbumsCompletion{n: 10},
+			&format.Albums{Albums: []format.Album{
+				{Artist: "X", Name: "A", Heard: 2, Tracks: 3, FullPlays: 0},
+			}},
+			true,
+		},
+		{
+			"loved songs",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "X", Title: "z"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					keys:  "song",
+					by:    "all",
+					loved: true,
+					n:     10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.FromMap(map[string][]float64{
+					"X - x": {1, 2, 2},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"loved tracks",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			printLoved{n: 10},
+			&format.Loved{Tracks: []format.LovedTrack{
+				{Artist: "X", Title: "x", Loved: rsrc.ParseDay("2018-01-02"), Before: 1, After: 2},
+			}},
+			true,
+		},
+		{
+			"loved songs ignoring case",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "x", Title: "X"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "X"}, {Artist: "X", Title: "X"}},
+			},
+			printTotal{
+				printCharts: printCharts{
+					keys:  "song",
+					by:    "all",
+					loved: true,
+					n:     10,
+				},
+			},
+			&format.Charts{
+				Charts: []charts.Charts{charts.FromMap(map[string][]float64{
+					"x - X": {1, 1},
+					"X - X": {0, 2},
+				})},
+				Numbered:   true,
+				Precision:  0,
+				Percentage: false,
+			},
+			true,
+		},
+		{
+			"loved tracks ignoring case",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "x", Title: "X"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "X"}, {Artist: "X", Title: "X"}},
+			},
+			printLoved{n: 10},
+			&format.Loved{Tracks: []format.LovedTrack{
+				{Artist: "X", Title: "x", Loved: rsrc.ParseDay("2018-01-02"), Before: 1, After: 2},
+			}},
+			true,
+		},
+		{
+			"correction suggestions",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "x", Title: "x"}},
+				{{Artist: "X", Title: "x"}},
+			},
+			correctionsSuggest{},
+			&format.Suggestions{Suggestions: []format.Suggestion{
+				{From: "x", To: "X", FromPlays: 1, ToPlays: 2},
+			}},
+			true,
+		},
+		{
+			"genres",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X", Title: "x"}, {Artist: "Y", Title: "y"}},
+				{{Artist: "X", Title: "x"}, {Artist: "X", Title: "x"}},
+			},
+			printGenres{depth: 1},
+			&format.Genres{Root: charts.GenreNode{Genre: "", Total: 4, Children: []charts.GenreNode{
+				{Genre: "pop", Plays: 3, Total: 3, Children: []charts.GenreNode{}},
+				{Genre: "rock", Plays: 1, Total: 1, Children: []charts.GenreNode{}},
+			}}},
+			true,
+		},
 		// TODO test corrections (in other test)
 		// TODO test normalized (in other test)
 	}
@@ -837,7 +1358,14 @@ func TestPrint(t *testing.T) {
 					rsrc.ArtistTags("Y"):         nil,
 					rsrc.TagInfo("pop"):          nil,
 					rsrc.TagInfo("rock"):         nil,
-					rsrc.TagInfo("french"):       nil}
+					rsrc.TagInfo("french"):       nil,
+					rsrc.ArtistSimilar("X"):      nil,
+					rsrc.ArtistSimilar("Y"):      nil,
+					rsrc.AlbumInfo("X", "A"):     nil,
+					rsrc.Loved(user):             nil,
+					rsrc.CreditExceptions(user):  nil,
+					rsrc.Rules(user):             nil,
+					rsrc.Correlations(user):      nil}
 
 			if c.user != nil && c.history != nil {
 				for i := range c.history {
@@ -855,6 +1383,21 @@ func TestPrint(t *testing.T) {
 			unpack.WriteTagInfo(tagPop, s)
 			unpack.WriteTagInfo(tagRock, s)
 			unpack.WriteTagInfo(tagFrench, s)
+			unpack.WriteSimilarArtists("X", []unpack.SimilarArtist{{Name: "Z", Match: 1}, {Name: "Y", Match: 0.5}}, s)
+			unpack.WriteSimilarArtists("Y", []unpack.SimilarArtist{}, s)
+			unpack.WriteLovedTracks([]unpack.LovedTrack{
+				{Artist: "X", Title: "x", Loved: rsrc.ParseDay("2018-01-02")},
+			}, user, s)
+			unpack.WriteCreditExceptions([]string{"X & Z"}, user, s)
+			unpack.WriteRules([]unpack.PartitionRule{
+				{Name: "French pop", Tags: []string{"pop"}, Country: "France"},
+				{Name: "2018 rock", Tags: []string{"rock"}, FromYear: 2018, ToYear: 2018},
+			}, user, s)
+			unpack.WriteAlbumInfo("X", "A", unpack.AlbumInfo{
+				Artist: "X",
+				Name:   "A",
+				Tracks: []unpack.AlbumTrack{{Title: "a"}, {Title: "b"}, {Title: "c"}},
+			}, s)
 
 			if c.user != nil && c.history != nil {
 				unpack.WriteBookmark(c.user.Registered.AddDate(0, 0, len(c.history)), user, s)
@@ -876,7 +1419,7 @@ func TestPrint(t *testing.T) {
 
 			session := &unpack.SessionInfo{User: user}
 			pl := pipeline.New(session, s)
-			err := c.cmd.Execute(session, s, pl, d)
+			err := c.cmd.Execute(context.Background(), session, s, pl, d)
 			if err != nil && c.ok {
 				t.Fatalf("unexpected error: %v", err)
 			} else if err == nil && !c.ok {
@@ -947,7 +1490,7 @@ func TestPrintTags(t *testing.T) {
 			unpack.WriteArtistTags(artist, c.tags, s)
 
 			pl := pipeline.New(nil, s)
-			err := c.cmd.Execute(nil, s, pl, d)
+			err := c.cmd.Execute(context.Background(), nil, s, pl, d)
 			if err != nil && c.ok {
 				t.Fatalf("unexpected error: %v", err)
 			} else if err == nil && !c.ok {
@@ -0,0 +1,171 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// correlations returns the correlations between the user's top artists in bins
+// of step days. They are cached and only recomputed if the step changed or if
+// the cache lacks some of the top artists or their daily plays changed, e.g.
+// by corrections, excluded plays or a new time zone.
+func correlations(ctx context.Context, user string, top, step int, s io.Store, pl pipeline.Pipeline,
+) (*unpack.Correlations, error) {
+	bookmark, err := unpack.LoadBookmark(user, s)
+	if err != nil {
+		return nil, err
+	}
+
+	sums, err := pl.Execute(ctx, []string{"artists", "sum", "cache", fmt.Sprintf("top,%v", top)})
+	if err != nil {
+		return nil, err
+	}
+	titles := sums.Titles()
+	names := make([]string, len(titles))
+	for i, title := range titles {
+		names[i] = title.String()
+	}
+
+	daily, err := pl.Execute(ctx, []string{"artists"})
+	if err != nil {
+		return nil, err
+	}
+	prints, err := charts.Fingerprints(ctx, daily, titles)
+	if err != nil {
+		return nil, err
+	}
+
+	if cached, err := unpack.LoadCorrelations(user, s); err == nil && cached.Step == step {
+		if corr, ok := subCorrelations(cached, names, prints); ok {
+			return corr, nil
+		}
+	}
+
+	values, err := charts.Correlations(ctx, daily, titles, step)
+	if err != nil {
+		return nil, err
+	}
+
+	corr := &unpack.Correlations{
+		Bookmark:     bookmark,
+		Step:         step,
+		Titles:       names,
+		Fingerprints: prints,
+		Values:       values,
+	}
+	return corr, unpack.WriteCorrelations(corr, user, s)
+}
+
+// subCorrelations returns the correlations between titles if corr contains all
+// of them with the same fingerprints.
+func subCorrelations(corr *unpack.Correlations, titles []string, prints []uint64,
+) (*unpack.Correlations, bool) {
+	idxs := map[string]int{}
+	for i, title := range corr.Titles {
+		idxs[title] = i
+	}
+
+	values := make([][]float64, len(titles))
+	for i, a := range titles {
+		ia, ok := idxs[a]
+		if !ok || corr.Fingerprints[ia] != prints[i] {
+			return nil, false
+		}
+		values[i] = make([]float64, len(titles))
+		for j, b := range titles {
+			if ib, ok := idxs[b]; ok {
+				values[i][j] = corr.Values[ia][ib]
+			}
+		}
+	}
+
+	return &unpack.Correlations{
+		Bookmark:     corr.Bookmark,
+		Step:         corr.Step,
+		Titles:       titles,
+		Fingerprints: prints,
+		Values:       values,
+	}, true
+}
+
+type printRelated struct {
+	artist string
+	n      int
+	top    int
+	days   int
+}
+
+func (cmd printRelated) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	corr, err := correlations(ctx, session.User, cmd.top, cmd.days, s, pl)
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	for i, title := range corr.Titles {
+		if title == cmd.artist {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("'%v' is not among the top %v artists", cmd.artist, cmd.top)
+	}
+
+	pairs := []charts.Pair{}
+	for i, title := range corr.Titles {
+		if i != idx {
+			pairs = append(pairs, charts.Pair{
+				Title:  charts.ArtistTitle(title),
+				Values: []float64{corr.Values[idx][i]},
+			})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].Values[0] > pairs[j].Values[0]
+	})
+	if cmd.n >= 0 && len(pairs) > cmd.n {
+		pairs = pairs[:cmd.n]
+	}
+
+	return d.Display(&format.Charts{
+		Context:   ctx,
+		Charts:    []charts.Charts{charts.InOrder(pairs)},
+		Numbered:  true,
+		Precision: 2,
+	})
+}
+
+type printClusters struct {
+	k    int
+	top  int
+	days int
+}
+
+func (cmd printClusters) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	corr, err := correlations(ctx, session.User, cmd.top, cmd.days, s, pl)
+	if err != nil {
+		return err
+	}
+
+	clusters := [][]string{}
+	for _, cluster := range charts.Clusters(corr.Values, cmd.k) {
+		names := make([]string, len(cluster))
+		for i, idx := range cluster {
+			names[i] = corr.Titles[idx]
+		}
+		clusters = append(clusters, names)
+	}
+
+	return d.Display(&format.Clusters{Clusters: clusters})
+}
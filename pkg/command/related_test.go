@@ -0,0 +1,49 @@
+package command
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+func TestSubCorrelations(t *testing.T) {
+	corr := &unpack.Correlations{
+		Bookmark:     rsrc.ParseDay("2018-01-02"),
+		Step:         7,
+		Titles:       []string{"A", "B", "C"},
+		Fingerprints: []uint64{1, 2, 3},
+		Values: [][]float64{
+			{1, .1, .2},
+			{.1, 1, .3},
+			{.2, .3, 1},
+		},
+	}
+
+	for _, c := range []struct {
+		name   string
+		titles []string
+		prints []uint64
+		values [][]float64
+		ok     bool
+	}{
+		{"all", []string{"A", "B", "C"}, []uint64{1, 2, 3}, corr.Values, true},
+		{"reordered subset", []string{"C", "A"}, []uint64{3, 1}, [][]float64{{1, .2}, {.2, 1}}, true},
+		{"missing title", []string{"A", "D"}, []uint64{1, 4}, nil, false},
+		{"changed plays", []string{"A", "B"}, []uint64{1, 5}, nil, false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			sub, ok := subCorrelations(corr, c.titles, c.prints)
+			if ok != c.ok {
+				t.Fatalf("ok: has %v, want %v", ok, c.ok)
+			}
+			if !ok {
+				return
+			}
+			if !reflect.DeepEqual(sub.Titles, c.titles) || !reflect.DeepEqual(sub.Values, c.values) {
+				t.Errorf("has %v %v, want %v %v", sub.Titles, sub.Values, c.titles, c.values)
+			}
+		})
+	}
+}
@@ -51,13 +51,22 @@ var cmdRoot = node{
 var cmdLastfm = node{
 	cmd: exeHelp,
 	nodes: map[string]node{
-		"help":     cmdHelp,
-		"print":    cmdPrint,
-		"session":  cmdSession,
-		"table":    cmdTable,
-		"timeline": {cmd: exeTimeline},
-		"update":   cmdUpdate,
-		"info":     {cmd: exeInfo},
+		"corrections": cmdCorrections,
+		"duplicates":  cmdDuplicates,
+		"fetch":       {cmd: exeFetch},
+		"help":        cmdHelp,
+		"plays":       cmdPlays,
+		"print":       cmdPrint,
+		"repair":      {cmd: exeRepair},
+		"session":     cmdSession,
+		"storage":     cmdStorage,
+		"table":       cmdTable,
+		"tags":        cmdTags,
+		"timeline":    {cmd: exeTimeline},
+		"timezone":    cmdTimezone,
+		"update":      cmdUpdate,
+		"verify":      {cmd: exeVerify},
+		"info":        {cmd: exeInfo},
 	},
 }
 
@@ -67,24 +76,40 @@ var cmdHelp = node{
 
 var cmdPrint = node{
 	nodes: nodes{
-		"fade":     node{cmd: exePrintFade},
-		"period":   node{cmd: exePrintPeriod},
-		"interval": node{cmd: exePrintInterval},
-		"fademax":  node{cmd: exePrintFadeMax},
-		"tags":     node{cmd: exePrintTags},
-		"total":    node{cmd: exePrintTotal},
-		"after":    node{cmd: exePrintAfter},
-		"periods":  node{cmd: exePrintPeriods},
-		"fades":    node{cmd: exePrintFades},
-		"raw":      node{cmd: exePrintRaw},
+		"fade":       node{cmd: exePrintFade},
+		"period":     node{cmd: exePrintPeriod},
+		"interval":   node{cmd: exePrintInterval},
+		"fademax":    node{cmd: exePrintFadeMax},
+		"tags":       node{cmd: exePrintTags},
+		"total":      node{cmd: exePrintTotal},
+		"after":      node{cmd: exePrintAfter},
+		"periods":    node{cmd: exePrintPeriods},
+		"fades":      node{cmd: exePrintFades},
+		"raw":        node{cmd: exePrintRaw},
+		"similar":    node{cmd: exePrintSimilar},
+		"recommend":  node{cmd: exePrintRecommend},
+		"loved":      node{cmd: exePrintLoved},
+		"genres":     node{cmd: exePrintGenres},
+		"diversity":  node{cmd: exePrintDiversity},
+		"milestones": node{cmd: exePrintMilestones},
+		"related":    node{cmd: exePrintRelated},
+		"clusters":   node{cmd: exePrintClusters},
+		"eras":       node{cmd: exePrintEras},
+		"seasons":    node{cmd: exePrintSeasons},
+		"albums": node{
+			nodes: nodes{
+				"completion": node{cmd: exePrintAlbumsCompletion},
+			},
+		},
 	},
 }
 
 var cmdTable = node{
 	nodes: nodes{
-		"fade":   node{cmd: exeTableFade},
-		"period": node{cmd: exeTablePeriods},
-		"total":  node{cmd: exeTableTotal},
+		"fade":      node{cmd: exeTableFade},
+		"period":    node{cmd: exeTablePeriods},
+		"total":     node{cmd: exeTableTotal},
+		"diversity": node{cmd: exeTableDiversity},
 	},
 }
 
@@ -98,6 +123,41 @@ var cmdSession = node{
 	},
 }
 
+var cmdCorrections = node{
+	cmd: exeCorrectionsList,
+	nodes: nodes{
+		"list":    node{cmd: exeCorrectionsList},
+		"add":     node{cmd: exeCorrectionsAdd},
+		"remove":  node{cmd: exeCorrectionsRemove},
+		"suggest": node{cmd: exeCorrectionsSuggest},
+	},
+}
+
+var cmdDuplicates = node{
+	cmd: exeDuplicatesList,
+	nodes: nodes{
+		"list":    node{cmd: exeDuplicatesList},
+		"exclude": node{cmd: exeDuplicatesExclude},
+	},
+}
+
+var cmdPlays = node{
+	cmd: exePlaysList,
+	nodes: nodes{
+		"list":   node{cmd: exePlaysList},
+		"add":    node{cmd: exePlaysAdd},
+		"remove": node{cmd: exePlaysRemove},
+		"edit":   node{cmd: exePlaysEdit},
+		"drop":   node{cmd: exePlaysDrop},
+	},
+}
+
+var cmdStorage = node{
+	nodes: nodes{
+		"fsck": node{cmd: exeStorageFsck},
+	},
+}
+
 var cmdUpdate = node{
 	cmd: &cmd{
 		descr: "updates a user's history",
@@ -106,6 +166,114 @@ var cmdUpdate = node{
 		},
 		session: true,
 	},
+	nodes: nodes{
+		"loved":   node{cmd: exeUpdateLoved},
+		"rebuild": node{cmd: exeUpdateRebuild},
+	},
+}
+
+var cmdTags = node{
+	nodes: nodes{
+		"supertags": cmdTagMap("supertags"),
+		"countries": cmdTagMap("countries"),
+		"genres":    cmdTagMap("genres"),
+		"blacklist": node{
+			cmd: exeTagsList("blacklist"),
+			nodes: nodes{
+				"list":   node{cmd: exeTagsList("blacklist")},
+				"add":    node{cmd: exeTagsBlacklist},
+				"remove": node{cmd: exeTagsRemove("blacklist")},
+			},
+		},
+	},
+}
+
+func cmdTagMap(kind string) node {
+	return node{
+		cmd: exeTagsList(kind),
+		nodes: nodes{
+			"list":   node{cmd: exeTagsList(kind)},
+			"set":    node{cmd: exeTagsSet(kind)},
+			"remove": node{cmd: exeTagsRemove(kind)},
+		},
+	}
+}
+
+var cmdTimezone = node{
+	cmd: exeTimezonePrint,
+	nodes: nodes{
+		"set": node{cmd: exeTimezoneSet},
+	},
+}
+
+var exeUpdateLoved = &cmd{
+	descr: "downloads a user's loved tracks",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return updateLoved{}
+	},
+	session: true,
+}
+
+var exeUpdateRebuild = &cmd{
+	descr: "sorts the plays into days again, e.g. after the time zone was changed",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return rebuildHistory{}
+	},
+	session: true,
+}
+
+func exeTagsList(kind string) *cmd {
+	return &cmd{
+		descr: fmt.Sprintf("lists the configured %v", kind),
+		get: func(params []interface{}, opts map[string]interface{}) command {
+			return tagsList{kind: kind}
+		},
+	}
+}
+
+func exeTagsSet(kind string) *cmd {
+	return &cmd{
+		descr: fmt.Sprintf("sets the entry of a tag in the %v", kind),
+		get: func(params []interface{}, opts map[string]interface{}) command {
+			return tagsSet{kind: kind, tag: params[0].(string), value: params[1].(string)}
+		},
+		params: params{parTag, parTagValue},
+	}
+}
+
+func exeTagsRemove(kind string) *cmd {
+	return &cmd{
+		descr: fmt.Sprintf("removes a tag from the %v", kind),
+		get: func(params []interface{}, opts map[string]interface{}) command {
+			return tagsRemove{kind: kind, tag: params[0].(string)}
+		},
+		params: params{parTag},
+	}
+}
+
+var exeTagsBlacklist = &cmd{
+	descr: "adds a tag to the blacklist",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return tagsBlacklist{tag: params[0].(string)}
+	},
+	params: params{parTag},
+}
+
+var exeTimezonePrint = &cmd{
+	descr: "prints the time zone in which plays are sorted into days",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return timezonePrint{}
+	},
+	session: true,
+}
+
+var exeTimezoneSet = &cmd{
+	descr: "sets the time zone in which plays are sorted into days",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return timezoneSet{name: params[0].(string)}
+	},
+	params:  params{parTimezone},
+	session: true,
 }
 
 var exeInfo = &cmd{
@@ -117,6 +285,43 @@ var exeInfo = &cmd{
 	session: false,
 }
 
+var exeFetch = &cmd{
+	descr: "downloads the resources that were missing while working offline",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return fetchMissing{}
+	},
+}
+
+var exeStorageFsck = &cmd{
+	descr: "checks the stored files for damage and the prepared history for missing days",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return storageFsck{
+			quarantine: opts["quarantine"].(bool),
+			refetch:    opts["refetch"].(bool),
+		}
+	},
+	options: options{
+		"quarantine": optQuarantine,
+		"refetch":    optRefetch,
+	},
+}
+
+var exeVerify = &cmd{
+	descr: "lists the days whose stored plays don't match the totals reported by Last.fm",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return verifyHistory{}
+	},
+	session: true,
+}
+
+var exeRepair = &cmd{
+	descr: "re-fetches the days whose stored plays don't match the totals reported by Last.fm",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return repairHistory{}
+	},
+	session: true,
+}
+
 var exeHelp = &cmd{
 	descr: "gives help",
 	get: func(params []interface{}, opts map[string]interface{}) command {
@@ -135,6 +340,9 @@ var exePrintFade = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			hl:   params[0].(float64),
@@ -150,6 +358,9 @@ var exePrintFade = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"date":       optDate,
 	},
@@ -167,6 +378,9 @@ var exePrintPeriod = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			period: params[0].(string),
@@ -185,6 +399,9 @@ var exePrintPeriod = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 	},
 	session: true,
@@ -201,6 +418,9 @@ var exePrintInterval = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			begin: getDay(params[0]),
@@ -216,6 +436,9 @@ var exePrintInterval = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 	},
 	session: true,
@@ -232,6 +455,9 @@ var exePrintFadeMax = &cmd{
 			percentage: false, // Disabled since it makes no sense here
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			hl: params[0].(float64),
@@ -245,6 +471,9 @@ var exePrintFadeMax = &cmd{
 		"n":          optArtistCount,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 	},
 	session: true,
@@ -258,6 +487,75 @@ var exePrintTags = &cmd{
 	params: params{parArtistName},
 }
 
+var exePrintSimilar = &cmd{
+	descr: "prints the artists that are similar to an artist",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printSimilar{
+			artist: params[0].(string),
+			n:      opts["n"].(int),
+		}
+	},
+	params: params{parArtistName},
+	options: options{
+		"n": optArtistCount,
+	},
+	session: true,
+}
+
+var exePrintRecommend = &cmd{
+	descr: "prints artists that are similar to the ones a user listens to but were played rarely",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printRecommend{
+			n:        opts["n"].(int),
+			maxPlays: opts["max"].(float64),
+		}
+	},
+	options: options{
+		"n":   optArtistCount,
+		"max": optMaxPlays,
+	},
+	session: true,
+}
+
+var exePrintLoved = &cmd{
+	descr: "prints a user's loved tracks and how often they were played before and after being loved",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printLoved{n: opts["n"].(int)}
+	},
+	options: options{
+		"n": optSongCount,
+	},
+	session: true,
+}
+
+var exePrintGenres = &cmd{
+	descr: "prints the plays per genre as a tree of genres and their subgenres",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printGenres{
+			genre:    opts["genre"].(string),
+			depth:    opts["depth"].(int),
+			duration: opts["duration"].(bool),
+		}
+	},
+	options: options{
+		"genre":    optGenre,
+		"depth":    optGenreDepth,
+		"duration": optChartsDuration,
+	},
+	session: true,
+}
+
+var exePrintAlbumsCompletion = &cmd{
+	descr: "prints which share of the tracks of each album was heard and how often it was played through",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printAlbumsCompletion{n: opts["n"].(int)}
+	},
+	options: options{
+		"n": optAlbumCount,
+	},
+	session: true,
+}
+
 var exePrintTotal = &cmd{
 	descr: "tables a user's top artists by total number of plays",
 	get: func(params []interface{}, opts map[string]interface{}) command {
@@ -269,6 +567,9 @@ var exePrintTotal = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			date: getDay(opts["date"]),
@@ -282,6 +583,9 @@ var exePrintTotal = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"date":       optDate,
 	},
@@ -299,6 +603,9 @@ var exePrintAfter = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			n: params[0].(int),
@@ -313,6 +620,9 @@ var exePrintAfter = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"date":       optDate,
 	},
@@ -330,6 +640,9 @@ var exePrintPeriods = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			period: params[0].(string),
@@ -350,6 +663,9 @@ var exePrintPeriods = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"begin":      optBegin,
 		"end":        optEnd,
@@ -368,6 +684,9 @@ var exePrintFades = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			hl:     params[0].(float64),
@@ -389,6 +708,9 @@ var exePrintFades = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"begin":      optBegin,
 		"end":        optEnd,
@@ -437,6 +759,9 @@ var exeTableFade = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			hl:   params[0].(float64),
@@ -452,12 +777,185 @@ var exeTableFade = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"step":       optStep,
 	},
 	session: true,
 }
 
+var exePrintDiversity = &cmd{
+	descr: "prints how diverse a user's listening is in fading charts, a half-life of 0 uses the total plays",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printDiversity{printCharts: printCharts{
+			keys:     opts["keys"].(string),
+			by:       opts["by"].(string),
+			name:     opts["name"].(string),
+			duration: opts["duration"].(bool),
+			identity: opts["identity"].(string),
+			credits:  opts["credits"].(string),
+			loved:    opts["loved"].(bool),
+		},
+			hl:   params[0].(float64),
+			top:  opts["top"].(int),
+			date: getDay(opts["date"]),
+		}
+	},
+	params: params{parHL},
+	options: options{
+		"keys":     optChartsKeys,
+		"by":       optChartType,
+		"name":     optGenericName,
+		"duration": optChartsDuration,
+		"identity": optChartsIdentity,
+		"credits":  optChartsCredits,
+		"loved":    optChartsLoved,
+		"top":      optDiversityTop,
+		"date":     optDate,
+	},
+	session: true,
+}
+
+var exeTableDiversity = &cmd{
+	descr: "tables how diverse a user's listening is in fading charts, a half-life of 0 uses the total plays",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return tableDiversity{printCharts: printCharts{
+			keys:     opts["keys"].(string),
+			by:       opts["by"].(string),
+			name:     opts["name"].(string),
+			duration: opts["duration"].(bool),
+			identity: opts["identity"].(string),
+			credits:  opts["credits"].(string),
+			loved:    opts["loved"].(bool),
+		},
+			hl:   params[0].(float64),
+			top:  opts["top"].(int),
+			step: opts["step"].(int),
+		}
+	},
+	params: params{parHL},
+	options: options{
+		"keys":     optChartsKeys,
+		"by":       optChartType,
+		"name":     optGenericName,
+		"duration": optChartsDuration,
+		"identity": optChartsIdentity,
+		"credits":  optChartsCredits,
+		"loved":    optChartsLoved,
+		"top":      optDiversityTop,
+		"step":     optStep,
+	},
+	session: true,
+}
+
+var exePrintMilestones = &cmd{
+	descr: "prints milestones of the user and of artists and projects the upcoming ones at the recent rate",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printMilestones{
+			n:      opts["n"].(int),
+			window: opts["window"].(int),
+		}
+	},
+	options: options{
+		"n":      optMilestoneCount,
+		"window": optMilestoneWindow,
+	},
+	session: true,
+}
+
+var exePrintRelated = &cmd{
+	descr: "prints the artists that are most often listened to at the same time as an artist",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printRelated{
+			artist: params[0].(string),
+			n:      opts["n"].(int),
+			top:    opts["pool"].(int),
+			days:   opts["days"].(int),
+		}
+	},
+	params: params{parArtistName},
+	options: options{
+		"n":    optArtistCount,
+		"pool": optCorrelationPool,
+		"days": optCorrelationDays,
+	},
+	session: true,
+}
+
+var exePrintClusters = &cmd{
+	descr: "prints clusters of top artists that are listened to at the same time",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printClusters{
+			k:    opts["k"].(int),
+			top:  opts["pool"].(int),
+			days: opts["days"].(int),
+		}
+	},
+	options: options{
+		"k":    optClusterCount,
+		"pool": optClusterPool,
+		"days": optCorrelationDays,
+	},
+	session: true,
+}
+
+var exePrintEras = &cmd{
+	descr: "prints eras in which the same artists dominated a user's listening",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printEras{
+			curve:      opts["curve"].(string),
+			hl:         opts["hl"].(float64),
+			days:       opts["sample"].(int),
+			similarity: opts["similarity"].(float64),
+			n:          opts["n"].(int),
+		}
+	},
+	options: options{
+		"curve":      optEraCurve,
+		"hl":         optEraHL,
+		"sample":     optEraSample,
+		"similarity": optEraSimilarity,
+		"n":          optEraArtists,
+	},
+	session: true,
+}
+
+var exePrintSeasons = &cmd{
+	descr: "prints the titles that a user listens to most strongly in certain months or on certain weekdays",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return printSeasons{printCharts: printCharts{
+			keys:     opts["keys"].(string),
+			by:       opts["by"].(string),
+			name:     opts["name"].(string),
+			n:        opts["n"].(int),
+			duration: opts["duration"].(bool),
+			identity: opts["identity"].(string),
+			credits:  opts["credits"].(string),
+			loved:    opts["loved"].(bool),
+		},
+			fold:  opts["fold"].(string),
+			min:   opts["min"].(float64),
+			years: opts["years"].(int),
+		}
+	},
+	options: options{
+		"keys":     optChartsKeys,
+		"by":       optChartType,
+		"name":     optGenericName,
+		"n":        optArtistCount,
+		"duration": optChartsDuration,
+		"identity": optChartsIdentity,
+		"credits":  optChartsCredits,
+		"loved":    optChartsLoved,
+		"fold":     optSeasonFold,
+		"min":      optSeasonMin,
+		"years":    optSeasonYears,
+	},
+	session: true,
+}
+
 var exeTablePeriods = &cmd{
 	descr: "tables a user's top artists by total number of plays in the specified periods",
 	get: func(params []interface{}, opts map[string]interface{}) command {
@@ -469,6 +967,9 @@ var exeTablePeriods = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			period: params[0].(string),
@@ -487,6 +988,9 @@ var exeTablePeriods = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 	},
 	session: true,
@@ -503,6 +1007,9 @@ var exeTableTotal = &cmd{
 			percentage: opts["%"].(bool),
 			normalized: opts["normalized"].(bool),
 			duration:   opts["duration"].(bool),
+			identity:   opts["identity"].(string),
+			credits:    opts["credits"].(string),
+			loved:      opts["loved"].(bool),
 			entry:      opts["entry"].(float64),
 		},
 			step: opts["step"].(int),
@@ -516,6 +1023,9 @@ var exeTableTotal = &cmd{
 		"%":          optChartsPercentage,
 		"normalized": optChartsNormalized,
 		"duration":   optChartsDuration,
+		"identity":   optChartsIdentity,
+		"credits":    optChartsCredits,
+		"loved":      optChartsLoved,
 		"entry":      optChartsEntry,
 		"step":       optStep,
 	},
@@ -555,6 +1065,154 @@ var exeSessionConfig = &cmd{
 	params: params{parOptionName, parOptionValue},
 }
 
+var exeCorrectionsList = &cmd{
+	descr: "lists a user's artist corrections",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return correctionsList{}
+	},
+	session: true,
+}
+
+var exeCorrectionsAdd = &cmd{
+	descr: "adds an artist correction",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return correctionsAdd{
+			from: params[0].(string),
+			to:   params[1].(string),
+		}
+	},
+	params:  params{parCorrectionFrom, parCorrectionTo},
+	session: true,
+}
+
+var exeCorrectionsRemove = &cmd{
+	descr: "removes an artist correction",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return correctionsRemove{from: params[0].(string)}
+	},
+	params:  params{parCorrectionFrom},
+	session: true,
+}
+
+var exeCorrectionsSuggest = &cmd{
+	descr: "suggests artist corrections for names that likely refer to the same artist",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return correctionsSuggest{accept: opts["accept"].(string)}
+	},
+	options: options{
+		"accept": optAcceptSuggestions,
+	},
+	session: true,
+}
+
+var exeDuplicatesList = &cmd{
+	descr: "lists plays that were likely scrobbled more than once",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return duplicatesList{
+			window: opts["window"].(int),
+			share:  opts["share"].(float64),
+		}
+	},
+	options: options{
+		"window": optDuplicateWindow,
+		"share":  optDuplicateShare,
+	},
+	session: true,
+}
+
+var exeDuplicatesExclude = &cmd{
+	descr: "excludes plays that were likely scrobbled more than once from the charts",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return duplicatesExclude{
+			window: opts["window"].(int),
+			share:  opts["share"].(float64),
+		}
+	},
+	options: options{
+		"window": optDuplicateWindow,
+		"share":  optDuplicateShare,
+	},
+	session: true,
+}
+
+var exePlaysList = &cmd{
+	descr: "lists the plays that were added, removed or edited by hand",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return playsList{}
+	},
+	session: true,
+}
+
+var exePlaysAdd = &cmd{
+	descr: "adds a play that was not scrobbled",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return playsAdd{
+			day:      getDay(params[0]),
+			artist:   params[1].(string),
+			title:    params[2].(string),
+			album:    opts["album"].(string),
+			duration: opts["duration"].(float64),
+			time:     opts["time"].(int),
+		}
+	},
+	params: params{parPlayDay, parArtistName, parSongTitle},
+	options: options{
+		"album":    optPlayAlbum,
+		"duration": optPlayDuration,
+		"time":     optPlayTime,
+	},
+	session: true,
+}
+
+var exePlaysRemove = &cmd{
+	descr: "removes a scrobbled play",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return playsRemove{
+			day:    getDay(params[0]),
+			artist: params[1].(string),
+			title:  params[2].(string),
+			time:   opts["time"].(int),
+		}
+	},
+	params: params{parPlayDay, parArtistName, parSongTitle},
+	options: options{
+		"time": optPlayTime,
+	},
+	session: true,
+}
+
+var exePlaysEdit = &cmd{
+	descr: "changes the artist, title or album of a scrobbled play",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return playsEdit{
+			day:       getDay(params[0]),
+			artist:    params[1].(string),
+			title:     params[2].(string),
+			time:      opts["time"].(int),
+			newArtist: opts["newartist"].(string),
+			newTitle:  opts["newtitle"].(string),
+			newAlbum:  opts["newalbum"].(string),
+		}
+	},
+	params: params{parPlayDay, parArtistName, parSongTitle},
+	options: options{
+		"time":      optPlayTime,
+		"newartist": optPlayNewArtist,
+		"newtitle":  optPlayNewTitle,
+		"newalbum":  optPlayNewAlbum,
+	},
+	session: true,
+}
+
+var exePlaysDrop = &cmd{
+	descr: "undoes a change to the plays by its number in 'plays list'",
+	get: func(params []interface{}, opts map[string]interface{}) command {
+		return playsDrop{n: params[0].(int)}
+	},
+	params:  params{parChangeNumber},
+	session: true,
+}
+
 var parOptionName = &param{
 	"option name",
 	"a name of an option",
@@ -579,6 +1237,54 @@ var parArtistName = &param{
 	"string",
 }
 
+var parCorrectionFrom = &param{
+	"from",
+	"the artist name that is corrected",
+	"string",
+}
+
+var parCorrectionTo = &param{
+	"to",
+	"the artist name it is corrected to",
+	"string",
+}
+
+var parPlayDay = &param{
+	"day",
+	"day of a play in the format YYYY-MM-DD",
+	"time",
+}
+
+var parSongTitle = &param{
+	"title",
+	"the title of a song",
+	"string",
+}
+
+var parChangeNumber = &param{
+	"number",
+	"the number of a change as listed by 'plays list'",
+	"int",
+}
+
+var parTag = &param{
+	"tag",
+	"a Last.fm tag",
+	"string",
+}
+
+var parTagValue = &param{
+	"value",
+	"the supertag, country or parent genre the tag belongs to",
+	"string",
+}
+
+var parTimezone = &param{
+	"time zone",
+	"an IANA time zone name like 'Europe/Berlin'",
+	"string",
+}
+
 var parHL = &param{
 	"half-life",
 	"span of days over which a 'scrobble' loses half its value",
@@ -611,7 +1317,7 @@ var parStr1 = &param{
 // TODO name any key (see above) of option are duplicate
 var optChartType = &option{
 	param{"by",
-		"'all' or 'super'",
+		"'all', 'super', 'country', 'tags', 'groups', 'custom', 'year', 'firstyear', 'firstquarter', 'tier', 'genre' or a subgenre like 'genre:metal'",
 		"string"}, // TODO make something like an enum
 	"all",
 }
@@ -623,6 +1329,20 @@ var optGenericName = &option{
 	"",
 }
 
+var optGenre = &option{
+	param{"genre",
+		"the genre whose subgenres are shown, all genres if empty",
+		"string"},
+	"",
+}
+
+var optGenreDepth = &option{
+	param{"depth",
+		"number of levels of subgenres that are shown",
+		"int"},
+	"2",
+}
+
 var optArtistCount = &option{
 	param{"n",
 		"number of artists",
@@ -630,6 +1350,27 @@ var optArtistCount = &option{
 	"10",
 }
 
+var optSongCount = &option{
+	param{"n",
+		"number of songs",
+		"int"},
+	"10",
+}
+
+var optAlbumCount = &option{
+	param{"n",
+		"number of albums",
+		"int"},
+	"10",
+}
+
+var optAcceptSuggestions = &option{
+	param{"accept",
+		"'all' or a comma separated list of the numbers of suggestions that are written",
+		"string"},
+	"",
+}
+
 var optChartsDuration = &option{
 	param{"duration",
 		"if charts are compiled by song duration",
@@ -637,6 +1378,27 @@ var optChartsDuration = &option{
 	"false",
 }
 
+var optChartsIdentity = &option{
+	param{"identity",
+		"'name' to group plays by name or 'mbid' to group them by MusicBrainz ID where it is known",
+		"string"},
+	"name",
+}
+
+var optChartsCredits = &option{
+	param{"credits",
+		"'full' or 'share' to split collaborations between the credited artists, who get the full play or an equal share of it",
+		"string"},
+	"",
+}
+
+var optChartsLoved = &option{
+	param{"loved",
+		"if only loved songs and the artists of loved songs are included",
+		"bool"},
+	"false",
+}
+
 var optChartsPercentage = &option{
 	param{"%",
 		"if charts are in percentage",
@@ -665,6 +1427,118 @@ var optChartsEntry = &option{
 	"0",
 }
 
+var optDiversityTop = &option{
+	param{"top",
+		"number of titles whose combined share is measured",
+		"int"},
+	"10",
+}
+
+var optMilestoneCount = &option{
+	param{"n",
+		"number of reached and of projected milestones",
+		"int"},
+	"20",
+}
+
+var optMilestoneWindow = &option{
+	param{"window",
+		"number of recent days whose rate is used for projections",
+		"int"},
+	"90",
+}
+
+var optCorrelationPool = &option{
+	param{"pool",
+		"number of top artists among which related artists are searched",
+		"int"},
+	"250",
+}
+
+var optClusterPool = &option{
+	param{"pool",
+		"number of top artists that are clustered",
+		"int"},
+	"50",
+}
+
+var optCorrelationDays = &option{
+	param{"days",
+		"number of days in which plays count as being at the same time",
+		"int"},
+	"7",
+}
+
+var optClusterCount = &option{
+	param{"k",
+		"number of clusters",
+		"int"},
+	"5",
+}
+
+var optEraCurve = &option{
+	param{"curve",
+		"curve that eras are detected in, 'fade' or 'gaussian'",
+		"string"},
+	"fade",
+}
+
+var optEraHL = &option{
+	param{"hl",
+		"half-life of the fading curve",
+		"float"},
+	"90",
+}
+
+var optEraSample = &option{
+	param{"sample",
+		"number of days between the compared points in time",
+		"int"},
+	"30",
+}
+
+var optEraSimilarity = &option{
+	param{"similarity",
+		"similarity to the current era below which a new era begins, between 0 and 1",
+		"float"},
+	"0.5",
+}
+
+var optEraArtists = &option{
+	param{"n",
+		"number of artists that define an era",
+		"int"},
+	"5",
+}
+
+var optSeasonFold = &option{
+	param{"fold",
+		"what days are folded by, 'month' or 'weekday'",
+		"string"},
+	"month",
+}
+
+var optSeasonMin = &option{
+	param{"min",
+		"minimum number of plays of a title",
+		"float"},
+	"50",
+}
+
+var optSeasonYears = &option{
+	param{"years",
+		"minimum number of years in which a title was played",
+		"int"},
+	"2",
+}
+
+var optSmoothing = &option{
+	param{"smoothing",
+		"kernel that year partitions and offsets are based on: 'gaussian[,sigma[,width]]', 'triangular[,width]', 'box[,width]' or 'ema[,span]'",
+		"string"},
+	"gaussian",
+}
+
 var optDate = &option{
 	param{"date",
 		"a date in the format YYYY-MM-DD",
@@ -689,6 +1563,83 @@ var optPrecision = &option{
 	"2",
 }
 
+var optDuplicateWindow = &option{
+	param{"window",
+		"number of seconds within which a repeated play is a duplicate",
+		"int"},
+	"30",
+}
+
+var optDuplicateShare = &option{
+	param{"share",
+		"share of a song's duration within which a repeated play is a duplicate, 0 to disable",
+		"float"},
+	"0.5",
+}
+
+var optPlayAlbum = &option{
+	param{"album",
+		"album of the play",
+		"string"},
+	"",
+}
+
+var optPlayDuration = &option{
+	param{"duration",
+		"duration of the play in minutes",
+		"float"},
+	"0",
+}
+
+var optPlayTime = &option{
+	param{"time",
+		"Unix time of the play, 0 to refer to the first play of the song on that day",
+		"int"},
+	"0",
+}
+
+var optPlayNewArtist = &option{
+	param{"newartist",
+		"new artist of the play",
+		"string"},
+	"",
+}
+
+var optPlayNewTitle = &option{
+	param{"newtitle",
+		"new title of the play",
+		"string"},
+	"",
+}
+
+var optPlayNewAlbum = &option{
+	param{"newalbum",
+		"new album of the play",
+		"string"},
+	"",
+}
+
+var optMaxPlays = &option{
+	param{"max",
+		"maximum number of plays of an artist",
+		"float"},
+	"10",
+}
+
+var optQuarantine = &option{
+	param{"quarantine",
+		"if broken files are moved to the quarantine directory",
+		"bool"},
+	"false",
+}
+
+var optRefetch = &option{
+	param{"refetch",
+		"if broken and missing history is fetched again",
+		"bool"},
+	"false",
+}
+
 var optStep = &option{
 	param{"step",
 		"date step", // TODO
@@ -707,6 +1658,7 @@ var storableOptions = []*option{
 	optChartsEntry,
 	optDate,
 	optStep,
+	optSmoothing,
 }
 
 func resolve(args []string, session *unpack.SessionInfo) (cmd command, err error) {
@@ -114,17 +114,17 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm", "print", "total"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-n=25"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 25}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 25}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-%=TRUE"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10, percentage: true}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10, percentage: true}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-n=k25"},
@@ -141,47 +141,220 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm", "print", "total", "-by=super", "-n=25"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "super", name: "", n: 25}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "super", name: "", identity: "name", n: 25}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=super", "-normalized", "-date=2018-02-01"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "super", name: "", normalized: true, n: 10}, date: rsrc.ParseDay("2018-02-01")}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "super", name: "", identity: "name", normalized: true, n: 10}, date: rsrc.ParseDay("2018-02-01")}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=year"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "year", name: "", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "year", name: "", identity: "name", n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=year", "-name=2018"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "year", name: "2018", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "year", name: "2018", identity: "name", n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=year", "-entry=60"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "year", entry: 60, n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "year", identity: "name", entry: 60, n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=year", "-entry=60", "-keys=song"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "song", by: "year", entry: 60, n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "song", by: "year", identity: "name", entry: 60, n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=country"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "country", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "country", identity: "name", n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-by=total"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "total", name: "", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "total", name: "", identity: "name", n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "print", "total", "-duration"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "all", duration: true, n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", identity: "name", duration: true, n: 10}}, true,
+		},
+		{
+			[]string{"lastfm", "print", "total", "-identity=mbid"},
+			&unpack.SessionInfo{User: "user"},
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", identity: "mbid", n: 10}}, true,
+		},
+		{
+			[]string{"lastfm", "print", "total", "-credits=share"},
+			&unpack.SessionInfo{User: "user"},
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", identity: "name", credits: "share", n: 10}}, true,
+		},
+		{
+			[]string{"lastfm", "print", "total", "-loved"},
+			&unpack.SessionInfo{User: "user"},
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", identity: "name", loved: true, n: 10}}, true,
+		},
+		{
+			[]string{"lastfm", "print", "loved", "-n=5"},
+			&unpack.SessionInfo{User: "user"},
+			printLoved{n: 5}, true,
+		},
+		{
+			[]string{"lastfm", "print", "genres", "-genre=metal", "-depth=1"},
+			&unpack.SessionInfo{User: "user"},
+			printGenres{genre: "metal", depth: 1}, true,
+		},
+		{
+			[]string{"lastfm", "print", "diversity", "365", "-by=super", "-top=5"},
+			&unpack.SessionInfo{User: "user"},
+			printDiversity{printCharts: printCharts{keys: "artist", by: "super", identity: "name"}, hl: 365, top: 5}, true,
+		},
+		{
+			[]string{"lastfm", "table", "diversity", "0", "-step=7"},
+			&unpack.SessionInfo{User: "user"},
+			tableDiversity{printCharts: printCharts{keys: "artist", by: "all", identity: "name"}, top: 10, step: 7}, true,
+		},
+		{
+			[]string{"lastfm", "print", "milestones", "-window=30"},
+			&unpack.SessionInfo{User: "user"},
+			printMilestones{n: 20, window: 30}, true,
+		},
+		{
+			[]string{"lastfm", "print", "related", "ABBA", "-n=5"},
+			&unpack.SessionInfo{User: "user"},
+			printRelated{artist: "ABBA", n: 5, top: 250, days: 7}, true,
+		},
+		{
+			[]string{"lastfm", "print", "clusters", "-k=3", "-days=1"},
+			&unpack.SessionInfo{User: "user"},
+			printClusters{k: 3, top: 50, days: 1}, true,
+		},
+		{
+			[]string{"lastfm", "print", "eras", "-hl=180", "-similarity=0.3"},
+			&unpack.SessionInfo{User: "user"},
+			printEras{curve: "fade", hl: 180, days: 30, similarity: .3, n: 5}, true,
+		},
+		{
+			[]string{"lastfm", "print", "seasons", "-by=super", "-fold=weekday"},
+			&unpack.SessionInfo{User: "user"},
+			printSeasons{
+				printCharts: printCharts{
+					keys:     "artist",
+					by:       "super",
+					n:        10,
+					identity: "name",
+				},
+				fold:  "weekday",
+				min:   50,
+				years: 2,
+			}, true,
+		},
+		{
+			[]string{"lastfm", "update", "loved"},
+			&unpack.SessionInfo{User: "user"},
+			updateLoved{}, true,
+		},
+		{
+			[]string{"lastfm", "update", "rebuild"},
+			&unpack.SessionInfo{User: "user"},
+			rebuildHistory{}, true,
+		},
+		{
+			[]string{"lastfm", "verify"},
+			&unpack.SessionInfo{User: "user"},
+			verifyHistory{}, true,
+		},
+		{
+			[]string{"lastfm", "repair"},
+			&unpack.SessionInfo{User: "user"},
+			repairHistory{}, true,
+		},
+		{
+			[]string{"lastfm", "tags", "supertags"},
+			&unpack.SessionInfo{User: "user"},
+			tagsList{kind: "supertags"}, true,
+		},
+		{
+			[]string{"lastfm", "tags", "countries", "set", "german", "Germany"},
+			&unpack.SessionInfo{User: "user"},
+			tagsSet{kind: "countries", tag: "german", value: "Germany"}, true,
+		},
+		{
+			[]string{"lastfm", "tags", "blacklist", "add", "seen live"},
+			&unpack.SessionInfo{User: "user"},
+			tagsBlacklist{tag: "seen live"}, true,
+		},
+		{
+			[]string{"lastfm", "tags", "blacklist", "remove", "seen live"},
+			&unpack.SessionInfo{User: "user"},
+			tagsRemove{kind: "blacklist", tag: "seen live"}, true,
+		},
+		{
+			[]string{"lastfm", "timezone"},
+			&unpack.SessionInfo{User: "user"},
+			timezonePrint{}, true,
+		},
+		{
+			[]string{"lastfm", "timezone", "set", "Europe/Berlin"},
+			&unpack.SessionInfo{User: "user"},
+			timezoneSet{name: "Europe/Berlin"}, true,
+		},
+		{
+			[]string{"lastfm", "corrections"},
+			&unpack.SessionInfo{User: "user"},
+			correctionsList{}, true,
+		},
+		{
+			[]string{"lastfm", "corrections", "add", "a", "A"},
+			&unpack.SessionInfo{User: "user"},
+			correctionsAdd{from: "a", to: "A"}, true,
+		},
+		{
+			[]string{"lastfm", "corrections", "add", "a"},
+			&unpack.SessionInfo{User: "user"}, nil, false,
+		},
+		{
+			[]string{"lastfm", "corrections", "remove", "a"},
+			&unpack.SessionInfo{User: "user"},
+			correctionsRemove{from: "a"}, true,
+		},
+		{
+			[]string{"lastfm", "corrections", "suggest", "-accept=1,3"},
+			&unpack.SessionInfo{User: "user"},
+			correctionsSuggest{accept: "1,3"}, true,
+		},
+		{
+			[]string{"lastfm", "duplicates"},
+			&unpack.SessionInfo{User: "user"},
+			duplicatesList{window: 30, share: 0.5}, true,
+		},
+		{
+			[]string{"lastfm", "duplicates", "exclude", "-window=10", "-share=0"},
+			&unpack.SessionInfo{User: "user"},
+			duplicatesExclude{window: 10, share: 0}, true,
+		},
+		{
+			[]string{"lastfm", "plays"},
+			&unpack.SessionInfo{User: "user"},
+			playsList{}, true,
+		},
+		{
+			[]string{"lastfm", "plays", "add", "2018-01-01", "A", "a", "-duration=3.5"},
+			&unpack.SessionInfo{User: "user"},
+			playsAdd{day: rsrc.ParseDay("2018-01-01"), artist: "A", title: "a", duration: 3.5}, true,
+		},
+		{
+			[]string{"lastfm", "plays", "edit", "2018-01-01", "A", "a", "-time=100", "-newartist=B"},
+			&unpack.SessionInfo{User: "user"},
+			playsEdit{day: rsrc.ParseDay("2018-01-01"), artist: "A", title: "a", time: 100, newArtist: "B"}, true,
+		},
+		{
+			[]string{"lastfm", "plays", "drop", "x"},
+			&unpack.SessionInfo{User: "user"}, nil, false,
 		},
 		{
 			[]string{"lastfm", "print", "asdf"},
@@ -194,12 +367,12 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm", "print", "fade", "30.25"},
 			&unpack.SessionInfo{User: "user"},
-			printFade{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, hl: 30.25}, true,
+			printFade{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, hl: 30.25}, true,
 		},
 		{
 			[]string{"lastfm", "print", "fade", "30.25", "-name=DYD"},
 			&unpack.SessionInfo{User: "user"},
-			printFade{printCharts: printCharts{keys: "artist", by: "all", name: "DYD", n: 10}, hl: 30.25}, true,
+			printFade{printCharts: printCharts{keys: "artist", by: "all", name: "DYD", identity: "name", n: 10}, hl: 30.25}, true,
 		},
 		{
 			[]string{"lastfm", "print", "fade", "30.25", "-name"},
@@ -208,12 +381,12 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm", "print", "fade", "10", "-%"},
 			&unpack.SessionInfo{User: "user"},
-			printFade{printCharts: printCharts{keys: "artist", by: "all", n: 10, percentage: true}, hl: 10}, true,
+			printFade{printCharts: printCharts{keys: "artist", by: "all", identity: "name", n: 10, percentage: true}, hl: 10}, true,
 		},
 		{
 			[]string{"lastfm", "print", "fade", "10", "-normalized=True", "-date=2000-01-01"},
 			&unpack.SessionInfo{User: "user"},
-			printFade{printCharts: printCharts{keys: "artist", by: "all", n: 10, normalized: true}, hl: 10, date: rsrc.ParseDay("2000-01-01")}, true,
+			printFade{printCharts: printCharts{keys: "artist", by: "all", identity: "name", n: 10, normalized: true}, hl: 10, date: rsrc.ParseDay("2000-01-01")}, true,
 		},
 		{
 			[]string{"lastfm", "print", "fade"},
@@ -234,45 +407,45 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm", "print", "period", "2015"},
 			&unpack.SessionInfo{User: "user"},
-			printPeriod{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, period: "2015"}, true,
+			printPeriod{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, period: "2015"}, true,
 		},
 		{
 			[]string{"lastfm", "print", "period", "2015", "-%=1"},
 			&unpack.SessionInfo{User: "user"},
-			printPeriod{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10, percentage: true}, period: "2015"}, true,
+			printPeriod{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10, percentage: true}, period: "2015"}, true,
 		},
 		{
 			[]string{"lastfm", "print", "period", "2015", "-normalized=t"},
 			&unpack.SessionInfo{User: "user"},
-			printPeriod{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10, normalized: true}, period: "2015"}, true,
+			printPeriod{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10, normalized: true}, period: "2015"}, true,
 		},
 		{
 			[]string{"lastfm", "print", "interval", "2007-01-01", "2018-12-24"},
 			&unpack.SessionInfo{User: "user"},
 			printInterval{
-				printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10, normalized: false},
+				printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10, normalized: false},
 				begin:       rsrc.ParseDay("2007-01-01"),
 				end:         rsrc.ParseDay("2018-12-24")}, true,
 		},
 		{
 			[]string{"lastfm", "print", "fademax", "66"},
 			&unpack.SessionInfo{User: "user"},
-			printFadeMax{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10, percentage: false}, hl: 66}, true,
+			printFadeMax{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10, percentage: false}, hl: 66}, true,
 		},
 		{
 			[]string{"lastfm", "print", "periods", "3y"},
 			&unpack.SessionInfo{User: "user"},
-			printPeriods{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10, percentage: false}, period: "3y", begin: rsrc.ParseDay("0001-01-01"), end: rsrc.ParseDay("9999-12-31")}, true,
+			printPeriods{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10, percentage: false}, period: "3y", begin: rsrc.ParseDay("0001-01-01"), end: rsrc.ParseDay("9999-12-31")}, true,
 		},
 		{
 			[]string{"lastfm", "print", "periods", "3y", "-keys=song", "-end=2022-01-01"},
 			&unpack.SessionInfo{User: "user"},
-			printPeriods{printCharts: printCharts{keys: "song", by: "all", name: "", n: 10, percentage: false}, period: "3y", begin: rsrc.ParseDay("0001-01-01"), end: rsrc.ParseDay("2022-01-01")}, true,
+			printPeriods{printCharts: printCharts{keys: "song", by: "all", name: "", identity: "name", n: 10, percentage: false}, period: "3y", begin: rsrc.ParseDay("0001-01-01"), end: rsrc.ParseDay("2022-01-01")}, true,
 		},
 		{
 			[]string{"lastfm", "print", "fades", "333", "3y", "-keys=song", "-end=2022-01-01"},
 			&unpack.SessionInfo{User: "user"},
-			printFades{printCharts: printCharts{keys: "song", by: "all", name: "", n: 10, percentage: false}, hl: 333, period: "3y", begin: rsrc.ParseDay("0001-01-01"), end: rsrc.ParseDay("2022-01-01")}, true,
+			printFades{printCharts: printCharts{keys: "song", by: "all", name: "", identity: "name", n: 10, percentage: false}, hl: 333, period: "3y", begin: rsrc.ParseDay("0001-01-01"), end: rsrc.ParseDay("2022-01-01")}, true,
 		},
 		{
 			[]string{"lastfm", "print", "tags", "Add"},
@@ -285,22 +458,22 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm-csv", "print", "total"},
 			&unpack.SessionInfo{User: "user"},
-			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}}, true,
 		},
 		{
 			[]string{"lastfm", "table", "total"},
 			&unpack.SessionInfo{User: "user"},
-			tableTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, step: 1}, true,
+			tableTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, step: 1}, true,
 		},
 		{
 			[]string{"lastfm", "table", "total", "-step=200"},
 			&unpack.SessionInfo{User: "user"},
-			tableTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, step: 200}, true,
+			tableTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, step: 200}, true,
 		},
 		{
 			[]string{"lastfm", "table", "period", "1y"},
 			&unpack.SessionInfo{User: "user"},
-			tablePeriods{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, period: "1y"}, true,
+			tablePeriods{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, period: "1y"}, true,
 		},
 		// {
 		// 	[]string{"lastfm", "timeline", "-before=2008-01-23", "-from=2000-11-03"},
@@ -313,25 +486,25 @@ func TestResolve(t *testing.T) {
 		{
 			[]string{"lastfm-csv", "table", "fade", "10"},
 			&unpack.SessionInfo{User: "user"},
-			tableFade{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, hl: 10, step: 1}, true,
+			tableFade{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, hl: 10, step: 1}, true,
 		},
 		{
 			// relevant option stored
 			[]string{"lastfm-csv", "table", "fade", "10"},
 			&unpack.SessionInfo{User: "user", Options: map[string]string{"step": "30"}},
-			tableFade{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, hl: 10, step: 30}, true,
+			tableFade{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, hl: 10, step: 30}, true,
 		},
 		{
 			// irrelevant option stored
 			[]string{"lastfm", "print", "total"},
 			&unpack.SessionInfo{User: "user", Options: map[string]string{"step": "30"}},
-			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}}, true,
+			printTotal{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}}, true,
 		},
 		{
 			// explicit parameter overrides stored parameter
 			[]string{"lastfm-csv", "table", "fade", "10", "-step=25"},
 			&unpack.SessionInfo{User: "user", Options: map[string]string{"step": "30"}},
-			tableFade{printCharts: printCharts{keys: "artist", by: "all", name: "", n: 10}, hl: 10, step: 25}, true,
+			tableFade{printCharts: printCharts{keys: "artist", by: "all", name: "", identity: "name", n: 10}, hl: 10, step: 25}, true,
 		},
 		{
 			// raw steps
@@ -365,3 +538,22 @@ func TestResolve(t *testing.T) {
 	}
 
 }
+
+func TestIsCommand(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		ok   bool
+	}{
+		{"print", true},
+		{"session", true},
+		{"", false},
+		{"lastfm", false},
+		{"favicon.ico", false},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			if ok := IsCommand(c.name); ok != c.ok {
+				t.Errorf("expected %v but got %v", c.ok, ok)
+			}
+		})
+	}
+}
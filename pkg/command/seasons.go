@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type printSeasons struct {
+	printCharts
+	fold  string
+	min   float64
+	years int
+}
+
+func (cmd printSeasons) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	var buckets []string
+	switch cmd.fold {
+	case "month":
+		for m := time.January; m <= time.December; m++ {
+			buckets = append(buckets, m.String()[:3])
+		}
+	case "weekday":
+		for i := 1; i <= 7; i++ {
+			buckets = append(buckets, time.Weekday(i % 7).String()[:3])
+		}
+	default:
+		return fmt.Errorf("fold '%v' is unknown, use 'month' or 'weekday'", cmd.fold)
+	}
+
+	steps, err := cmd.getSteps()
+	if err != nil {
+		return err
+	}
+
+	cha, err := pl.Execute(ctx, setStep(steps))
+	if err != nil {
+		return err
+	}
+
+	seasons, err := charts.Seasons(ctx, cha, pl.Registered(), cmd.fold == "weekday")
+	if err != nil {
+		return err
+	}
+
+	candidates := []charts.Season{}
+	for _, season := range seasons {
+		if season.Total >= cmd.min && season.Years >= cmd.years {
+			candidates = append(candidates, season)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Title.String() < candidates[j].Title.String()
+	})
+	if len(candidates) > cmd.n {
+		candidates = candidates[:cmd.n]
+	}
+
+	f := &format.Seasons{Buckets: buckets, Seasons: make([]format.Season, len(candidates))}
+	for i, season := range candidates {
+		f.Seasons[i] = format.Season{
+			Name:    season.Title.String(),
+			Score:   season.Score,
+			Profile: season.Profile,
+		}
+	}
+	return d.Display(f)
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -15,7 +16,7 @@ import (
 type sessionInfo struct{}
 
 func (cmd sessionInfo) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	if session == nil {
 		d.Display(&format.Message{Msg: "no session is running"})
 	} else {
@@ -32,7 +33,7 @@ type sessionStart struct {
 }
 
 func (cmd sessionStart) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	if session != nil {
 		return fmt.Errorf("a session is already running for '%v'", session.User)
 	}
@@ -43,7 +44,7 @@ func (cmd sessionStart) Execute(
 type sessionStop struct{}
 
 func (cmd sessionStop) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	if session == nil {
 		return errors.New("no session is running")
 	}
@@ -56,7 +57,7 @@ type sessionConfig struct {
 }
 
 func (cmd sessionConfig) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	if session == nil {
 		return errors.New("no session is running")
 	}
@@ -73,6 +74,11 @@ func (cmd sessionConfig) Execute(
 	if !found {
 		return fmt.Errorf("option '%v' doesn't exist", cmd.option)
 	}
+	if cmd.option == "smoothing" {
+		if err := pipeline.CheckSmoothing(cmd.value); err != nil {
+			return err
+		}
+	}
 
 	params := make(map[string]string)
 	for k, v := range session.Options {
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -27,7 +28,7 @@ func TestSessionInfo(t *testing.T) {
 			cmd := sessionInfo{}
 
 			pl := pipeline.New(c.session, nil)
-			err := cmd.Execute(c.session, nil, pl, d)
+			err := cmd.Execute(context.Background(), c.session, nil, pl, d)
 			if err != nil && c.ok {
 				t.Fatalf("unexpected error: %v", err)
 			} else if err == nil && !c.ok {
@@ -126,6 +127,20 @@ func TestSession(t *testing.T) {
 			false,
 			&unpack.SessionInfo{User: "U", Options: map[string]string{}},
 		},
+		{
+			"config: smoothing",
+			&unpack.SessionInfo{User: "U", Options: map[string]string{}},
+			sessionConfig{"smoothing", "box,14"},
+			true,
+			&unpack.SessionInfo{User: "U", Options: map[string]string{"smoothing": "box,14"}},
+		},
+		{
+			"config: smoothing invalid",
+			&unpack.SessionInfo{User: "U", Options: map[string]string{}},
+			sessionConfig{"smoothing", "median,3"},
+			false,
+			&unpack.SessionInfo{User: "U", Options: map[string]string{}},
+		},
 	}
 
 	for _, c := range cases {
@@ -135,7 +150,7 @@ func TestSession(t *testing.T) {
 			d := mock.NewDisplay()
 
 			pl := pipeline.New(c.sessionPre, s)
-			err := c.cmd.Execute(c.sessionPre, s, pl, d)
+			err := c.cmd.Execute(context.Background(), c.sessionPre, s, pl, d)
 			if err != nil && c.ok {
 				t.Fatalf("unexpected error: %v", err)
 			} else if err == nil && !c.ok {
@@ -0,0 +1,123 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type printSimilar struct {
+	artist string
+	n      int
+}
+
+func (cmd printSimilar) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	// only the artist itself is loaded, the graph of the whole history would
+	// take a request per artist
+	graph, err := organize.LoadSimilarityGraph([]string{cmd.artist}, io.WithContext(ctx, s))
+	if err != nil {
+		return err
+	}
+
+	neighbors := graph.Neighbors(cmd.artist)
+	scores := make([]organize.Score, 0, len(neighbors))
+	for name, match := range neighbors {
+		scores = append(scores, organize.Score{Name: name, Score: match})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].Name < scores[j].Name
+	})
+
+	return d.Display(&format.Charts{
+		Context:   ctx,
+		Charts:    []charts.Charts{scoreCharts(scores, cmd.n)},
+		Numbered:  true,
+		Precision: 2,
+	})
+}
+
+type printRecommend struct {
+	n        int
+	maxPlays float64
+}
+
+func (cmd printRecommend) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	plays, err := artistPlays(ctx, pl)
+	if err != nil {
+		return err
+	}
+
+	artists := make([]string, 0, len(plays))
+	for artist := range plays {
+		artists = append(artists, artist)
+	}
+	sort.Strings(artists)
+
+	graph, err := organize.LoadSimilarityGraph(artists, s)
+	if len(graph) == 0 && err != nil {
+		return err
+	}
+
+	scores := graph.Recommend(plays, cmd.maxPlays)
+	if len(scores) == 0 {
+		return d.Display(&format.Message{
+			Msg: fmt.Sprintf("no similar artists with at most %v plays were found", cmd.maxPlays)})
+	}
+
+	return d.Display(&format.Charts{
+		Context:   ctx,
+		Charts:    []charts.Charts{scoreCharts(scores, cmd.n)},
+		Numbered:  true,
+		Precision: 0,
+	})
+}
+
+// artistPlays returns the total number of plays of every artist in the user's
+// history.
+func artistPlays(ctx context.Context, pl pipeline.Pipeline) (map[string]float64, error) {
+	cha, err := pl.Execute(ctx, []string{"artists", "sum", "column,-1"})
+	if err != nil {
+		return nil, err
+	}
+
+	titles := cha.Titles()
+	data, err := cha.Data(ctx, titles, 0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	plays := make(map[string]float64, len(titles))
+	for i, title := range titles {
+		plays[title.Artist()] = data[i][0]
+	}
+	return plays, nil
+}
+
+// scoreCharts turns the first n scores into charts with a single column.
+func scoreCharts(scores []organize.Score, n int) charts.Charts {
+	if n >= 0 && len(scores) > n {
+		scores = scores[:n]
+	}
+
+	pairs := make([]charts.Pair, len(scores))
+	for i, score := range scores {
+		pairs[i] = charts.Pair{
+			Title:  charts.ArtistTitle(score.Name),
+			Values: []float64{score.Score},
+		}
+	}
+	return charts.InOrder(pairs)
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type storageFsck struct {
+	quarantine bool
+	refetch    bool
+}
+
+func (cmd storageFsck) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	paths, err := io.ListFiles(rsrc.Root)
+	if err != nil {
+		return err
+	}
+
+	report, err := organize.Fsck(paths, s)
+	if err != nil {
+		return err
+	}
+
+	for _, damage := range report.Broken {
+		d.Display(&format.Message{Msg: fmt.Sprintf("broken: %v: %v", damage.Path, damage.Err)})
+	}
+	for _, user := range sortedUsers(report.Missing) {
+		for _, day := range report.Missing[user] {
+			d.Display(&format.Message{Msg: fmt.Sprintf("missing: history of '%v' on %v", user, day)})
+		}
+	}
+
+	missing := 0
+	for _, days := range report.Missing {
+		missing += len(days)
+	}
+	d.Display(&format.Message{Msg: fmt.Sprintf("checked %v files, %v broken, %v days missing",
+		report.Checked, len(report.Broken), missing)})
+
+	// Broken files are also moved away when they are re-fetched. Resources that
+	// cannot be re-fetched here will be downloaded again on their next access.
+	if cmd.quarantine || cmd.refetch {
+		for _, damage := range report.Broken {
+			if err := io.Quarantine(rsrc.Root, damage.Path); err != nil {
+				return err
+			}
+		}
+		d.Display(&format.Message{Msg: fmt.Sprintf("quarantined %v files", len(report.Broken))})
+	}
+
+	if cmd.refetch {
+		days := organize.DamagedDays(report.Broken)
+		for user, missing := range report.Missing {
+			days[user] = append(days[user], missing...)
+		}
+
+		for _, user := range sortedUsers(days) {
+			if err := organize.RefetchDays(user, days[user], s); err != nil {
+				return err
+			}
+			d.Display(&format.Message{Msg: fmt.Sprintf("re-fetched %v days of '%v'", len(days[user]), user)})
+		}
+	}
+
+	return nil
+}
+
+func sortedUsers(days map[string][]rsrc.Day) []string {
+	users := make([]string, 0, len(days))
+	for user := range days {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	return users
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nilsbu/lastfm/pkg/charts"
@@ -17,7 +18,7 @@ type tableTotal struct {
 }
 
 func (cmd tableTotal) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -26,7 +27,7 @@ func (cmd tableTotal) Execute(
 	steps = setStep(steps, "sum", "cache")
 	steps = append(steps, fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -35,14 +36,15 @@ func (cmd tableTotal) Execute(
 
 	steps = append(steps, fmt.Sprintf("step,%vd", cmd.step))
 
-	cha, err = pl.Execute(steps)
+	cha, err = pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
 
 	f := &format.Table{
-		Charts: cha,
-		Ranges: ranges,
+		Context: ctx,
+		Charts:  cha,
+		Ranges:  ranges,
 	}
 
 	err = d.Display(f)
@@ -61,7 +63,7 @@ type tableFade struct {
 
 // TODO Test table fade
 func (cmd tableFade) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -71,7 +73,7 @@ func (cmd tableFade) Execute(
 
 	steps = append(steps, fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
@@ -79,14 +81,15 @@ func (cmd tableFade) Execute(
 
 	steps = append(steps, fmt.Sprintf("step,%vd", cmd.step))
 
-	cha, err = pl.Execute(steps)
+	cha, err = pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
 
 	f := &format.Table{
-		Charts: cha,
-		Ranges: ranges,
+		Context: ctx,
+		Charts:  cha,
+		Ranges:  ranges,
 	}
 
 	err = d.Display(f)
@@ -103,7 +106,7 @@ type tablePeriods struct {
 }
 
 func (cmd tablePeriods) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	steps, err := cmd.getSteps()
 	if err != nil {
 		return err
@@ -112,15 +115,16 @@ func (cmd tablePeriods) Execute(
 	steps = setStep(steps, "id")
 	steps = append(steps, fmt.Sprintf("periods,%v", cmd.period), "cache", fmt.Sprintf("top,%v", cmd.n))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
 	ranges, _ := charts.ParseRanges(cmd.period, pl.Registered(), cha.Len())
 
 	f := &format.Table{
-		Charts: cha,
-		Ranges: ranges,
+		Context: ctx,
+		Charts:  cha,
+		Ranges:  ranges,
 	}
 
 	return d.Display(f)
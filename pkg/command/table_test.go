@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -112,6 +113,30 @@ func TestTable(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"table diversity",
+			&unpack.User{Name: user, Registered: rsrc.ParseDay("2018-01-01")},
+			[][]info.Song{
+				{{Artist: "X"}},
+				{{Artist: "Y"}},
+				{{Artist: "X"}},
+			}, true,
+			tableDiversity{
+				printCharts: printCharts{by: "all"},
+				top:         1,
+				step:        1,
+			},
+			&format.Table{
+				Charts: charts.InOrder([]charts.Pair{
+					{Title: charts.KeyTitle("entropy"), Values: []float64{0, 1, 0.9182958340544896}},
+					{Title: charts.KeyTitle("gini"), Values: []float64{0, 0, 1.0 / 6}},
+					{Title: charts.KeyTitle("effective"), Values: []float64{1, 2, 1.8898815748423097}},
+					{Title: charts.KeyTitle("top1"), Values: []float64{1, .5, 2.0 / 3}},
+				}),
+				Ranges: charts.ParseRangesTrusted("1d", rsrc.ParseDay("2018-01-01"), 3),
+			},
+			true,
+		},
 		{
 			"table period; years",
 			&unpack.User{Name: user, Registered: rsrc.ParseDay("2017-12-30")},
@@ -219,7 +244,7 @@ func TestTable(t *testing.T) {
 
 			session := &unpack.SessionInfo{User: user}
 			pl := pipeline.New(session, s)
-			err := c.cmd.Execute(session, s, pl, d)
+			err := c.cmd.Execute(context.Background(), session, s, pl, d)
 			if err != nil && c.ok {
 				t.Fatalf("unexpected error: %v", err)
 			} else if err == nil && !c.ok {
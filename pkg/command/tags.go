@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type tagsList struct {
+	kind string
+}
+
+func (cmd tagsList) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tc, err := organize.LoadTagConfig(s)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	if cmd.kind == "blacklist" {
+		lines = append([]string{}, tc.Blacklist...)
+	} else {
+		tags, err := tagMap(tc, cmd.kind)
+		if err != nil {
+			return err
+		}
+		for tag, value := range tags {
+			lines = append(lines, fmt.Sprintf("%v -> %v", tag, value))
+		}
+	}
+	sort.Strings(lines)
+
+	return d.Display(&format.Message{Msg: strings.Join(lines, "\n")})
+}
+
+type tagsSet struct {
+	kind, tag, value string
+}
+
+func (cmd tagsSet) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tc, err := organize.LoadTagConfig(s)
+	if err != nil {
+		return err
+	}
+
+	tags, err := tagMap(tc, cmd.kind)
+	if err != nil {
+		return err
+	}
+	tags[normalizeTag(cmd.tag)] = strings.TrimSpace(cmd.value)
+
+	return writeTagConfig(tc, cmd.kind, s)
+}
+
+type tagsBlacklist struct {
+	tag string
+}
+
+func (cmd tagsBlacklist) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tc, err := organize.LoadTagConfig(s)
+	if err != nil {
+		return err
+	}
+
+	tag := normalizeTag(cmd.tag)
+	for _, t := range tc.Blacklist {
+		if t == tag {
+			return fmt.Errorf("'%v' is already blacklisted", tag)
+		}
+	}
+	tc.Blacklist = append(tc.Blacklist, tag)
+
+	return writeTagConfig(tc, "blacklist", s)
+}
+
+type tagsRemove struct {
+	kind, tag string
+}
+
+func (cmd tagsRemove) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	tc, err := organize.LoadTagConfig(s)
+	if err != nil {
+		return err
+	}
+
+	tag := normalizeTag(cmd.tag)
+	if cmd.kind == "blacklist" {
+		blacklist := []string{}
+		for _, t := range tc.Blacklist {
+			if t != tag {
+				blacklist = append(blacklist, t)
+			}
+		}
+		if len(blacklist) == len(tc.Blacklist) {
+			return fmt.Errorf("'%v' is not blacklisted", tag)
+		}
+		tc.Blacklist = blacklist
+	} else {
+		tags, err := tagMap(tc, cmd.kind)
+		if err != nil {
+			return err
+		}
+		if _, ok := tags[tag]; !ok {
+			return fmt.Errorf("there is no entry for '%v' in %v", tag, cmd.kind)
+		}
+		delete(tags, tag)
+	}
+
+	return writeTagConfig(tc, cmd.kind, s)
+}
+
+func tagMap(tc *organize.TagConfig, kind string) (map[string]string, error) {
+	switch kind {
+	case "supertags":
+		return tc.Supertags, nil
+	case "countries":
+		return tc.Countries, nil
+	case "genres":
+		return tc.Genres, nil
+	default:
+		return nil, fmt.Errorf("'%v' is no tag configuration, use 'supertags', 'countries' or 'genres'", kind)
+	}
+}
+
+// writeTagConfig writes one kind of the tag configuration. Running servers pick
+// it up on their next refresh or on SIGHUP.
+func writeTagConfig(tc *organize.TagConfig, kind string, s io.Store) error {
+	switch kind {
+	case "supertags":
+		return unpack.WriteSupertags(tc.Supertags, s)
+	case "countries":
+		return unpack.WriteCountries(tc.Countries, s)
+	case "genres":
+		if _, err := tc.GenreTree(); err != nil {
+			return err
+		}
+		return unpack.WriteGenres(tc.Genres, s)
+	default:
+		return unpack.WriteBlacklist(tc.Blacklist, s)
+	}
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
@@ -0,0 +1,122 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestTags(t *testing.T) {
+	cases := []struct {
+		name      string
+		supertags []byte
+		cmds      []command
+		ok        bool
+		post      map[string]string
+		blacklist []string
+	}{
+		{
+			"set supertag",
+			[]byte(`{"tags":{"metal":"metal"}}`),
+			[]command{tagsSet{kind: "supertags", tag: " Death Metal", value: "metal"}},
+			true,
+			map[string]string{"metal": "metal", "death metal": "metal"},
+			[]string{"a"},
+		},
+		{
+			"remove supertag",
+			[]byte(`{"tags":{"metal":"metal","pop":"pop"}}`),
+			[]command{tagsRemove{kind: "supertags", tag: "pop"}},
+			true,
+			map[string]string{"metal": "metal"},
+			[]string{"a"},
+		},
+		{
+			"remove unknown supertag",
+			[]byte(`{"tags":{"metal":"metal"}}`),
+			[]command{tagsRemove{kind: "supertags", tag: "pop"}},
+			false,
+			map[string]string{"metal": "metal"},
+			[]string{"a"},
+		},
+		{
+			"blacklist",
+			[]byte(`{"tags":{}}`),
+			[]command{tagsBlacklist{tag: "b"}, tagsRemove{kind: "blacklist", tag: "a"}},
+			true,
+			map[string]string{},
+			[]string{"b"},
+		},
+		{
+			"blacklist twice",
+			[]byte(`{"tags":{}}`),
+			[]command{tagsBlacklist{tag: "A"}},
+			false,
+			map[string]string{},
+			[]string{"a"},
+		},
+		{
+			"cyclic genres",
+			[]byte(`{"tags":{}}`),
+			[]command{tagsSet{kind: "genres", tag: "metal", value: "black metal"}},
+			false,
+			map[string]string{},
+			[]string{"a"},
+		},
+		{
+			"invalid file",
+			[]byte(`{"tags":{" metal":"metal"}}`),
+			[]command{tagsSet{kind: "supertags", tag: "pop", value: "pop"}},
+			false,
+			nil,
+			[]string{"a"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			session := &unpack.SessionInfo{User: "user"}
+			io0, _ := mock.IO(map[rsrc.Locator][]byte{
+				rsrc.Supertags(): c.supertags,
+				rsrc.Blacklist(): []byte(`{"tags":["a"]}`),
+			}, mock.Path)
+			s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+			var err error
+			for _, cmd := range c.cmds {
+				d := mock.NewDisplay()
+				if err = cmd.Execute(context.Background(), session, s, pipeline.New(session, s), d); err != nil {
+					break
+				}
+			}
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+
+			tc, err := organize.LoadTagConfig(s)
+			if c.post == nil {
+				if err == nil {
+					t.Error("expected invalid configuration")
+				}
+				return
+			} else if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if !reflect.DeepEqual(tc.Supertags, c.post) {
+				t.Errorf("wrong supertags\nhas:  %v\nwant: %v", tc.Supertags, c.post)
+			}
+			if !reflect.DeepEqual(tc.Blacklist, c.blacklist) {
+				t.Errorf("wrong blacklist\nhas:  %v\nwant: %v", tc.Blacklist, c.blacklist)
+			}
+		})
+	}
+}
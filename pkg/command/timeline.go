@@ -2,6 +2,7 @@ package command
 
 // TODO Re-add timeline
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -19,7 +20,7 @@ type printTimeline struct {
 }
 
 func (cmd printTimeline) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 
 	user, err := unpack.LoadUserInfo(session.User, unpack.NewCacheless(s))
 	if err != nil {
@@ -43,13 +44,13 @@ func (cmd printTimeline) Execute(
 
 	steps = setStep(steps, fmt.Sprintf("fade,%v", fcmd.hl))
 
-	cha, err := pl.Execute(steps)
+	cha, err := pl.Execute(ctx, steps)
 	if err != nil {
 		return err
 	}
 
 	titles := cha.Titles()
-	data, err := cha.Data(titles, 0, cha.Len())
+	data, err := cha.Data(ctx, titles, 0, cha.Len())
 	if err != nil {
 		return err
 	}
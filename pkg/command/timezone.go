@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type timezonePrint struct{}
+
+func (cmd timezonePrint) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	loc, err := organize.LoadTimezone(session.User, s)
+	if err != nil {
+		return errors.Wrap(err, "failed to load time zone")
+	}
+
+	return d.Display(&format.Message{Msg: loc.String()})
+}
+
+type timezoneSet struct {
+	name string
+}
+
+func (cmd timezoneSet) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	if _, err := time.LoadLocation(cmd.name); err != nil {
+		return errors.Wrapf(err, "'%v' is no valid time zone", cmd.name)
+	}
+
+	settings, err := unpack.LoadSettings(session.User, s)
+	if errors.Is(err, fs.ErrNotExist) {
+		settings = &unpack.Settings{}
+	} else if err != nil {
+		return errors.Wrap(err, "failed to load settings")
+	}
+	settings.Timezone = cmd.name
+	if err := unpack.WriteSettings(settings, session.User, s); err != nil {
+		return errors.Wrap(err, "failed to write settings")
+	}
+
+	return d.Display(&format.Message{
+		Msg: fmt.Sprintf("time zone set to %v, run 'update rebuild' to sort the history into days again", cmd.name),
+	})
+}
@@ -0,0 +1,67 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestTimezone(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings []byte
+		timezone string
+		ok       bool
+		post     string
+	}{
+		{"set", []byte(`{"timezone":"Local"}`), "UTC", true, "UTC"},
+		{"invalid name", []byte(`{"timezone":"Local"}`), "Nowhere/Atlantis", false, "Local"},
+		{"no settings", nil, "America/New_York", true, "America/New_York"},
+		{"corrupt settings", []byte(`{"timezone":`), "UTC", false, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			session := &unpack.SessionInfo{User: "user"}
+			io0, _ := mock.IO(map[rsrc.Locator][]byte{
+				rsrc.Settings("user"): c.settings,
+			}, mock.Path)
+			s, _ := io.NewStore([][]rsrc.IO{{io0}})
+
+			d := mock.NewDisplay()
+			err := timezoneSet{name: c.timezone}.Execute(
+				context.Background(), session, s, pipeline.New(session, s), d)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+
+			if c.post == "" {
+				if data, _ := s.Read(rsrc.Settings("user")); !reflect.DeepEqual(data, c.settings) {
+					t.Errorf("settings were overwritten with '%v'", string(data))
+				}
+				return
+			}
+
+			d = mock.NewDisplay()
+			err = timezonePrint{}.Execute(context.Background(), session, s, pipeline.New(session, s), d)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if len(d.Msgs) != 1 {
+				t.Fatalf("expected 1 message but got %v", len(d.Msgs))
+			}
+			if msg := d.Msgs[0].(*format.Message).Msg; msg != c.post {
+				t.Errorf("wrong time zone: has '%v', want '%v'", msg, c.post)
+			}
+		})
+	}
+}
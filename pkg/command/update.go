@@ -1,6 +1,7 @@
 package command
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
@@ -16,13 +17,18 @@ import (
 type updateHistory struct{}
 
 func (cmd updateHistory) Execute(
-	session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
 	user, err := unpack.LoadUserInfo(session.User, unpack.NewCacheless(s))
 	if err != nil {
 		return errors.Wrap(err, "failed to load user info")
 	}
 
-	today := rsrc.DayFromTime(time.Now())
+	loc, err := organize.LoadTimezone(user.Name, s)
+	if err != nil {
+		return errors.Wrap(err, "failed to load time zone")
+	}
+
+	today := rsrc.DayFromTime(time.Now().In(loc))
 	_, err = organize.UpdateHistory(user, today.AddDate(0, 0, 1), s, io.FreshStore(s))
 	if err != nil {
 		return errors.Wrap(err, "failed to update user history")
@@ -35,3 +41,13 @@ func (cmd updateHistory) Execute(
 
 	return nil
 }
+
+type rebuildHistory struct{}
+
+func (cmd rebuildHistory) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	if err := organize.RebuildHistory(session.User, s); err != nil {
+		return errors.Wrap(err, "failed to rebuild user history")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/display"
+	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/pipeline"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+type verifyHistory struct{}
+
+func (cmd verifyHistory) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	_, err := verify(session.User, s, d)
+	return err
+}
+
+type repairHistory struct{}
+
+func (cmd repairHistory) Execute(
+	ctx context.Context, session *unpack.SessionInfo, s io.Store, pl pipeline.Pipeline, d display.Display) error {
+	mismatches, err := verify(session.User, s, d)
+	if err != nil {
+		return err
+	}
+
+	if err := organize.RepairHistory(session.User, mismatches, s); err != nil {
+		return errors.Wrap(err, "failed to repair history")
+	}
+	return d.Display(&format.Message{Msg: fmt.Sprintf("re-fetched %v days", len(mismatches))})
+}
+
+// verify displays the days whose stored plays don't match the totals reported
+// by Last.fm and returns them.
+func verify(user string, s io.Store, d display.Display) ([]organize.Mismatch, error) {
+	mismatches, err := organize.VerifyHistory(user, s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range mismatches {
+		d.Display(&format.Message{Msg: fmt.Sprintf("mismatch: %v: %v of %v plays stored",
+			m.Day, m.Stored, m.Reported)})
+	}
+	d.Display(&format.Message{Msg: fmt.Sprintf("%v days don't match", len(mismatches))})
+
+	return mismatches, nil
+}
@@ -0,0 +1,122 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Albums lists albums with the share of their tracks that were heard and how
+// often they were played through.
+type Albums struct {
+	Albums []Album
+}
+
+// Album is a line of Albums. Heard is the number of tracks that were played at
+// least once, FullPlays the number of times the whole album was played.
+type Album struct {
+	Artist, Name string
+	Heard        int
+	Tracks       int
+	FullPlays    int
+}
+
+func (a Album) share() float64 {
+	if a.Tracks == 0 {
+		return 0
+	}
+	return 100 * float64(a.Heard) / float64(a.Tracks)
+}
+
+func (a Album) title() string {
+	return a.Artist + " - " + a.Name
+}
+
+func (f *Albums) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"#\";\"Artist\";\"Album\";\"Heard\";\"Tracks\";\"Share\";\"Full Plays\"\n")
+	for i, a := range f.Albums {
+		share := strings.Replace(fmt.Sprintf("%.2f", a.share()), ".", decimal, 1)
+		_, err := fmt.Fprintf(w, "%d;\"%v\";\"%v\";%d;%d;%v;%d\n",
+			i+1, a.Artist, a.Name, a.Heard, a.Tracks, share, a.FullPlays)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Albums) Plain(w io.Writer) error {
+	if len(f.Albums) == 0 {
+		return nil
+	}
+
+	numPattern := "%" + strconv.Itoa(int(math.Log10(float64(len(f.Albums))))+1) + "d: "
+	titleLen := 0
+	for _, a := range f.Albums {
+		if l := utf8.RuneCountInString(a.title()); l > titleLen {
+			titleLen = l
+		}
+	}
+
+	for i, a := range f.Albums {
+		title := a.title() + strings.Repeat(" ", titleLen-utf8.RuneCountInString(a.title()))
+		_, err := fmt.Fprintf(w, numPattern+"%v - %6.2f%% (%d/%d), %d full plays\n",
+			i+1, title, a.share(), a.Heard, a.Tracks, a.FullPlays)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Albums) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>#</td><td>Artist</td><td>Album</td><td>Heard</td><td>Full Plays</td></tr>")
+	for i, a := range f.Albums {
+		_, err := fmt.Fprintf(w,
+			"<tr><td>%d</td><td>%v</td><td>%v</td><td>%.2f%% (%d/%d)</td><td>%d</td></tr>",
+			i+1, a.Artist, a.Name, a.share(), a.Heard, a.Tracks, a.FullPlays)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type albumJSON struct {
+	Artist    string  `json:"artist"`
+	Album     string  `json:"album"`
+	Heard     int     `json:"heard"`
+	Tracks    int     `json:"tracks"`
+	Share     float64 `json:"share"`
+	FullPlays int     `json:"fullplays"`
+}
+
+func (f *Albums) JSON(w io.Writer) error {
+	albums := make([]albumJSON, len(f.Albums))
+	for i, a := range f.Albums {
+		albums[i] = albumJSON{
+			Artist:    a.Artist,
+			Album:     a.Name,
+			Heard:     a.Heard,
+			Tracks:    a.Tracks,
+			Share:     a.share() / 100,
+			FullPlays: a.FullPlays,
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Albums []albumJSON `json:"albums"`
+	}{albums})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
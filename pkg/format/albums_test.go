@@ -0,0 +1,52 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlbums(t *testing.T) {
+	f := &Albums{Albums: []Album{
+		{Artist: "A", Name: "X", Heard: 2, Tracks: 2, FullPlays: 3},
+		{Artist: "B", Name: "Long", Heard: 1, Tracks: 4, FullPlays: 0},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"#\";\"Artist\";\"Album\";\"Heard\";\"Tracks\";\"Share\";\"Full Plays\"\n" +
+				"1;\"A\";\"X\";2;2;100,00;3\n" +
+				"2;\"B\";\"Long\";1;4;25,00;0\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"1: A - X    - 100.00% (2/2), 3 full plays\n" +
+				"2: B - Long -  25.00% (1/4), 0 full plays\n",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"albums":[{"artist":"A","album":"X","heard":2,"tracks":2,"share":1,"fullplays":3},` +
+				`{"artist":"B","album":"Long","heard":1,"tracks":4,"share":0.25,"fullplays":0}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package format
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math"
@@ -13,6 +14,9 @@ import (
 )
 
 type Charts struct {
+	// Context cancels the computation of the charts. If it is nil,
+	// context.Background() is used.
+	Context    context.Context
 	Charts     []charts.Charts
 	Ranges     charts.Ranges
 	Numbered   bool
@@ -20,6 +24,13 @@ type Charts struct {
 	Percentage bool
 }
 
+func background(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
 type data struct {
 	titles [][]charts.Title
 	values [][][]float64
@@ -35,7 +46,7 @@ func prep(f *Charts) (*data, error) {
 		titles[i] = f.Charts[i].Titles()
 
 		var err error
-		values[i], err = f.Charts[i].Data(titles[i], 0, 1)
+		values[i], err = f.Charts[i].Data(background(f.Context), titles[i], 0, 1)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,70 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Clusters lists groups of artists that are listened to at the same time.
+type Clusters struct {
+	Clusters [][]string
+}
+
+func (f *Clusters) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"Cluster\";\"Artist\"\n")
+	for i, cluster := range f.Clusters {
+		for _, artist := range cluster {
+			if _, err := fmt.Fprintf(w, "%d;\"%v\"\n", i+1, artist); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (f *Clusters) Plain(w io.Writer) error {
+	if len(f.Clusters) == 0 {
+		return nil
+	}
+
+	numPattern := "%" + strconv.Itoa(int(math.Log10(float64(len(f.Clusters))))+1) + "d: "
+	for i, cluster := range f.Clusters {
+		_, err := fmt.Fprintf(w, numPattern+"%v\n", i+1, strings.Join(cluster, ", "))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Clusters) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<ol>")
+	defer fmt.Fprint(w, "</ol>")
+
+	for _, cluster := range f.Clusters {
+		if _, err := fmt.Fprintf(w, "<li>%v</li>", strings.Join(cluster, ", ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Clusters) JSON(w io.Writer) error {
+	clusters := f.Clusters
+	if clusters == nil {
+		clusters = [][]string{}
+	}
+
+	data, err := json.Marshal(struct {
+		Clusters [][]string `json:"clusters"`
+	}{clusters})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
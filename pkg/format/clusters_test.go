@@ -0,0 +1,54 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestClusters(t *testing.T) {
+	f := &Clusters{Clusters: [][]string{{"A", "B"}, {"C"}}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"Cluster\";\"Artist\"\n" +
+				"1;\"A\"\n" +
+				"1;\"B\"\n" +
+				"2;\"C\"\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"1: A, B\n" +
+				"2: C\n",
+		},
+		{
+			"html",
+			func(buf *bytes.Buffer) error { return f.HTML(buf) },
+			"<ol><li>A, B</li><li>C</li></ol>",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"clusters":[["A","B"],["C"]]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Suggestions lists proposed artist corrections with the plays of both names.
+type Suggestions struct {
+	Suggestions []Suggestion
+}
+
+// Suggestion is a line of Suggestions.
+type Suggestion struct {
+	From, To           string
+	FromPlays, ToPlays int
+}
+
+func (f *Suggestions) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"#\";\"From\";\"From Plays\";\"To\";\"To Plays\"\n")
+	for i, s := range f.Suggestions {
+		_, err := fmt.Fprintf(w, "%d;\"%v\";%d;\"%v\";%d\n",
+			i+1, s.From, s.FromPlays, s.To, s.ToPlays)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Suggestions) Plain(w io.Writer) error {
+	if len(f.Suggestions) == 0 {
+		return nil
+	}
+
+	numPattern := "%" + strconv.Itoa(int(math.Log10(float64(len(f.Suggestions))))+1) + "d: "
+	fromLen := 0
+	for _, s := range f.Suggestions {
+		if l := utf8.RuneCountInString(s.From); l > fromLen {
+			fromLen = l
+		}
+	}
+
+	for i, s := range f.Suggestions {
+		from := s.From + strings.Repeat(" ", fromLen-utf8.RuneCountInString(s.From))
+		_, err := fmt.Fprintf(w, numPattern+"%v -> %v (%d plays -> %d plays)\n",
+			i+1, from, s.To, s.FromPlays, s.ToPlays)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Suggestions) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>#</td><td>From</td><td>From Plays</td><td>To</td><td>To Plays</td></tr>")
+	for i, s := range f.Suggestions {
+		_, err := fmt.Fprintf(w,
+			"<tr><td>%d</td><td>%v</td><td>%d</td><td>%v</td><td>%d</td></tr>",
+			i+1, s.From, s.FromPlays, s.To, s.ToPlays)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type suggestionJSON struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	FromPlays int    `json:"fromPlays"`
+	ToPlays   int    `json:"toPlays"`
+}
+
+func (f *Suggestions) JSON(w io.Writer) error {
+	suggestions := make([]suggestionJSON, len(f.Suggestions))
+	for i, s := range f.Suggestions {
+		suggestions[i] = suggestionJSON{
+			From:      s.From,
+			To:        s.To,
+			FromPlays: s.FromPlays,
+			ToPlays:   s.ToPlays,
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Suggestions []suggestionJSON `json:"suggestions"`
+	}{suggestions})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
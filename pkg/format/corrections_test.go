@@ -0,0 +1,52 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSuggestions(t *testing.T) {
+	f := &Suggestions{Suggestions: []Suggestion{
+		{From: "bjork", To: "Björk", FromPlays: 1, ToPlays: 3},
+		{From: "Beatles", To: "The Beatles", FromPlays: 2, ToPlays: 10},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"#\";\"From\";\"From Plays\";\"To\";\"To Plays\"\n" +
+				"1;\"bjork\";1;\"Björk\";3\n" +
+				"2;\"Beatles\";2;\"The Beatles\";10\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"1: bjork   -> Björk (1 plays -> 3 plays)\n" +
+				"2: Beatles -> The Beatles (2 plays -> 10 plays)\n",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"suggestions":[{"from":"bjork","to":"Björk","fromPlays":1,"toPlays":3},` +
+				`{"from":"Beatles","to":"The Beatles","fromPlays":2,"toPlays":10}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package format
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,9 @@ import (
 )
 
 type DiffCharts struct {
+	// Context cancels the computation of the charts. If it is nil,
+	// context.Background() is used.
+	Context    context.Context
 	Charts     []charts.DiffCharts
 	Ranges     charts.Ranges
 	Numbered   bool
@@ -21,6 +25,7 @@ func convertToCharts(c *DiffCharts) *Charts {
 	for _, chart := range c.Charts {
 		charts.Charts = append(charts.Charts, chart)
 	}
+	charts.Context = c.Context
 	charts.Ranges = c.Ranges
 	charts.Numbered = c.Numbered
 	charts.Precision = c.Precision
@@ -57,12 +62,12 @@ type diffChartJSON struct {
 	Precision int       `json:"precision"`
 }
 
-func convertDiffDataToJSON(c charts.DiffCharts, precision int, d *data) ([]byte, error) {
+func convertDiffDataToJSON(ctx context.Context, c charts.DiffCharts, precision int, d *data) ([]byte, error) {
 	var jsonData diffChartJSON
 	jsonData.Precision = precision
 	for i, title := range d.titles[0] {
 		value := d.values[0][i][0]
-		place, prevValue, _ := c.Previous(title)
+		place, prevValue, _ := c.Previous(ctx, title)
 		chartData := diffChartData{
 			Title:     title.String(),
 			Value:     value,
@@ -87,7 +92,7 @@ func (c *DiffCharts) JSON(w io.Writer) error {
 		return err
 	}
 
-	bytes, err := convertDiffDataToJSON(c.Charts[0], c.Precision, d)
+	bytes, err := convertDiffDataToJSON(background(c.Context), c.Charts[0], c.Precision, d)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Duplicates lists plays that are suspected to be duplicates.
+type Duplicates struct {
+	Plays []Duplicate
+}
+
+// Duplicate is a line of Duplicates. Time is the Unix time of the play and Gap
+// the number of seconds since the previous play of the song.
+type Duplicate struct {
+	Artist, Title string
+	Time, Gap     int64
+}
+
+func (d Duplicate) name() string {
+	return d.Artist + " - " + d.Title
+}
+
+func (d Duplicate) time() string {
+	return time.Unix(d.Time, 0).UTC().Format("2006-01-02 15:04:05")
+}
+
+func (f *Duplicates) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"#\";\"Artist\";\"Title\";\"Time\";\"Gap\"\n")
+	for i, d := range f.Plays {
+		_, err := fmt.Fprintf(w, "%d;\"%v\";\"%v\";%v;%d\n",
+			i+1, d.Artist, d.Title, d.time(), d.Gap)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Duplicates) Plain(w io.Writer) error {
+	if len(f.Plays) == 0 {
+		return nil
+	}
+
+	numPattern := "%" + strconv.Itoa(int(math.Log10(float64(len(f.Plays))))+1) + "d: "
+	nameLen := 0
+	for _, d := range f.Plays {
+		if l := utf8.RuneCountInString(d.name()); l > nameLen {
+			nameLen = l
+		}
+	}
+
+	for i, d := range f.Plays {
+		name := d.name() + strings.Repeat(" ", nameLen-utf8.RuneCountInString(d.name()))
+		_, err := fmt.Fprintf(w, numPattern+"%v - %v, %ds after previous play\n",
+			i+1, name, d.time(), d.Gap)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Duplicates) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>#</td><td>Artist</td><td>Title</td><td>Time</td><td>Gap</td></tr>")
+	for i, d := range f.Plays {
+		_, err := fmt.Fprintf(w,
+			"<tr><td>%d</td><td>%v</td><td>%v</td><td>%v</td><td>%d</td></tr>",
+			i+1, d.Artist, d.Title, d.time(), d.Gap)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type duplicateJSON struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Time   int64  `json:"time"`
+	Gap    int64  `json:"gap"`
+}
+
+func (f *Duplicates) JSON(w io.Writer) error {
+	plays := make([]duplicateJSON, len(f.Plays))
+	for i, d := range f.Plays {
+		plays[i] = duplicateJSON{
+			Artist: d.Artist,
+			Title:  d.Title,
+			Time:   d.Time,
+			Gap:    d.Gap,
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Plays []duplicateJSON `json:"plays"`
+	}{plays})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
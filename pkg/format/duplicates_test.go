@@ -0,0 +1,52 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDuplicates(t *testing.T) {
+	f := &Duplicates{Plays: []Duplicate{
+		{Artist: "A", Title: "a", Time: 1514808000, Gap: 5},
+		{Artist: "BB", Title: "b", Time: 1514894410, Gap: 100},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"#\";\"Artist\";\"Title\";\"Time\";\"Gap\"\n" +
+				"1;\"A\";\"a\";2018-01-01 12:00:00;5\n" +
+				"2;\"BB\";\"b\";2018-01-02 12:00:10;100\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"1: A - a  - 2018-01-01 12:00:00, 5s after previous play\n" +
+				"2: BB - b - 2018-01-02 12:00:10, 100s after previous play\n",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"plays":[{"artist":"A","title":"a","time":1514808000,"gap":5},` +
+				`{"artist":"BB","title":"b","time":1514894410,"gap":100}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// Eras lists spans of time in which the same artists dominated the listening.
+type Eras struct {
+	Eras []Era
+}
+
+// Era is a line of Eras from Begin to End exclusively. Artists are the artists
+// that defined the era and Genres are the genres that were listened to, both
+// ordered by share.
+type Era struct {
+	Begin, End rsrc.Day
+	Artists    []Share
+	Genres     []Share
+}
+
+// Share is the share of an artist or genre in an era. It is between 0 and 1.
+type Share struct {
+	Name  string
+	Share float64
+}
+
+func joinShares(shares []Share) string {
+	strs := make([]string, len(shares))
+	for i, s := range shares {
+		strs[i] = fmt.Sprintf("%v (%.1f%%)", s.Name, 100*s.Share)
+	}
+	return strings.Join(strs, ", ")
+}
+
+func (f *Eras) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"Begin\";\"End\";\"Artists\";\"Genres\"\n")
+	for _, era := range f.Eras {
+		_, err := fmt.Fprintf(w, "%v;%v;\"%v\";\"%v\"\n",
+			era.Begin, era.End,
+			strings.Replace(joinShares(era.Artists), ".", decimal, -1),
+			strings.Replace(joinShares(era.Genres), ".", decimal, -1))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Eras) Plain(w io.Writer) error {
+	for _, era := range f.Eras {
+		_, err := fmt.Fprintf(w, "%v - %v\n  artists: %v\n  genres:  %v\n",
+			era.Begin, era.End, joinShares(era.Artists), joinShares(era.Genres))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Eras) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>Begin</td><td>End</td><td>Artists</td><td>Genres</td></tr>")
+	for _, era := range f.Eras {
+		_, err := fmt.Fprintf(w, "<tr><td>%v</td><td>%v</td><td>%v</td><td>%v</td></tr>",
+			era.Begin, era.End, joinShares(era.Artists), joinShares(era.Genres))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type shareJSON struct {
+	Name  string  `json:"name"`
+	Share float64 `json:"share"`
+}
+
+type eraJSON struct {
+	Begin   string      `json:"begin"`
+	End     string      `json:"end"`
+	Artists []shareJSON `json:"artists"`
+	Genres  []shareJSON `json:"genres"`
+}
+
+func sharesJSON(shares []Share) []shareJSON {
+	js := make([]shareJSON, len(shares))
+	for i, s := range shares {
+		js[i] = shareJSON{Name: s.Name, Share: s.Share}
+	}
+	return js
+}
+
+func (f *Eras) JSON(w io.Writer) error {
+	eras := make([]eraJSON, len(f.Eras))
+	for i, era := range f.Eras {
+		eras[i] = eraJSON{
+			Begin:   era.Begin.String(),
+			End:     era.End.String(),
+			Artists: sharesJSON(era.Artists),
+			Genres:  sharesJSON(era.Genres),
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Eras []eraJSON `json:"eras"`
+	}{eras})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
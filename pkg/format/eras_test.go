@@ -0,0 +1,78 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+func TestEras(t *testing.T) {
+	f := &Eras{Eras: []Era{
+		{
+			Begin:   rsrc.ParseDay("2018-01-01"),
+			End:     rsrc.ParseDay("2018-07-01"),
+			Artists: []Share{{"A", .5}, {"B", .125}},
+			Genres:  []Share{{"rock", .75}},
+		},
+		{
+			Begin:   rsrc.ParseDay("2018-07-01"),
+			End:     rsrc.ParseDay("2019-01-01"),
+			Artists: []Share{{"C", 1}},
+			Genres:  []Share{},
+		},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"Begin\";\"End\";\"Artists\";\"Genres\"\n" +
+				"2018-01-01;2018-07-01;\"A (50,0%), B (12,5%)\";\"rock (75,0%)\"\n" +
+				"2018-07-01;2019-01-01;\"C (100,0%)\";\"\"\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"2018-01-01 - 2018-07-01\n" +
+				"  artists: A (50.0%), B (12.5%)\n" +
+				"  genres:  rock (75.0%)\n" +
+				"2018-07-01 - 2019-01-01\n" +
+				"  artists: C (100.0%)\n" +
+				"  genres:  \n",
+		},
+		{
+			"html",
+			func(buf *bytes.Buffer) error { return f.HTML(buf) },
+			"<table><tr><td>Begin</td><td>End</td><td>Artists</td><td>Genres</td></tr>" +
+				"<tr><td>2018-01-01</td><td>2018-07-01</td><td>A (50.0%), B (12.5%)</td><td>rock (75.0%)</td></tr>" +
+				"<tr><td>2018-07-01</td><td>2019-01-01</td><td>C (100.0%)</td><td></td></tr></table>",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"eras":[{"begin":"2018-01-01","end":"2018-07-01",` +
+				`"artists":[{"name":"A","share":0.5},{"name":"B","share":0.125}],` +
+				`"genres":[{"name":"rock","share":0.75}]},` +
+				`{"begin":"2018-07-01","end":"2019-01-01",` +
+				`"artists":[{"name":"C","share":1}],"genres":[]}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
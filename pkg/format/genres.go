@@ -0,0 +1,136 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+// Genres is a tree of genres with the plays in them. The Root itself is not
+// printed, only its subgenres.
+type Genres struct {
+	Root      charts.GenreNode
+	Precision int
+}
+
+// genreLine is a genre of the tree in the order in which it is printed.
+type genreLine struct {
+	node  charts.GenreNode
+	path  string
+	depth int
+}
+
+func (f *Genres) lines() []genreLine {
+	lines := []genreLine{}
+	var walk func(nodes []charts.GenreNode, path string, depth int)
+	walk = func(nodes []charts.GenreNode, path string, depth int) {
+		for _, node := range nodes {
+			p := node.Genre
+			if path != "" {
+				p = path + "/" + node.Genre
+			}
+			lines = append(lines, genreLine{node, p, depth})
+			walk(node.Children, p, depth+1)
+		}
+	}
+	walk(f.Root.Children, f.Root.Genre, 0)
+	return lines
+}
+
+func (f *Genres) value(v float64) string {
+	return fmt.Sprintf("%."+fmt.Sprint(f.Precision)+"f", v)
+}
+
+func (f *Genres) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"Genre\";\"Path\";\"Plays\";\"Total\"\n")
+	for _, line := range f.lines() {
+		_, err := fmt.Fprintf(w, "\"%v\";\"%v\";%v;%v\n",
+			line.node.Genre, line.path,
+			strings.Replace(f.value(line.node.Plays), ".", decimal, 1),
+			strings.Replace(f.value(line.node.Total), ".", decimal, 1))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Genres) Plain(w io.Writer) error {
+	lines := f.lines()
+	nameLen, valueLen := 0, 0
+	for _, line := range lines {
+		if l := 2*line.depth + utf8.RuneCountInString(line.node.Genre); l > nameLen {
+			nameLen = l
+		}
+		if l := len(f.value(line.node.Total)); l > valueLen {
+			valueLen = l
+		}
+	}
+
+	for _, line := range lines {
+		name := strings.Repeat("  ", line.depth) + line.node.Genre
+		name += strings.Repeat(" ", nameLen-utf8.RuneCountInString(name))
+		_, err := fmt.Fprintf(w, "%v %"+fmt.Sprint(valueLen)+"v\n", name, f.value(line.node.Total))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Genres) HTML(w io.Writer) error {
+	var write func(nodes []charts.GenreNode) error
+	write = func(nodes []charts.GenreNode) error {
+		if len(nodes) == 0 {
+			return nil
+		}
+		fmt.Fprint(w, "<ul>")
+		defer fmt.Fprint(w, "</ul>")
+		for _, node := range nodes {
+			if _, err := fmt.Fprintf(w, "<li>%v: %v", node.Genre, f.value(node.Total)); err != nil {
+				return err
+			}
+			if err := write(node.Children); err != nil {
+				return err
+			}
+			fmt.Fprint(w, "</li>")
+		}
+		return nil
+	}
+	return write(f.Root.Children)
+}
+
+// genreJSON is a node of the genre tree as used by sunburst charts. Value
+// contains the plays of the genre itself, without its subgenres.
+type genreJSON struct {
+	Name     string      `json:"name"`
+	Value    float64     `json:"value"`
+	Total    float64     `json:"total"`
+	Children []genreJSON `json:"children"`
+}
+
+func toGenreJSON(node charts.GenreNode) genreJSON {
+	children := make([]genreJSON, len(node.Children))
+	for i, child := range node.Children {
+		children[i] = toGenreJSON(child)
+	}
+	return genreJSON{
+		Name:     node.Genre,
+		Value:    node.Plays,
+		Total:    node.Total,
+		Children: children,
+	}
+}
+
+func (f *Genres) JSON(w io.Writer) error {
+	data, err := json.Marshal(toGenreJSON(f.Root))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
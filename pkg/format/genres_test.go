@@ -0,0 +1,68 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestGenres(t *testing.T) {
+	f := &Genres{Root: charts.GenreNode{
+		Genre: "", Total: 15,
+		Children: []charts.GenreNode{
+			{Genre: "metal", Plays: 2, Total: 12, Children: []charts.GenreNode{
+				{Genre: "black metal", Plays: 10, Total: 10, Children: []charts.GenreNode{}},
+			}},
+			{Genre: "-", Plays: 3, Total: 3, Children: []charts.GenreNode{}},
+		},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"Genre\";\"Path\";\"Plays\";\"Total\"\n" +
+				"\"metal\";\"metal\";2;12\n" +
+				"\"black metal\";\"metal/black metal\";10;10\n" +
+				"\"-\";\"-\";3;3\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"metal         12\n" +
+				"  black metal 10\n" +
+				"-              3\n",
+		},
+		{
+			"html",
+			func(buf *bytes.Buffer) error { return f.HTML(buf) },
+			"<ul><li>metal: 12<ul><li>black metal: 10</li></ul></li><li>-: 3</li></ul>",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"name":"","value":0,"total":15,"children":[` +
+				`{"name":"metal","value":2,"total":12,"children":[` +
+				`{"name":"black metal","value":10,"total":10,"children":[]}]},` +
+				`{"name":"-","value":3,"total":3,"children":[]}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// Loved lists loved tracks with how often they were played before and after
+// they were loved.
+type Loved struct {
+	Tracks []LovedTrack
+}
+
+// LovedTrack is a line of Loved.
+type LovedTrack struct {
+	Artist, Title string
+	Loved         rsrc.Day
+	Before, After int
+}
+
+func (t LovedTrack) name() string {
+	return t.Artist + " - " + t.Title
+}
+
+func (f *Loved) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"#\";\"Artist\";\"Title\";\"Loved\";\"Before\";\"After\"\n")
+	for i, t := range f.Tracks {
+		_, err := fmt.Fprintf(w, "%d;\"%v\";\"%v\";%v;%d;%d\n",
+			i+1, t.Artist, t.Title, t.Loved, t.Before, t.After)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Loved) Plain(w io.Writer) error {
+	if len(f.Tracks) == 0 {
+		return nil
+	}
+
+	numPattern := "%" + strconv.Itoa(int(math.Log10(float64(len(f.Tracks))))+1) + "d: "
+	nameLen := 0
+	for _, t := range f.Tracks {
+		if l := utf8.RuneCountInString(t.name()); l > nameLen {
+			nameLen = l
+		}
+	}
+
+	for i, t := range f.Tracks {
+		name := t.name() + strings.Repeat(" ", nameLen-utf8.RuneCountInString(t.name()))
+		_, err := fmt.Fprintf(w, numPattern+"%v - loved %v, %d plays before, %d after\n",
+			i+1, name, t.Loved, t.Before, t.After)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Loved) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>#</td><td>Artist</td><td>Title</td><td>Loved</td><td>Before</td><td>After</td></tr>")
+	for i, t := range f.Tracks {
+		_, err := fmt.Fprintf(w,
+			"<tr><td>%d</td><td>%v</td><td>%v</td><td>%v</td><td>%d</td><td>%d</td></tr>",
+			i+1, t.Artist, t.Title, t.Loved, t.Before, t.After)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type lovedJSON struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Loved  string `json:"loved"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+func (f *Loved) JSON(w io.Writer) error {
+	tracks := make([]lovedJSON, len(f.Tracks))
+	for i, t := range f.Tracks {
+		tracks[i] = lovedJSON{
+			Artist: t.Artist,
+			Title:  t.Title,
+			Loved:  t.Loved.String(),
+			Before: t.Before,
+			After:  t.After,
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Tracks []lovedJSON `json:"tracks"`
+	}{tracks})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,54 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+func TestLoved(t *testing.T) {
+	f := &Loved{Tracks: []LovedTrack{
+		{Artist: "A", Title: "a", Loved: rsrc.ParseDay("2018-01-02"), Before: 2, After: 10},
+		{Artist: "BB", Title: "b", Loved: rsrc.ParseDay("2019-03-04"), Before: 0, After: 1},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"#\";\"Artist\";\"Title\";\"Loved\";\"Before\";\"After\"\n" +
+				"1;\"A\";\"a\";2018-01-02;2;10\n" +
+				"2;\"BB\";\"b\";2019-03-04;0;1\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"1: A - a  - loved 2018-01-02, 2 plays before, 10 after\n" +
+				"2: BB - b - loved 2019-03-04, 0 plays before, 1 after\n",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"tracks":[{"artist":"A","title":"a","loved":"2018-01-02","before":2,"after":10},` +
+				`{"artist":"BB","title":"b","loved":"2019-03-04","before":0,"after":1}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
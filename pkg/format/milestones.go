@@ -0,0 +1,110 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// Milestones lists milestones with the day they were reached. Projected
+// milestones are expected to be reached on their day at the current rate.
+type Milestones struct {
+	Milestones []Milestone
+}
+
+// Milestone is a line of Milestones. Kind is "scrobbles" for the user's total,
+// "plays" for plays of the artist Title and "hours" if Title was the first
+// artist to be listened to for Threshold hours.
+type Milestone struct {
+	Day       rsrc.Day
+	Kind      string
+	Title     string
+	Threshold float64
+	Projected bool
+}
+
+func (m Milestone) String() string {
+	switch m.Kind {
+	case "scrobbles":
+		return fmt.Sprintf("%v scrobbles", m.Threshold)
+	case "hours":
+		return fmt.Sprintf("%v: first artist with %v hours", m.Title, m.Threshold)
+	default:
+		return fmt.Sprintf("%v: %v %v", m.Title, m.Threshold, m.Kind)
+	}
+}
+
+func (f *Milestones) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"Day\";\"Kind\";\"Title\";\"Threshold\";\"Projected\"\n")
+	for _, m := range f.Milestones {
+		_, err := fmt.Fprintf(w, "%v;\"%v\";\"%v\";%v;%v\n",
+			m.Day, m.Kind, m.Title, m.Threshold, m.Projected)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Milestones) Plain(w io.Writer) error {
+	projected := false
+	for _, m := range f.Milestones {
+		if m.Projected && !projected {
+			projected = true
+			fmt.Fprint(w, "projected:\n")
+		}
+		if _, err := fmt.Fprintf(w, "%v %v\n", m.Day, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Milestones) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>Day</td><td>Milestone</td></tr>")
+	for _, m := range f.Milestones {
+		day := fmt.Sprint(m.Day)
+		if m.Projected {
+			day = "<i>" + day + "</i>"
+		}
+		if _, err := fmt.Fprintf(w, "<tr><td>%v</td><td>%v</td></tr>", day, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type milestoneJSON struct {
+	Day       string  `json:"day"`
+	Kind      string  `json:"kind"`
+	Title     string  `json:"title,omitempty"`
+	Threshold float64 `json:"threshold"`
+	Projected bool    `json:"projected"`
+}
+
+func (f *Milestones) JSON(w io.Writer) error {
+	milestones := make([]milestoneJSON, len(f.Milestones))
+	for i, m := range f.Milestones {
+		milestones[i] = milestoneJSON{
+			Day:       m.Day.String(),
+			Kind:      m.Kind,
+			Title:     m.Title,
+			Threshold: m.Threshold,
+			Projected: m.Projected,
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Milestones []milestoneJSON `json:"milestones"`
+	}{milestones})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,67 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+func TestMilestones(t *testing.T) {
+	f := &Milestones{Milestones: []Milestone{
+		{Day: rsrc.ParseDay("2018-01-02"), Kind: "scrobbles", Threshold: 10000},
+		{Day: rsrc.ParseDay("2018-03-04"), Kind: "plays", Title: "A", Threshold: 100},
+		{Day: rsrc.ParseDay("2019-05-06"), Kind: "hours", Title: "B", Threshold: 10, Projected: true},
+	}}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"Day\";\"Kind\";\"Title\";\"Threshold\";\"Projected\"\n" +
+				"2018-01-02;\"scrobbles\";\"\";10000;false\n" +
+				"2018-03-04;\"plays\";\"A\";100;false\n" +
+				"2019-05-06;\"hours\";\"B\";10;true\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"2018-01-02 10000 scrobbles\n" +
+				"2018-03-04 A: 100 plays\n" +
+				"projected:\n" +
+				"2019-05-06 B: first artist with 10 hours\n",
+		},
+		{
+			"html",
+			func(buf *bytes.Buffer) error { return f.HTML(buf) },
+			"<table><tr><td>Day</td><td>Milestone</td></tr>" +
+				"<tr><td>2018-01-02</td><td>10000 scrobbles</td></tr>" +
+				"<tr><td>2018-03-04</td><td>A: 100 plays</td></tr>" +
+				"<tr><td><i>2019-05-06</i></td><td>B: first artist with 10 hours</td></tr></table>",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"milestones":[{"day":"2018-01-02","kind":"scrobbles","threshold":10000,"projected":false},` +
+				`{"day":"2018-03-04","kind":"plays","title":"A","threshold":100,"projected":false},` +
+				`{"day":"2019-05-06","kind":"hours","title":"B","threshold":10,"projected":true}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Seasons lists titles with their seasonal listening patterns. Buckets are the
+// names of the months or weekdays that the profiles are folded into.
+type Seasons struct {
+	Buckets []string
+	Seasons []Season
+}
+
+// Season is a line of Seasons. Profile holds the share of each bucket and
+// Score is between 0 for no seasonality and 1 for a single bucket.
+type Season struct {
+	Name    string
+	Score   float64
+	Profile []float64
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+func (s Season) peak() int {
+	peak := 0
+	for i, v := range s.Profile {
+		if v > s.Profile[peak] {
+			peak = i
+		}
+	}
+	return peak
+}
+
+// spark draws the profile as a sparkline that is scaled to the peak.
+func (s Season) spark() string {
+	if len(s.Profile) == 0 {
+		return ""
+	}
+
+	max := s.Profile[s.peak()]
+	runes := make([]rune, len(s.Profile))
+	for i, v := range s.Profile {
+		idx := 0
+		if max > 0 {
+			idx = int(math.Round(v / max * float64(len(sparks)-1)))
+		}
+		runes[i] = sparks[idx]
+	}
+	return string(runes)
+}
+
+func (f *Seasons) CSV(w io.Writer, decimal string) error {
+	fmt.Fprint(w, "\"Name\";\"Score\"")
+	for _, bucket := range f.Buckets {
+		fmt.Fprintf(w, ";\"%v\"", bucket)
+	}
+	fmt.Fprint(w, "\n")
+
+	for _, s := range f.Seasons {
+		values := []string{strings.Replace(fmt.Sprint(s.Score), ".", decimal, -1)}
+		for _, v := range s.Profile {
+			values = append(values, strings.Replace(fmt.Sprint(v), ".", decimal, -1))
+		}
+		if _, err := fmt.Fprintf(w, "\"%v\";%v\n", s.Name, strings.Join(values, ";")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Seasons) Plain(w io.Writer) error {
+	if len(f.Seasons) == 0 {
+		return nil
+	}
+
+	numPattern := "%" + strconv.Itoa(int(math.Log10(float64(len(f.Seasons))))+1) + "d: "
+	nameLen := 0
+	for _, s := range f.Seasons {
+		if l := utf8.RuneCountInString(s.Name); l > nameLen {
+			nameLen = l
+		}
+	}
+
+	for i, s := range f.Seasons {
+		name := s.Name + strings.Repeat(" ", nameLen-utf8.RuneCountInString(s.Name))
+		peak := ""
+		if p := s.peak(); p < len(f.Buckets) {
+			peak = f.Buckets[p]
+		}
+		_, err := fmt.Fprintf(w, numPattern+"%v - %.2f, peak: %v %v\n",
+			i+1, name, s.Score, peak, s.spark())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Seasons) HTML(w io.Writer) error {
+	fmt.Fprint(w, "<table>")
+	defer fmt.Fprint(w, "</table>")
+
+	fmt.Fprint(w, "<tr><td>#</td><td>Name</td><td>Score</td>")
+	for _, bucket := range f.Buckets {
+		fmt.Fprintf(w, "<td>%v</td>", bucket)
+	}
+	fmt.Fprint(w, "</tr>")
+
+	for i, s := range f.Seasons {
+		fmt.Fprintf(w, "<tr><td>%d</td><td>%v</td><td>%.2f</td>", i+1, s.Name, s.Score)
+		for _, v := range s.Profile {
+			fmt.Fprintf(w, "<td>%.1f%%</td>", 100*v)
+		}
+		if _, err := fmt.Fprint(w, "</tr>"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type seasonJSON struct {
+	Name    string    `json:"name"`
+	Score   float64   `json:"score"`
+	Profile []float64 `json:"profile"`
+}
+
+func (f *Seasons) JSON(w io.Writer) error {
+	buckets := f.Buckets
+	if buckets == nil {
+		buckets = []string{}
+	}
+	seasons := make([]seasonJSON, len(f.Seasons))
+	for i, s := range f.Seasons {
+		seasons[i] = seasonJSON{Name: s.Name, Score: s.Score, Profile: s.Profile}
+	}
+
+	data, err := json.Marshal(struct {
+		Buckets []string     `json:"buckets"`
+		Seasons []seasonJSON `json:"seasons"`
+	}{buckets, seasons})
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
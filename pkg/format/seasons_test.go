@@ -0,0 +1,62 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSeasons(t *testing.T) {
+	f := &Seasons{
+		Buckets: []string{"Mon", "Tue", "Wed"},
+		Seasons: []Season{
+			{Name: "Ä", Score: .75, Profile: []float64{0, .25, .75}},
+			{Name: "BB", Score: 0, Profile: []float64{0, 0, 0}},
+		},
+	}
+
+	cases := []struct {
+		name   string
+		format func(buf *bytes.Buffer) error
+		str    string
+	}{
+		{
+			"csv",
+			func(buf *bytes.Buffer) error { return f.CSV(buf, ",") },
+			"\"Name\";\"Score\";\"Mon\";\"Tue\";\"Wed\"\n" +
+				"\"Ä\";0,75;0;0,25;0,75\n" +
+				"\"BB\";0;0;0;0\n",
+		},
+		{
+			"plain",
+			func(buf *bytes.Buffer) error { return f.Plain(buf) },
+			"1: Ä  - 0.75, peak: Wed ▁▃█\n" +
+				"2: BB - 0.00, peak: Mon ▁▁▁\n",
+		},
+		{
+			"html",
+			func(buf *bytes.Buffer) error { return f.HTML(buf) },
+			"<table><tr><td>#</td><td>Name</td><td>Score</td><td>Mon</td><td>Tue</td><td>Wed</td></tr>" +
+				"<tr><td>1</td><td>Ä</td><td>0.75</td><td>0.0%</td><td>25.0%</td><td>75.0%</td></tr>" +
+				"<tr><td>2</td><td>BB</td><td>0.00</td><td>0.0%</td><td>0.0%</td><td>0.0%</td></tr></table>",
+		},
+		{
+			"json",
+			func(buf *bytes.Buffer) error { return f.JSON(buf) },
+			`{"buckets":["Mon","Tue","Wed"],"seasons":[{"name":"Ä","score":0.75,"profile":[0,0.25,0.75]},` +
+				`{"name":"BB","score":0,"profile":[0,0,0]}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			if err := c.format(buf); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			if str := buf.String(); str != c.str {
+				t.Errorf("false formatting:\nhas:\n%v\nwant:\n%v", str, c.str)
+			}
+		})
+	}
+}
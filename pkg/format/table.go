@@ -1,6 +1,7 @@
 package format
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"strings"
@@ -9,8 +10,11 @@ import (
 )
 
 type Table struct {
-	Charts charts.Charts
-	Ranges charts.Ranges
+	// Context cancels the computation of the charts. If it is nil,
+	// context.Background() is used.
+	Context context.Context
+	Charts  charts.Charts
+	Ranges  charts.Ranges
 }
 
 func (f *Table) CSV(w io.Writer, decimal string) error {
@@ -63,7 +67,7 @@ func (f *Table) formatBody(
 	}
 
 	titles := f.Charts.Titles()
-	data, err := f.Charts.Data(titles, 0, f.Charts.Len())
+	data, err := f.Charts.Data(background(f.Context), titles, 0, f.Charts.Len())
 	if err != nil {
 		return err
 	}
@@ -7,7 +7,7 @@ import (
 
 func (f *Table) JSON(w io.Writer) error {
 	titles := f.Charts.Titles()
-	values, err := f.Charts.Data(titles, 0, f.Charts.Len())
+	values, err := f.Charts.Data(background(f.Context), titles, 0, f.Charts.Len())
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,16 @@
 package info
 
-// Song contains basic information about a song.
+// Song contains basic information about a song. The MBIDs are the
+// MusicBrainz IDs of the artist, the track and the album, they are empty if
+// they are unknown. Time is the Unix time at which the song was played, it is
+// 0 if it is unknown.
 type Song struct {
 	Artist, Title, Album string
 	Duration             float64
+
+	ArtistMBID, TrackMBID, AlbumMBID string
+
+	Time int64
 }
 
 // Tag contains information about a tag.
@@ -0,0 +1,46 @@
+package io
+
+import (
+	"context"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+type bound struct {
+	Store
+	ctx context.Context
+}
+
+// WithContext returns a handle to an existing store whose operations are bound
+// to ctx. Read(), Update() and Fetch() are cancelled like ReadContext(),
+// UpdateContext() and FetchContext(), Write() and Remove() fail without effect once ctx is done.
+// This allows code that only knows rsrc.Reader to be cancelled.
+func WithContext(ctx context.Context, s Store) Store {
+	return &bound{Store: s, ctx: ctx}
+}
+
+func (s *bound) Read(loc rsrc.Locator) ([]byte, error) {
+	return s.Store.ReadContext(s.ctx, loc)
+}
+
+func (s *bound) Update(loc rsrc.Locator) ([]byte, error) {
+	return s.Store.UpdateContext(s.ctx, loc)
+}
+
+func (s *bound) Fetch(loc rsrc.Locator) ([]byte, error) {
+	return s.Store.FetchContext(s.ctx, loc)
+}
+
+func (s *bound) Write(data []byte, loc rsrc.Locator) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	return s.Store.Write(data, loc)
+}
+
+func (s *bound) Remove(loc rsrc.Locator) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	return s.Store.Remove(loc)
+}
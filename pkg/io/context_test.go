@@ -0,0 +1,80 @@
+package io
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+// blockingIO is a reader that only returns once the context of the read is
+// done.
+type blockingIO struct {
+	FailIO
+}
+
+func (blockingIO) ReadContext(ctx context.Context, loc rsrc.Locator) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestStoreUpdateContext(t *testing.T) {
+	loc := rsrc.ArtistTags("abc")
+	closer, _ := mock.IO(map[rsrc.Locator][]byte{loc: []byte("old")}, mock.Path)
+
+	s, err := NewStore([][]rsrc.IO{{blockingIO{}}, {closer}})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.UpdateContext(ctx, loc); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline to be exceeded but got '%v'", err)
+	}
+
+	// the closer layer must not be consulted once the context is done
+	if _, err := s.ReadContext(ctx, loc); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline to be exceeded but got '%v'", err)
+	}
+
+	if data, err := s.ReadContext(context.Background(), loc); err != nil {
+		t.Error("unexpected error:", err)
+	} else if string(data) != "old" {
+		t.Errorf("read '%v' but expected 'old'", string(data))
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	loc := rsrc.ArtistTags("abc")
+	io, _ := mock.IO(map[rsrc.Locator][]byte{loc: []byte("old")}, mock.Path)
+	s, err := NewStore([][]rsrc.IO{{io}})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bound := WithContext(ctx, s)
+
+	if data, err := bound.Read(loc); err != nil {
+		t.Error("unexpected error:", err)
+	} else if string(data) != "old" {
+		t.Errorf("read '%v' but expected 'old'", string(data))
+	}
+
+	cancel()
+
+	if _, err := bound.Read(loc); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected read to be cancelled but got '%v'", err)
+	}
+	if err := bound.Write([]byte("new"), loc); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected write to be cancelled but got '%v'", err)
+	}
+	if data, _ := s.Read(loc); string(data) != "old" {
+		t.Errorf("cancelled write changed data to '%v'", string(data))
+	}
+}
@@ -5,12 +5,21 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
 
 // TODO Hide all types in io.
 
+// tempSuffix is part of the names of temporary files that are created during
+// writes. Files containing it are leftovers of interrupted writes.
+const tempSuffix = ".tmp"
+
+// quarantineDir is the directory below the data root in which damaged files
+// are kept.
+const quarantineDir = "quarantine"
+
 // FileReader is a Reader to read from the local file system.
 type FileReader struct{}
 
@@ -36,6 +45,20 @@ func (FileReader) Read(loc rsrc.Locator) ([]byte, error) {
 	return ioutil.ReadFile(path)
 }
 
+// Modified returns the time a file was last written.
+func (FileReader) Modified(loc rsrc.Locator) (time.Time, error) {
+	path, err := loc.Path()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
 func (FileWriter) Write(data []byte, loc rsrc.Locator) error {
 	path, err := loc.Path()
 	if err != nil {
@@ -50,15 +73,36 @@ func (FileWriter) Write(data []byte, loc rsrc.Locator) error {
 		}
 	}
 
-	f, err := os.Create(path)
+	// The data is written to a temporary file in the same directory which then
+	// replaces the target. A crash during the write leaves the previous version
+	// intact instead of a truncated file.
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+tempSuffix)
 	if err != nil {
 		// Will be *PathError
 		return err
 	}
 
-	_, err = f.Write(data)
-	f.Close()
-	return err
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err = os.Chmod(f.Name(), 0644); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
 }
 
 func (FileRemover) Remove(loc rsrc.Locator) error {
@@ -74,3 +118,43 @@ func (FileRemover) Remove(loc rsrc.Locator) error {
 	os.Remove(path)
 	return nil
 }
+
+// ListFiles lists all files below the data root. Quarantined files are not
+// included. The paths have the same form as the ones returned by
+// rsrc.Locator.Path().
+func ListFiles(root string) ([]string, error) {
+	quarantine := filepath.Join(root, quarantineDir)
+
+	paths := []string{}
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == quarantine {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		paths = append(paths, filepath.ToSlash(path))
+		return nil
+	})
+
+	return paths, err
+}
+
+// Quarantine moves a file below the data root into the quarantine directory.
+// Its path relative to the root is kept.
+func Quarantine(root, path string) error {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return err
+	}
+
+	target := filepath.Join(root, quarantineDir, rel)
+	if err = os.MkdirAll(filepath.Dir(target), 0040755); err != nil {
+		return err
+	}
+
+	return os.Rename(path, target)
+}
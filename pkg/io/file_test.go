@@ -3,6 +3,8 @@ package io
 import (
 	"errors"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nilsbu/lastfm/pkg/rsrc"
@@ -156,3 +158,59 @@ func TestFileIORemove(t *testing.T) {
 		})
 	}
 }
+
+func TestFileIOWriteLeavesNoTempFiles(t *testing.T) {
+	io := FileIO{}
+	loc := stubPath(path)
+
+	for _, text := range []string{"first", "second"} {
+		if err := io.Write([]byte(text), loc); err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	}
+
+	data, err := io.Read(loc)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("wrong data read, has '%v', expected 'second'", string(data))
+	}
+
+	paths, err := ListFiles(filepath.Dir(path))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	for _, p := range paths {
+		if strings.Contains(p, tempSuffix) {
+			t.Errorf("temporary file '%v' was not removed", p)
+		}
+	}
+	io.Remove(loc)
+}
+
+func TestQuarantine(t *testing.T) {
+	root := filepath.Join(filepath.Dir(path), "root")
+	defer os.RemoveAll(root)
+
+	broken := filepath.Join(root, "user", "x", "bookmark.json")
+	if err := (FileIO{}).Write([]byte("{"), stubPath(broken)); err != nil {
+		t.Fatal("unexpected error during setup:", err)
+	}
+
+	if err := Quarantine(root, broken); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	paths, err := ListFiles(root)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("quarantined files must not be listed, got %v", paths)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, quarantineDir, "user", "x", "bookmark.json")); err != nil {
+		t.Error("file was not moved into quarantine:", err)
+	}
+}
@@ -1,6 +1,10 @@
 package io
 
-import "github.com/nilsbu/lastfm/pkg/rsrc"
+import (
+	"context"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
 
 type fresh struct {
 	Cache Store
@@ -19,6 +23,10 @@ func (s *fresh) Read(loc rsrc.Locator) ([]byte, error) {
 	return s.Cache.Update(loc)
 }
 
+func (s *fresh) ReadContext(ctx context.Context, loc rsrc.Locator) ([]byte, error) {
+	return s.Cache.UpdateContext(ctx, loc)
+}
+
 func (s *fresh) Write(data []byte, loc rsrc.Locator) error {
 	return s.Cache.Write(data, loc)
 }
@@ -26,3 +34,22 @@ func (s *fresh) Write(data []byte, loc rsrc.Locator) error {
 func (s *fresh) Remove(loc rsrc.Locator) error {
 	return s.Cache.Remove(loc)
 }
+
+type fetched struct {
+	Cache Store
+}
+
+// FetchedStore returns a reader of an existing store whose Read() executes
+// Fetch(), i.e. resources are retrieved from the most distant layer without
+// being written to the closer layers.
+func FetchedStore(cache Store) rsrc.Reader {
+	return &fetched{Cache: cache}
+}
+
+func (s *fetched) Read(loc rsrc.Locator) ([]byte, error) {
+	return s.Cache.Fetch(loc)
+}
+
+func (s *fetched) ReadContext(ctx context.Context, loc rsrc.Locator) ([]byte, error) {
+	return s.Cache.FetchContext(ctx, loc)
+}
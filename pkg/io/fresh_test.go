@@ -74,3 +74,39 @@ func TestFresh(t *testing.T) {
 		t.Errorf("l1: wrong data: has '%v', want '%v'", string(data), "written")
 	}
 }
+
+func TestFetched(t *testing.T) {
+	l0, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.APIKey(): []byte("new"),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+
+	l1, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.APIKey(): []byte("old"),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+
+	store, err := NewStore([][]rsrc.IO{{l0}, {l1}})
+	if err != nil {
+		t.Fatal("unexpected ctor error:", err)
+	}
+
+	data, err := FetchedStore(store).Read(rsrc.APIKey())
+	if err != nil {
+		t.Error("unexpected read error:", err)
+	} else if string(data) != "new" {
+		t.Errorf("read: wrong data: has '%v', want '%v'", string(data), "new")
+	}
+
+	// ensure l1 is left untouched
+	data, err = l1.Read(rsrc.APIKey())
+	if err != nil {
+		t.Fatal("unexpected read error:", err)
+	} else if string(data) != "old" {
+		t.Errorf("l1: wrong data: has '%v', want '%v'", string(data), "old")
+	}
+}
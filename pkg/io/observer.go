@@ -4,9 +4,19 @@ import (
 	"fmt"
 
 	"github.com/nilsbu/lastfm/pkg/format"
+	"github.com/nilsbu/lastfm/pkg/metrics"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
 
+var (
+	requestedOps = metrics.Default.Counter("lastfm_io_requested_total",
+		"Number of IO operations requested per store layer.", "layer", "op")
+	completedOps = metrics.Default.Counter("lastfm_io_completed_total",
+		"Number of IO operations completed per store layer.", "layer", "op")
+)
+
+var opNames = map[rune]string{'r': "read", 'w': "write", 'd': "remove"}
+
 type observer interface {
 	RequestRead(r rsrc.Locator)
 	NotifyRead(r rsrc.Locator)
@@ -17,6 +27,7 @@ type observer interface {
 }
 
 type counter struct {
+	layer string
 	fChan chan<- format.Formatter
 	eChan chan obEvent
 	back  chan bool
@@ -28,8 +39,11 @@ type obEvent struct {
 	receive bool
 }
 
-func newObserver(fChan chan<- format.Formatter) observer {
+// newObserver creates an observer that sends its progress messages to fChan.
+// The counts are also exported as metrics with the given layer label.
+func newObserver(fChan chan<- format.Formatter, layer string) observer {
 	o := &counter{
+		layer: layer,
 		fChan: fChan,
 		count: make(map[rune][2]int),
 		eChan: make(chan obEvent),
@@ -40,8 +54,10 @@ func newObserver(fChan chan<- format.Formatter) observer {
 		for e := range o.eChan {
 			if e.receive {
 				o.count[e.kind] = [2]int{o.count[e.kind][0] + 1, o.count[e.kind][1]}
+				completedOps.Inc(o.layer, opNames[e.kind])
 			} else {
 				o.count[e.kind] = [2]int{o.count[e.kind][0], o.count[e.kind][1] + 1}
+				requestedOps.Inc(o.layer, opNames[e.kind])
 			}
 			o.sendFormat()
 			o.back <- true
@@ -66,7 +66,7 @@ func TestObserver(t *testing.T) {
 				quit <- true
 			}(d)
 
-			o := newObserver(fChan)
+			o := newObserver(fChan, "0")
 			for _, e := range c.events {
 				switch e.t {
 				case 'r':
@@ -97,3 +97,20 @@ func TestObserver(t *testing.T) {
 		})
 	}
 }
+
+func TestObserverMetrics(t *testing.T) {
+	before := requestedOps.Value("x", "write")
+	beforeDone := completedOps.Value("x", "write")
+
+	o := newObserver(DumpChan(), "x")
+	o.RequestWrite(rsrc.SessionInfo())
+	o.RequestWrite(rsrc.SessionInfo())
+	o.NotifyWrite(rsrc.SessionInfo())
+
+	if n := requestedOps.Value("x", "write") - before; n != 2 {
+		t.Errorf("%v requested writes counted, expected 2", n)
+	}
+	if n := completedOps.Value("x", "write") - beforeDone; n != 1 {
+		t.Errorf("%v completed writes counted, expected 1", n)
+	}
+}
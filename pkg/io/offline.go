@@ -0,0 +1,63 @@
+package io
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// OfflineIO takes the place of WebIO when the network must not be used. Reads
+// of resources that only exist locally fail like on WebIO, all other reads fail
+// with rsrc.ErrOffline and their locators are recorded. Writes and removes fail like on FailIO.
+type OfflineIO struct {
+	*offlineReader
+	FailWriter
+	FailRemover
+}
+
+type offlineReader struct {
+	mtx     sync.Mutex
+	missing map[string]rsrc.Locator
+	order   []string
+}
+
+// NewOfflineIO creates an OfflineIO. Copies of it share the record of missing
+// resources.
+func NewOfflineIO() OfflineIO {
+	return OfflineIO{
+		offlineReader: &offlineReader{missing: map[string]rsrc.Locator{}},
+	}
+}
+
+func (r *offlineReader) Read(loc rsrc.Locator) ([]byte, error) {
+	path, err := loc.Path()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := loc.URL(""); errors.Is(err, rsrc.ErrNoURL) {
+		return nil, err
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if _, ok := r.missing[path]; !ok {
+		r.missing[path] = loc
+		r.order = append(r.order, path)
+	}
+
+	return nil, rsrc.ErrOffline
+}
+
+// Missing returns the locators that were read, in the order of their first
+// request. Each locator is included once.
+func (r *offlineReader) Missing() []rsrc.Locator {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	locs := make([]rsrc.Locator, len(r.order))
+	for i, path := range r.order {
+		locs[i] = r.missing[path]
+	}
+	return locs
+}
@@ -0,0 +1,71 @@
+package io
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+func TestOfflineIO(t *testing.T) {
+	io := NewOfflineIO()
+	cpy := io
+
+	for _, loc := range []rsrc.Locator{
+		stubPath("a"), stubPath("b"), stubPath("a"),
+	} {
+		if _, err := cpy.Read(loc); !errors.Is(err, rsrc.ErrOffline) {
+			t.Errorf("expected ErrOffline, got '%v'", err)
+		}
+	}
+
+	if _, err := io.Read(rsrc.File("a")); !errors.Is(err, rsrc.ErrNoURL) {
+		t.Errorf("expected ErrNoURL for local resource, got '%v'", err)
+	}
+	if _, err := io.Read(stubPath("")); err == nil || errors.Is(err, rsrc.ErrOffline) {
+		t.Errorf("expected path error, got '%v'", err)
+	}
+	if err := io.Write([]byte{}, stubPath("a")); err == nil {
+		t.Error("expected error for write but none occurred")
+	}
+	if err := io.Remove(stubPath("a")); err == nil {
+		t.Error("expected error for remove but none occurred")
+	}
+
+	expected := []rsrc.Locator{stubPath("a"), stubPath("b")}
+	if missing := io.Missing(); !reflect.DeepEqual(missing, expected) {
+		t.Errorf("wrong missing resources: has %v, expected %v", missing, expected)
+	}
+}
+
+func TestStoreOffline(t *testing.T) {
+	off := NewOfflineIO()
+	s, err := NewStore([][]rsrc.IO{{off}, {FailIO{}}})
+	if err != nil {
+		t.Fatal("unexpected error during setup:", err)
+	}
+
+	_, err = s.Read(stubPath("a"))
+	if !errors.Is(err, rsrc.ErrOffline) {
+		t.Errorf("expected error wrapping ErrOffline, got '%v'", err)
+	}
+}
+
+func TestStoreOfflineLocal(t *testing.T) {
+	off := NewOfflineIO()
+	s, err := NewStore([][]rsrc.IO{{off}, {FileIO{}}})
+	if err != nil {
+		t.Fatal("unexpected error during setup:", err)
+	}
+
+	_, err = s.Read(rsrc.File(filepath.Join(t.TempDir(), "missing.json")))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected error wrapping ErrNotExist, got '%v'", err)
+	}
+	if missing := off.Missing(); len(missing) > 0 {
+		t.Errorf("local resource must not be recorded, has %v", missing)
+	}
+}
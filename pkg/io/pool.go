@@ -1,13 +1,15 @@
 package io
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
 
 type readPool interface {
-	read(loc rsrc.Locator) <-chan readResult
+	read(ctx context.Context, loc rsrc.Locator) <-chan readResult
 }
 
 type writePool interface {
@@ -52,9 +54,15 @@ func newPool(
 			for {
 				select {
 				case j := <-r:
-					data, err := io.Read(j.loc)
+					res := readResult{err: j.ctx.Err()}
+					if res.err == nil {
+						res.data, res.err = rsrc.ReadContext(j.ctx, io, j.loc)
+						if d, ok := io.(dated); ok && res.err == nil {
+							res.modified, _ = d.Modified(j.loc)
+						}
+					}
 					o.NotifyRead(j.loc)
-					j.back <- readResult{data: data, err: err}
+					j.back <- res
 				case j := <-w:
 					err := io.Write(j.data, j.loc)
 					o.NotifyWrite(j.loc)
@@ -74,20 +82,36 @@ func newPool(
 type readWorker chan readJob
 
 type readJob struct {
+	ctx  context.Context
 	loc  rsrc.Locator
 	back chan<- readResult
 }
 
-// readResult is contains the return values of Reader.Read().
+// readResult is contains the return values of Reader.Read(). If the reader
+// knows when the resource was written, it is contained in modified, otherwise
+// modified is zero.
 type readResult struct {
-	data []byte
-	err  error
+	data     []byte
+	modified time.Time
+	err      error
+}
+
+// dated is a reader that knows when a resource was last written.
+type dated interface {
+	Modified(loc rsrc.Locator) (time.Time, error)
 }
 
-func (wp workerPool) read(loc rsrc.Locator) <-chan readResult {
+// read queues a read job. Jobs whose context is done by the time a worker
+// would pick them up are answered with the context's error without reading.
+func (wp workerPool) read(ctx context.Context, loc rsrc.Locator) <-chan readResult {
 	wp.o.RequestRead(loc)
-	resultChan := make(chan readResult)
-	wp.r <- readJob{loc: loc, back: resultChan}
+	resultChan := make(chan readResult, 1)
+	select {
+	case wp.r <- readJob{ctx: ctx, loc: loc, back: resultChan}:
+	case <-ctx.Done():
+		wp.o.NotifyRead(loc)
+		resultChan <- readResult{err: ctx.Err()}
+	}
 	return resultChan
 }
 
@@ -1,6 +1,7 @@
 package io
 
 import (
+	"context"
 	"testing"
 
 	"github.com/nilsbu/lastfm/pkg/rsrc"
@@ -78,7 +79,7 @@ func TestPool(t *testing.T) {
 				ios = append(ios, io)
 			}
 
-			p, err := newPool(ios, newObserver(dumpChan()))
+			p, err := newPool(ios, newObserver(DumpChan(), "0"))
 			if err != nil {
 				if c.ctorOK {
 					t.Error("unexpected error in constructor:", err)
@@ -105,7 +106,7 @@ func TestPool(t *testing.T) {
 				return
 			}
 
-			readResult := <-p.read(c.loc)
+			readResult := <-p.read(context.Background(), c.loc)
 			data, err := readResult.data, readResult.err
 			if err != nil && c.readOK {
 				t.Error("unexpected error during read:", err)
@@ -1,8 +1,11 @@
 package io
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/nilsbu/lastfm/pkg/format"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
@@ -21,22 +24,56 @@ import (
 // allow for faster retrieval in subsequent requests. To ensure that the most
 // recent version of a resource is loaded, use Update() or see Fresh().
 //
+// Resources can expire according to a Freshness policy. If the copy of a
+// resource that Read() finds in a closer layer is older than its kind permits,
+// the more distant layers are consulted. If none of them has the resource, the
+// stale copy is returned.
+//
 // Update() searches for a resource starting with the most distant layer. Once
 // it finds the resource it overwrites potentially outdated versions in all
 // closer layers.
 //
+// Fetch() searches for a resource like Update() but leaves the closer layers
+// untouched.
+//
 // Write() writes the resource to all layers. The error is always nil.
 //
 // Remove() removes the resource from all layers. The error is always nil.
 //
+// ReadContext(), UpdateContext() and FetchContext() behave like Read(),
+// Update() and Fetch() but stop once ctx is done. Reads that haven't started yet are skipped and readers that
+// implement rsrc.ContextReader are interrupted. The error is then ctx.Err().
+//
 // TODO What role does fail.Threat play? Thread-safety?
 type Store interface {
 	rsrc.IO
+	ReadContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error)
 	Update(loc rsrc.Locator) (data []byte, err error)
+	UpdateContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error)
+	Fetch(loc rsrc.Locator) (data []byte, err error)
+	FetchContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error)
+}
+
+// Freshness assigns kinds of resources, as returned by rsrc.Kind(), the age
+// after which stored copies become stale. Kinds that aren't included never
+// become stale.
+type Freshness map[string]time.Duration
+
+// DefaultFreshness is the Freshness used for stores that access Last.fm. Tags
+// and artist information change slowly while user information is refreshed
+// daily. Histories of past days, track durations and tracklists never change.
+var DefaultFreshness = Freshness{
+	"user.getInfo":        24 * time.Hour,
+	"user.getLovedTracks": 24 * time.Hour,
+	"artist.getInfo":      180 * 24 * time.Hour,
+	"artist.getTopTags":   180 * 24 * time.Hour,
+	"artist.getSimilar":   180 * 24 * time.Hour,
+	"tag.getInfo":         180 * 24 * time.Hour,
 }
 
 type cache struct {
-	layers []pool
+	layers    []pool
+	freshness Freshness
 }
 
 // New creates a store. The layers are described by ios. They are ordered from
@@ -46,6 +83,7 @@ type cache struct {
 func new(
 	ios [][]rsrc.IO,
 	obChans []chan<- format.Formatter,
+	freshness Freshness,
 ) (Store, error) {
 	if len(ios) == 0 {
 		return nil, errors.New("store must have at least one layer")
@@ -57,7 +95,7 @@ func new(
 
 	pools := make([]pool, len(ios))
 	for i := range ios {
-		ob := newObserver(obChans[i])
+		ob := newObserver(obChans[i], strconv.Itoa(i))
 		pool, err := newPool(ios[i], ob)
 		if err != nil {
 			return nil, err
@@ -65,10 +103,11 @@ func new(
 		pools[i] = pool
 	}
 
-	return &cache{pools}, nil
+	return &cache{layers: pools, freshness: freshness}, nil
 }
 
-func dumpChan() chan<- format.Formatter {
+// DumpChan returns an observer channel whose messages are discarded.
+func DumpChan() chan<- format.Formatter {
 	obChan := make(chan format.Formatter)
 	go func() {
 		for range obChan {
@@ -81,7 +120,7 @@ func dumpChan() chan<- format.Formatter {
 func dumpChans(n int) []chan<- format.Formatter {
 	chans := make([]chan<- format.Formatter, n)
 	for i := 0; i < n; i++ {
-		chans[i] = dumpChan()
+		chans[i] = DumpChan()
 	}
 	return chans
 }
@@ -93,7 +132,7 @@ func dumpChans(n int) []chan<- format.Formatter {
 func NewStore(
 	ios [][]rsrc.IO,
 ) (Store, error) {
-	return new(ios, dumpChans(len(ios)))
+	return new(ios, dumpChans(len(ios)), Freshness{})
 }
 
 // NewObservedStore creates a store. The layers are described by ios. They are
@@ -106,15 +145,42 @@ func NewObservedStore(
 	ios [][]rsrc.IO,
 	obChans []chan<- format.Formatter,
 ) (Store, error) {
-	return new(ios, obChans)
+	return new(ios, obChans, Freshness{})
+}
+
+// NewExpiringStore creates a store like NewObservedStore. Stored resources
+// expire as described by freshness.
+func NewExpiringStore(
+	ios [][]rsrc.IO,
+	obChans []chan<- format.Formatter,
+	freshness Freshness,
+) (Store, error) {
+	return new(ios, obChans, freshness)
 }
 
 func (s *cache) Read(loc rsrc.Locator) (data []byte, err error) {
-	return s.read(loc, len(s.layers)-1, -1)
+	return s.ReadContext(context.Background(), loc)
+}
+
+func (s *cache) ReadContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error) {
+	return s.read(ctx, loc, len(s.layers)-1, -1)
 }
 
 func (s *cache) Update(loc rsrc.Locator) (data []byte, err error) {
-	return s.read(loc, 0, 1)
+	return s.UpdateContext(context.Background(), loc)
+}
+
+func (s *cache) UpdateContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error) {
+	return s.read(ctx, loc, 0, 1)
+}
+
+func (s *cache) Fetch(loc rsrc.Locator) (data []byte, err error) {
+	return s.FetchContext(context.Background(), loc)
+}
+
+func (s *cache) FetchContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error) {
+	data, _, err = s.find(ctx, loc, 0, 1)
+	return data, err
 }
 
 func (s *cache) Write(data []byte, loc rsrc.Locator) error {
@@ -131,22 +197,69 @@ func (s *cache) Remove(loc rsrc.Locator) error {
 	return nil
 }
 
-func (s *cache) read(loc rsrc.Locator, start int, di int,
+func (s *cache) read(ctx context.Context, loc rsrc.Locator, start int, di int,
 ) (data []byte, err error) {
+	data, idx, err := s.find(ctx, loc, start, di)
+	if err != nil {
+		return nil, err
+	}
+	if idx >= 0 {
+		s.write(data, loc, idx+1, 1)
+	}
+	return data, nil
+}
+
+// find searches the layers for a resource, beginning at start in direction di.
+// The index of the layer it was found in is returned, it is -1 if only a stale
+// copy was found.
+func (s *cache) find(ctx context.Context, loc rsrc.Locator, start int, di int,
+) (data []byte, idx int, err error) {
 
+	var stale []byte
+	var lastErr error
 	idx, found := s.cascade(start, di, func(i int) bool {
-		result := <-s.layers[i].read(loc)
+		if err := ctx.Err(); err != nil {
+			lastErr = err
+			return false
+		}
+		result := <-s.layers[i].read(ctx, loc)
+		if result.err != nil {
+			// layers that cannot hold the resource don't tell why it is missing
+			if lastErr == nil || !errors.Is(result.err, rsrc.ErrNoURL) {
+				lastErr = result.err
+			}
+			return false
+		}
+		if di < 0 && i > 0 && s.expired(loc, result.modified) {
+			if stale == nil {
+				stale = result.data
+			}
+			return false
+		}
 		data = result.data
-		return result.err == nil
+		return true
 	})
 
 	if !found {
+		if err := ctx.Err(); err != nil {
+			return nil, -1, err
+		}
+		if stale != nil {
+			return stale, -1, nil
+		}
 		s, _ := loc.Path()
-		return nil, fmt.Errorf("resource '%v' not found", s)
+		return nil, -1, fmt.Errorf("resource '%v' not found: %w", s, lastErr)
 	}
 
-	s.write(data, loc, idx+1, 1)
-	return data, nil
+	return data, idx, nil
+}
+
+func (s *cache) expired(loc rsrc.Locator, modified time.Time) bool {
+	if modified.IsZero() {
+		return false
+	}
+	ttl, ok := s.freshness[rsrc.Kind(loc)]
+	return ok && ttl > 0 && time.Since(modified) > ttl
 }
 
 func (s *cache) write(data []byte, loc rsrc.Locator, start int, di int) {
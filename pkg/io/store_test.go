@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/nilsbu/lastfm/pkg/display"
 	"github.com/nilsbu/lastfm/pkg/format"
@@ -525,3 +526,75 @@ func TestStoreWrongObserverCount(t *testing.T) {
 		t.Fatal("expected error in constructor")
 	}
 }
+
+type datedIO struct {
+	rsrc.IO
+	modified time.Time
+}
+
+func (io datedIO) Modified(loc rsrc.Locator) (time.Time, error) {
+	return io.modified, nil
+}
+
+func TestStoreReadExpired(t *testing.T) {
+	loc := rsrc.ArtistTags("abc")
+
+	cases := []struct {
+		name    string
+		age     time.Duration
+		distant []byte
+		data    []byte
+		written []byte
+	}{
+		{
+			"fresh copy is used",
+			time.Hour,
+			[]byte("new"),
+			[]byte("old"),
+			[]byte("old"),
+		},
+		{
+			"stale copy is replaced",
+			48 * time.Hour,
+			[]byte("new"),
+			[]byte("new"),
+			[]byte("new"),
+		},
+		{
+			"stale copy is used if no other is available",
+			48 * time.Hour,
+			nil,
+			[]byte("old"),
+			[]byte("old"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			distant, _ := mock.IO(map[rsrc.Locator][]byte{loc: c.distant}, mock.URL)
+			closer, _ := mock.IO(map[rsrc.Locator][]byte{loc: []byte("old")}, mock.Path)
+
+			s, err := NewExpiringStore(
+				[][]rsrc.IO{{distant}, {datedIO{closer, time.Now().Add(-c.age)}}},
+				dumpChans(2),
+				Freshness{"artist.getTopTags": 24 * time.Hour},
+			)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			data, err := s.Read(loc)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if !reflect.DeepEqual(data, c.data) {
+				t.Errorf("read '%v' but expected '%v'", string(data), string(c.data))
+			}
+
+			written, _ := closer.Read(loc)
+			if !reflect.DeepEqual(written, c.written) {
+				t.Errorf("closer layer contains '%v' but expected '%v'", string(written), string(c.written))
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package io
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -21,17 +22,28 @@ func NewWebIO(apiKey string) WebIO {
 	}
 }
 
-// Downloader is a reader for Last.fm. It implements io.Reader.
+// Downloader is a reader for Last.fm. It implements io.Reader and
+// rsrc.ContextReader.
 type Downloader string
 
 // TODO test with net/http/httptest
 func (d Downloader) Read(loc rsrc.Locator) (data []byte, err error) {
+	return d.ReadContext(context.Background(), loc)
+}
+
+// ReadContext downloads the resource. The request is aborted when ctx is done.
+func (d Downloader) ReadContext(ctx context.Context, loc rsrc.Locator) (data []byte, err error) {
 	url, err := loc.URL(string(d))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
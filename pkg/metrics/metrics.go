@@ -0,0 +1,242 @@
+// Package metrics collects counters and histograms and exposes them in the
+// Prometheus text format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefBuckets are the default upper bounds of histogram buckets. They are
+// suitable for durations in seconds.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// Default is the registry that metrics of this program are registered with.
+var Default = NewRegistry()
+
+type metric interface {
+	write(w io.Writer)
+}
+
+// Registry holds metrics. It implements http.Handler, serving all metrics in
+// the Prometheus text format. Registry is thread-safe.
+type Registry struct {
+	mtx     sync.Mutex
+	metrics []metric
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Counter creates a counter and registers it. The counter is split by the
+// given labels, values for them have to be passed in the same order when the
+// counter is increased.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	c := &Counter{
+		series: series{name: name, help: help, labels: labels},
+		values: map[string]float64{},
+	}
+	r.register(c)
+	return c
+}
+
+// Histogram creates a histogram with the given bucket upper bounds and
+// registers it. Labels work as for Counter().
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	bs := append([]float64{}, buckets...)
+	sort.Float64s(bs)
+	h := &Histogram{
+		series:  series{name: name, help: help, labels: labels},
+		buckets: bs,
+		values:  map[string]*histogramValue{},
+	}
+	r.register(h)
+	return h
+}
+
+func (r *Registry) register(m metric) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+// Write writes all metrics in the Prometheus text format.
+func (r *Registry) Write(w io.Writer) {
+	r.mtx.Lock()
+	metrics := append([]metric{}, r.metrics...)
+	r.mtx.Unlock()
+
+	for _, m := range metrics {
+		m.write(w)
+	}
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	r.Write(w)
+}
+
+type series struct {
+	mtx    sync.Mutex
+	name   string
+	help   string
+	labels []string
+}
+
+func (s *series) key(values []string) string {
+	if len(values) != len(s.labels) {
+		panic(fmt.Sprintf("metric '%v' has %v labels but got %v values",
+			s.name, len(s.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+func (s *series) header(w io.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %v %v\n", s.name, s.help)
+	fmt.Fprintf(w, "# TYPE %v %v\n", s.name, kind)
+}
+
+// labelString formats the labels that belong to key. Additional name-value
+// pairs can be appended in extra.
+func (s *series) labelString(key string, extra ...string) string {
+	pairs := []string{}
+	if len(s.labels) > 0 {
+		for i, value := range strings.Split(key, "\xff") {
+			pairs = append(pairs, fmt.Sprintf("%v=\"%v\"", s.labels[i], escape(value)))
+		}
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%v=\"%v\"", extra[i], escape(extra[i+1])))
+	}
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+// Counter is a metric that only increases.
+type Counter struct {
+	series
+	values map[string]float64
+}
+
+// Inc increases the counter by 1.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add increases the counter by v, which must not be negative.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	if v < 0 {
+		panic("counter cannot decrease")
+	}
+	key := c.key(labelValues)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.values[key] += v
+}
+
+// Value returns the current value of the counter.
+func (c *Counter) Value(labelValues ...string) float64 {
+	key := c.key(labelValues)
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.values[key]
+}
+
+func (c *Counter) write(w io.Writer) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.header(w, "counter")
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%v%v %v\n", c.name, c.labelString(k), formatFloat(c.values[k]))
+	}
+}
+
+// Histogram is a metric that counts observations in buckets.
+type Histogram struct {
+	series
+	buckets []float64
+	values  map[string]*histogramValue
+}
+
+type histogramValue struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Observe adds a single observation to the histogram.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	key := h.key(labelValues)
+
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	hv, ok := h.values[key]
+	if !ok {
+		hv = &histogramValue{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hv
+	}
+
+	for i, b := range h.buckets {
+		if v <= b {
+			hv.counts[i]++
+		}
+	}
+	hv.count++
+	hv.sum += v
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	h.header(w, "histogram")
+	keys := make([]string, 0, len(h.values))
+	for k := range h.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		hv := h.values[k]
+		for i, b := range h.buckets {
+			fmt.Fprintf(w, "%v_bucket%v %v\n",
+				h.name, h.labelString(k, "le", formatFloat(b)), hv.counts[i])
+		}
+		fmt.Fprintf(w, "%v_bucket%v %v\n", h.name, h.labelString(k, "le", "+Inf"), hv.count)
+		fmt.Fprintf(w, "%v_sum%v %v\n", h.name, h.labelString(k), formatFloat(hv.sum))
+		fmt.Fprintf(w, "%v_count%v %v\n", h.name, h.labelString(k), hv.count)
+	}
+}
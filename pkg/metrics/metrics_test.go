@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryWrite(t *testing.T) {
+	r := NewRegistry()
+	c := r.Counter("ops_total", "Number of ops.", "op")
+	h := r.Histogram("duration_seconds", "Duration.", []float64{1, 0.1})
+	plain := r.Counter("plain_total", "Plain.")
+
+	c.Inc("read")
+	c.Add(2, "write")
+	c.Inc("read")
+	c.Inc(`a"b`)
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(3)
+	plain.Inc()
+
+	buf := new(bytes.Buffer)
+	r.Write(buf)
+
+	expected := strings.Join([]string{
+		"# HELP ops_total Number of ops.",
+		"# TYPE ops_total counter",
+		`ops_total{op="a\"b"} 1`,
+		`ops_total{op="read"} 2`,
+		`ops_total{op="write"} 2`,
+		"# HELP duration_seconds Duration.",
+		"# TYPE duration_seconds histogram",
+		`duration_seconds_bucket{le="0.1"} 1`,
+		`duration_seconds_bucket{le="1"} 2`,
+		`duration_seconds_bucket{le="+Inf"} 3`,
+		"duration_seconds_sum 3.55",
+		"duration_seconds_count 3",
+		"# HELP plain_total Plain.",
+		"# TYPE plain_total counter",
+		"plain_total 1",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("wrong output:\n%v\nexpected:\n%v", buf.String(), expected)
+	}
+
+	if v := c.Value("read"); v != 2 {
+		t.Errorf("counter has value %v, expected 2", v)
+	}
+}
+
+func TestRegistryServeHTTP(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("x_total", "X.").Inc()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf("wrong content type '%v'", rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "x_total 1\n") {
+		t.Errorf("counter missing in output:\n%v", rec.Body.String())
+	}
+}
+
+func TestCounterWrongLabels(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	NewRegistry().Counter("x_total", "X.", "a").Inc()
+}
@@ -0,0 +1,127 @@
+package organize
+
+import (
+	"sort"
+	"strings"
+
+	async "github.com/nilsbu/async"
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// AlbumCompletion describes how completely an album has been heard. Tracks is
+// the number of tracks on the album, Heard is the number of them that were
+// played at least once and FullPlays is how often the album was played
+// through, i.e. the number of plays of its least played track.
+type AlbumCompletion struct {
+	Artist, Album string
+	Tracks        int
+	Heard         int
+	FullPlays     int
+}
+
+// Share returns the share of the album's tracks that were heard.
+func (c AlbumCompletion) Share() float64 {
+	if c.Tracks == 0 {
+		return 0
+	}
+	return float64(c.Heard) / float64(c.Tracks)
+}
+
+type album struct {
+	artist, name string
+}
+
+// CompleteAlbums computes the completion of all albums that occur in the
+// history. Tracks are matched to the tracklist by their title, ignoring case.
+// Albums whose tracklist cannot be loaded or is empty are missing from the
+// result, the errors are returned together with the remaining completions.
+// The result is sorted by share, then by full plays, both descending.
+func CompleteAlbums(history [][]info.Song, r rsrc.Reader) ([]AlbumCompletion, error) {
+	plays := countAlbumPlays(history)
+
+	albums := make([]album, 0, len(plays))
+	for a := range plays {
+		albums = append(albums, a)
+	}
+
+	loader := unpack.NewCached(r)
+	infos := make([]unpack.AlbumInfo, len(albums))
+	errs := make([]error, len(albums))
+	async.Pi(len(albums), func(i int) {
+		infos[i], errs[i] = unpack.LoadAlbumInfo(albums[i].artist, albums[i].name, loader)
+		errs[i] = errors.Wrapf(errs[i], "could not load album '%v' by '%v'",
+			albums[i].name, albums[i].artist)
+	})
+
+	completions := []AlbumCompletion{}
+	err := &async.MultiError{Msg: "could not load albums", Errs: []error{}}
+	for i, a := range albums {
+		if errs[i] != nil {
+			err.Errs = append(err.Errs, errs[i])
+		} else if len(infos[i].Tracks) > 0 {
+			completions = append(completions, complete(a, infos[i].Tracks, plays[a]))
+		}
+	}
+
+	sort.Slice(completions, func(i, j int) bool {
+		a, b := completions[i], completions[j]
+		if a.Share() != b.Share() {
+			return a.Share() > b.Share()
+		}
+		if a.FullPlays != b.FullPlays {
+			return a.FullPlays > b.FullPlays
+		}
+		if a.Artist != b.Artist {
+			return a.Artist < b.Artist
+		}
+		return a.Album < b.Album
+	})
+
+	if len(err.Errs) > 0 {
+		return completions, err
+	}
+	return completions, nil
+}
+
+func countAlbumPlays(history [][]info.Song) map[album]map[string]int {
+	plays := map[album]map[string]int{}
+	for _, day := range history {
+		for _, song := range day {
+			if song.Album == "" {
+				continue
+			}
+
+			a := album{song.Artist, song.Album}
+			if _, ok := plays[a]; !ok {
+				plays[a] = map[string]int{}
+			}
+			plays[a][strings.ToLower(song.Title)]++
+		}
+	}
+	return plays
+}
+
+func complete(a album, tracks []unpack.AlbumTrack, plays map[string]int) AlbumCompletion {
+	c := AlbumCompletion{
+		Artist:    a.artist,
+		Album:     a.name,
+		Tracks:    len(tracks),
+		FullPlays: -1,
+	}
+
+	for _, track := range tracks {
+		n := plays[strings.ToLower(track.Title)]
+		if n > 0 {
+			c.Heard++
+		}
+		if c.FullPlays < 0 || n < c.FullPlays {
+			c.FullPlays = n
+		}
+	}
+
+	return c
+}
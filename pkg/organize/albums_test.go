@@ -0,0 +1,55 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestCompleteAlbums(t *testing.T) {
+	io, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.AlbumInfo("A", "X"): []byte(`{"album":{"name":"X","artist":"A","tracks":{"track":[{"name":"one"},{"name":"two"},{"name":"three"}]}}}`),
+		rsrc.AlbumInfo("A", "Y"): []byte(`{"album":{"name":"Y","artist":"A","tracks":{"track":{"name":"single"}}}}`),
+		rsrc.AlbumInfo("B", "Z"): []byte(`{"error":6,"message":"Album not found"}`),
+		rsrc.AlbumInfo("B", "E"): []byte(`{"album":{"name":"E","artist":"B"}}`),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	history := [][]info.Song{
+		{
+			{Artist: "A", Title: "One", Album: "X"},
+			{Artist: "A", Title: "two", Album: "X"},
+			{Artist: "A", Title: "single", Album: "Y"},
+			{Artist: "B", Title: "z", Album: "Z"},
+		},
+		{
+			{Artist: "A", Title: "one", Album: "X"},
+			{Artist: "A", Title: "single", Album: "Y"},
+			{Artist: "A", Title: "two", Album: ""},
+			{Artist: "B", Title: "e", Album: "E"},
+		},
+	}
+
+	completions, err := organize.CompleteAlbums(history, io)
+	if err == nil {
+		t.Error("expected error for unknown album")
+	}
+
+	expected := []organize.AlbumCompletion{
+		{Artist: "A", Album: "Y", Tracks: 1, Heard: 1, FullPlays: 2},
+		{Artist: "A", Album: "X", Tracks: 3, Heard: 2, FullPlays: 0},
+	}
+	if !reflect.DeepEqual(completions, expected) {
+		t.Errorf("wrong completions:\n has:  %v\nwant: %v", completions, expected)
+	}
+
+	if share := completions[1].Share(); share != 2.0/3 {
+		t.Errorf("wrong share: has %v, want %v", share, 2.0/3)
+	}
+}
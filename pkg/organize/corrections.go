@@ -0,0 +1,144 @@
+package organize
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+)
+
+// Suggestion is a proposed artist correction. From is the name that would be
+// replaced by To.
+type Suggestion struct {
+	From, To           string
+	FromPlays, ToPlays int
+}
+
+// SuggestCorrections finds artist names in plays that are likely spellings of
+// the same artist. Names are considered equal if they only differ in case,
+// diacritics, a leading "The", "&" vs. "and", a "feat." suffix or punctuation.
+// Within such a group, all names are suggested to be corrected to the one that
+// was played the most, preferring names without a featured artist. Names that
+// already have a correction are ignored. The suggestions are sorted by the
+// plays of the target name and then alphabetically.
+func SuggestCorrections(plays [][]info.Song, corrections map[string]string) []Suggestion {
+	counts := map[string]int{}
+	for _, day := range plays {
+		for _, song := range day {
+			if _, ok := corrections[song.Artist]; ok {
+				continue
+			}
+			counts[song.Artist]++
+		}
+	}
+
+	groups := map[string][]string{}
+	for name := range counts {
+		key := normalizeArtist(name)
+		if key == "" {
+			continue
+		}
+		groups[key] = append(groups[key], name)
+	}
+
+	suggestions := []Suggestion{}
+	for _, names := range groups {
+		if len(names) < 2 {
+			continue
+		}
+
+		sort.Slice(names, func(i, j int) bool {
+			fi, fj := hasFeature(names[i]), hasFeature(names[j])
+			if fi != fj {
+				return fj
+			}
+			if counts[names[i]] != counts[names[j]] {
+				return counts[names[i]] > counts[names[j]]
+			}
+			return names[i] < names[j]
+		})
+
+		to := names[0]
+		for _, from := range names[1:] {
+			suggestions = append(suggestions, Suggestion{
+				From:      from,
+				To:        to,
+				FromPlays: counts[from],
+				ToPlays:   counts[to],
+			})
+		}
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if a.ToPlays != b.ToPlays {
+			return a.ToPlays > b.ToPlays
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		if a.FromPlays != b.FromPlays {
+			return a.FromPlays > b.FromPlays
+		}
+		return a.From < b.From
+	})
+
+	return suggestions
+}
+
+var featureMarkers = []string{" feat. ", " feat ", " ft. ", " ft ", " featuring ", " (feat. ", " (ft. "}
+
+func hasFeature(name string) bool {
+	return featureIndex(strings.ToLower(name)) >= 0
+}
+
+func featureIndex(name string) int {
+	idx := -1
+	for _, marker := range featureMarkers {
+		if i := strings.Index(name+" ", marker); i >= 0 && (idx < 0 || i < idx) {
+			idx = i
+		}
+	}
+	return idx
+}
+
+var diacritics = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'ā': "a",
+	'æ': "ae", 'ç': "c", 'č': "c", 'ć': "c", 'ď': "d", 'ð': "d",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ē': "e", 'ě': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i", 'ī': "i",
+	'ł': "l", 'ñ': "n", 'ń': "n", 'ň': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ō': "o",
+	'œ': "oe", 'ř': "r", 'ß': "ss", 'š': "s", 'ś': "s", 'ť': "t",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u", 'ū': "u", 'ů': "u",
+	'ý': "y", 'ÿ': "y", 'ž': "z", 'ź': "z", 'ż': "z",
+}
+
+// normalizeArtist reduces an artist name to a key that is equal for names that
+// likely refer to the same artist.
+func normalizeArtist(name string) string {
+	name = strings.ToLower(name)
+	if i := featureIndex(name); i >= 0 {
+		name = name[:i]
+	}
+
+	var sb strings.Builder
+	for _, r := range name {
+		if s, ok := diacritics[r]; ok {
+			sb.WriteString(s)
+		} else if r == '&' {
+			sb.WriteString(" and ")
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		} else if unicode.IsSpace(r) {
+			sb.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(sb.String())
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,94 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/organize"
+)
+
+func TestSuggestCorrections(t *testing.T) {
+	songs := func(artist string, n int) []info.Song {
+		day := make([]info.Song, n)
+		for i := range day {
+			day[i] = info.Song{Artist: artist}
+		}
+		return day
+	}
+
+	cases := []struct {
+		name        string
+		plays       [][]info.Song
+		corrections map[string]string
+		suggestions []organize.Suggestion
+	}{
+		{
+			"no plays",
+			[][]info.Song{},
+			map[string]string{},
+			[]organize.Suggestion{},
+		},
+		{
+			"case and diacritics",
+			[][]info.Song{
+				append(songs("Björk", 3), songs("bjork", 1)...),
+				songs("BJÖRK", 2),
+			},
+			map[string]string{},
+			[]organize.Suggestion{
+				{From: "BJÖRK", To: "Björk", FromPlays: 2, ToPlays: 3},
+				{From: "bjork", To: "Björk", FromPlays: 1, ToPlays: 3},
+			},
+		},
+		{
+			"the, and, punctuation",
+			[][]info.Song{
+				songs("The Beatles", 5),
+				songs("Beatles", 1),
+				songs("Simon & Garfunkel", 1),
+				songs("Simon and Garfunkel", 2),
+				songs("Guns N' Roses", 1),
+				songs("Guns N Roses", 1),
+				songs("The", 1),
+			},
+			map[string]string{},
+			[]organize.Suggestion{
+				{From: "Beatles", To: "The Beatles", FromPlays: 1, ToPlays: 5},
+				{From: "Simon & Garfunkel", To: "Simon and Garfunkel", FromPlays: 1, ToPlays: 2},
+				{From: "Guns N' Roses", To: "Guns N Roses", FromPlays: 1, ToPlays: 1},
+			},
+		},
+		{
+			"featured artists are not preferred",
+			[][]info.Song{
+				songs("A feat. B", 4),
+				songs("A ft. C", 1),
+				songs("A", 2),
+			},
+			map[string]string{},
+			[]organize.Suggestion{
+				{From: "A feat. B", To: "A", FromPlays: 4, ToPlays: 2},
+				{From: "A ft. C", To: "A", FromPlays: 1, ToPlays: 2},
+			},
+		},
+		{
+			"corrected names are ignored",
+			[][]info.Song{
+				songs("ABC", 2),
+				songs("abc", 1),
+			},
+			map[string]string{"abc": "X"},
+			[]organize.Suggestion{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			suggestions := organize.SuggestCorrections(c.plays, c.corrections)
+			if !reflect.DeepEqual(suggestions, c.suggestions) {
+				t.Errorf("wrong suggestions:\n has:  %v\nwant: %v", suggestions, c.suggestions)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package organize
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// DuplicateRules decide when a play is a duplicate of the previous play of the
+// same song. Window is the number of seconds within which every repetition is
+// a duplicate. A repetition is also a duplicate if it follows the previous
+// play by less than Share of the song's duration. Share is ignored if it is 0
+// or the duration is unknown.
+type DuplicateRules struct {
+	Window int64
+	Share  float64
+}
+
+// Duplicate is a play that is suspected to be a duplicate. Gap is the number
+// of seconds since the previous play of the same song.
+type Duplicate struct {
+	Song info.Song
+	Day  rsrc.Day
+	Gap  int64
+}
+
+// FindDuplicates detects plays that were likely scrobbled more than once.
+// plays contains the plays per day since registered. Plays without time are
+// ignored. Songs are matched by artist and title ignoring case. A play is
+// compared to the previous play of the same song that is no duplicate itself,
+// so that all repetitions in a burst are found. The result is sorted by time.
+func FindDuplicates(plays [][]info.Song, registered rsrc.Day, rules DuplicateRules) []Duplicate {
+	type timedPlay struct {
+		song info.Song
+		day  int
+	}
+
+	timed := []timedPlay{}
+	for d, day := range plays {
+		for _, song := range day {
+			if song.Time != 0 {
+				timed = append(timed, timedPlay{song, d})
+			}
+		}
+	}
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].song.Time < timed[j].song.Time
+	})
+
+	duplicates := []Duplicate{}
+	last := map[string]int64{}
+	for _, play := range timed {
+		key := strings.ToLower(play.song.Artist) + "\n" + strings.ToLower(play.song.Title)
+		if prev, ok := last[key]; ok {
+			gap := play.song.Time - prev
+			if rules.isDuplicate(gap, play.song.Duration) {
+				duplicates = append(duplicates, Duplicate{
+					Song: play.song,
+					Day:  registered.AddDate(0, 0, play.day),
+					Gap:  gap,
+				})
+				continue
+			}
+		}
+		last[key] = play.song.Time
+	}
+
+	return duplicates
+}
+
+func (r DuplicateRules) isDuplicate(gap int64, duration float64) bool {
+	if gap <= r.Window {
+		return true
+	}
+	// durations are stored in minutes
+	return r.Share > 0 && duration > 0 && float64(gap) < r.Share*duration*60
+}
@@ -0,0 +1,85 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	registered := rsrc.ParseDay("2018-01-01")
+	t0 := registered.Midnight()
+
+	cases := []struct {
+		name       string
+		plays      [][]info.Song
+		rules      organize.DuplicateRules
+		duplicates []organize.Duplicate
+	}{
+		{
+			"no plays",
+			[][]info.Song{{}},
+			organize.DuplicateRules{Window: 30},
+			[]organize.Duplicate{},
+		},
+		{
+			"burst within window",
+			[][]info.Song{{
+				{Artist: "A", Title: "a", Time: t0 + 20},
+				{Artist: "A", Title: "a", Time: t0 + 10},
+				{Artist: "a", Title: "A", Time: t0 + 30},
+				{Artist: "B", Title: "a", Time: t0 + 11},
+			}},
+			organize.DuplicateRules{Window: 30},
+			[]organize.Duplicate{
+				{Song: info.Song{Artist: "A", Title: "a", Time: t0 + 20}, Day: registered, Gap: 10},
+				{Song: info.Song{Artist: "a", Title: "A", Time: t0 + 30}, Day: registered, Gap: 20},
+			},
+		},
+		{
+			"outside of window",
+			[][]info.Song{{
+				{Artist: "A", Title: "a", Time: t0},
+				{Artist: "A", Title: "a", Time: t0 + 31},
+			}},
+			organize.DuplicateRules{Window: 30},
+			[]organize.Duplicate{},
+		},
+		{
+			"share of duration across days",
+			[][]info.Song{
+				{{Artist: "A", Title: "a", Duration: 4, Time: t0 + 86300}},
+				{
+					{Artist: "A", Title: "a", Duration: 4, Time: t0 + 86400},
+					{Artist: "A", Title: "a", Duration: 4, Time: t0 + 86600},
+				},
+			},
+			organize.DuplicateRules{Window: 30, Share: .5},
+			[]organize.Duplicate{
+				{Song: info.Song{Artist: "A", Title: "a", Duration: 4, Time: t0 + 86400},
+					Day: registered.AddDate(0, 0, 1), Gap: 100},
+			},
+		},
+		{
+			"plays without time",
+			[][]info.Song{{
+				{Artist: "A", Title: "a"},
+				{Artist: "A", Title: "a"},
+			}},
+			organize.DuplicateRules{Window: 30},
+			[]organize.Duplicate{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			duplicates := organize.FindDuplicates(c.plays, registered, c.rules)
+			if !reflect.DeepEqual(duplicates, c.duplicates) {
+				t.Errorf("wrong duplicates:\n has:  %v\nwant: %v", duplicates, c.duplicates)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package organize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	async "github.com/nilsbu/async"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// Damage describes a stored file that failed validation.
+type Damage struct {
+	Path string
+	Err  error
+}
+
+// FsckReport is the result of a storage check. Broken contains all files that
+// could not be validated, Missing contains the days from a user's registration
+// until before the bookmark for which no prepared history exists. That is the
+// range that is verified and loaded into the charts.
+type FsckReport struct {
+	Checked int
+	Broken  []Damage
+	Missing map[string][]rsrc.Day
+}
+
+// Fsck validates the files at the given paths against the schema they are
+// unpacked with and reports missing days in the prepared history of all users
+// that have a bookmark. The paths are expected to be in the form returned by
+// rsrc.Locator.Path().
+func Fsck(paths []string, r rsrc.Reader) (*FsckReport, error) {
+	errs := make([]error, len(paths))
+	async.Pi(len(paths), func(i int) {
+		data, err := r.Read(rsrc.File(paths[i]))
+		if err == nil {
+			err = unpack.CheckFile(paths[i], data)
+		}
+		errs[i] = err
+	})
+
+	report := &FsckReport{
+		Checked: len(paths),
+		Broken:  []Damage{},
+		Missing: map[string][]rsrc.Day{},
+	}
+
+	exists := map[string]bool{}
+	users := map[string]bool{}
+	for i, path := range paths {
+		exists[path] = true
+		if errs[i] != nil {
+			report.Broken = append(report.Broken, Damage{Path: path, Err: errs[i]})
+			continue
+		}
+
+		if parts := strings.Split(path, "/"); len(parts) == 4 &&
+			parts[0] == rsrc.Root && parts[1] == "user" && parts[3] == "bookmark.json" {
+			users[parts[2]] = true
+		}
+	}
+
+	for user := range users {
+		missing, err := missingDays(user, exists, r)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			report.Missing[user] = missing
+		}
+	}
+
+	return report, nil
+}
+
+func missingDays(user string, exists map[string]bool, r rsrc.Reader,
+) ([]rsrc.Day, error) {
+	info, err := unpack.LoadUserInfo(user, unpack.NewCacheless(r))
+	if err != nil {
+		return nil, fmt.Errorf("cannot check history of '%v': %v", user, err)
+	}
+	bookmark, err := unpack.LoadBookmark(user, r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot check history of '%v': %v", user, err)
+	}
+
+	missing := []rsrc.Day{}
+	days := rsrc.Between(info.Registered, bookmark).Days()
+	for i := 0; i < days; i++ {
+		day := info.Registered.AddDate(0, 0, i)
+		path, _ := rsrc.DayHistory(user, day).Path()
+		if !exists[path] {
+			missing = append(missing, day)
+		}
+	}
+	return missing, nil
+}
+
+// DamagedDays returns the days whose history is affected by the damaged files,
+// either because their prepared history or a raw page of their plays is
+// broken. The result maps user names to their days.
+func DamagedDays(damages []Damage) map[string][]rsrc.Day {
+	set := map[string]map[string]rsrc.Day{}
+	for _, damage := range damages {
+		if user, day, ok := dayOf(damage.Path); ok {
+			if _, ok := set[user]; !ok {
+				set[user] = map[string]rsrc.Day{}
+			}
+			set[user][day.String()] = day
+		}
+	}
+
+	days := map[string][]rsrc.Day{}
+	for user, userDays := range set {
+		for _, day := range userDays {
+			days[user] = append(days[user], day)
+		}
+		sort.Slice(days[user], func(i, j int) bool {
+			return days[user][i].Midnight() < days[user][j].Midnight()
+		})
+	}
+	return days
+}
+
+// dayOf determines the user and day a history file belongs to.
+func dayOf(path string) (user string, day rsrc.Day, ok bool) {
+	parts := strings.Split(strings.TrimSuffix(path, ".json"), "/")
+	switch {
+	case len(parts) == 5 && parts[1] == "user" && parts[3] == "history":
+		// <root>/user/<user>/history/<day>.json
+		day = rsrc.ParseDay(parts[4])
+		return parts[2], day, day != nil
+	case len(parts) == 6 && parts[2] == "user.getRecentTracks" && len(parts[5]) >= 10:
+		// <root>/raw/user.getRecentTracks/<user>/86400/<day>T<time>-<page>.json
+		day = rsrc.ParseDay(parts[5][:10])
+		return parts[3], day, day != nil
+	default:
+		return "", nil, false
+	}
+}
@@ -0,0 +1,56 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestFsck(t *testing.T) {
+	day := rsrc.ParseDay("2018-01-10")
+
+	files := map[rsrc.Locator][]byte{
+		rsrc.UserInfo("A"):                         []byte(`{"user":{"name":"A","registered":{"unixtime":1515542400}}}`),
+		rsrc.Bookmark("A"):                         []byte(`{"nextday":"2018-01-13"}`),
+		rsrc.DayHistory("A", day):                  []byte(`[["X","x","","1.000000"]]`),
+		rsrc.DayHistory("A", day.AddDate(0, 0, 1)): []byte(`[["X","x",`),
+		rsrc.History("A", 2, day):                  []byte(`{"recenttracks":`),
+	}
+
+	paths := []string{}
+	for loc := range files {
+		path, _ := loc.Path()
+		paths = append(paths, path)
+	}
+
+	io, err := mock.IO(files, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+
+	report, err := organize.Fsck(paths, io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if report.Checked != len(files) {
+		t.Errorf("checked %v files but expected %v", report.Checked, len(files))
+	}
+	if len(report.Broken) != 2 {
+		t.Errorf("found %v broken files but expected 2", len(report.Broken))
+	}
+
+	missing := map[string][]rsrc.Day{"A": {day.AddDate(0, 0, 2)}}
+	if !reflect.DeepEqual(missing, report.Missing) {
+		t.Errorf("wrong missing days\nwant: %v\nhas:  %v", missing, report.Missing)
+	}
+
+	damaged := organize.DamagedDays(report.Broken)
+	expected := map[string][]rsrc.Day{"A": {day, day.AddDate(0, 0, 1)}}
+	if !reflect.DeepEqual(expected, damaged) {
+		t.Errorf("wrong damaged days\nwant: %v\nhas:  %v", expected, damaged)
+	}
+}
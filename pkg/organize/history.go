@@ -3,6 +3,8 @@ package organize
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"time"
 
 	async "github.com/nilsbu/async"
 	"github.com/nilsbu/lastfm/pkg/info"
@@ -48,9 +50,12 @@ func UpdateHistory(
 		return oldPlays[:days], nil
 	}
 
-	newPlays, err := LoadHistory(
-		unpack.User{Name: user.Name, Registered: endCached},
-		end, f, cache) // TODO make fresh optional
+	loc, err := LoadTimezone(user.Name, s)
+	if err != nil {
+		return nil, err
+	}
+
+	newPlays, err := loadHistory(user.Name, endCached, end, loc, f, cache) // TODO make fresh optional
 	if err != nil {
 		return nil, err
 	}
@@ -91,29 +96,85 @@ func LoadHistory(
 		return nil, errors.New("parameter 'end' is no valid Day")
 	} else if user.Registered == nil {
 		return nil, errors.New("user has no valid registration date")
+	} else if loc, err := LoadTimezone(user.Name, io); err != nil {
+		return nil, err
 	} else {
-		return loadHistory(user.Name, user.Registered, end, io, l)
+		return loadHistory(user.Name, user.Registered, end, loc, io, l)
 	}
 }
 
+// LoadTimezone loads the time zone in which the plays of a user are sorted into
+// days. It is UTC if none was set or the settings don't exist. Other errors
+// are returned since sorting plays in the wrong time zone would overwrite the
+// prepared history.
+func LoadTimezone(user string, r rsrc.Reader) (*time.Location, error) {
+	settings, err := unpack.LoadSettings(user, r)
+	if errors.Is(err, fs.ErrNotExist) {
+		return time.UTC, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	} else if settings.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(settings.Timezone)
+}
+
+// loadHistory loads the plays of the days from begin to end (exclusive) in the
+// time zone loc and writes them as prepared history. Since Last.fm is queried
+// by UTC days, the plays of the UTC days that overlap with the local days are
+// loaded and then sorted by their time. Plays without time stay on the day
+// they were fetched for.
 func loadHistory(
 	user string,
 	begin, end rsrc.Day,
+	loc *time.Location,
 	io rsrc.IO,
 	l unpack.Loader) ([][]info.Song, error) {
 
-	if days := rsrc.Between(begin, end).Days(); days <= 0 {
+	days := rsrc.Between(begin, end).Days()
+	if days <= 0 {
 		return nil, nil
-	} else {
-		result := make([][]info.Song, days)
-		errs := async.Pie(days, func(i int) error {
-			date := begin.AddDate(0, 0, i)
-			dp, err := loadDayPlays(user, date, io, l)
-			result[i] = dp
-			return err
-		})
-		return result, errs
 	}
+
+	first := rsrc.ToDay(rsrc.LocalMidnight(begin, loc))
+	last := rsrc.ToDay(rsrc.LocalMidnight(end, loc) - 1)
+	fetched := make([][]info.Song, rsrc.Between(first, last).Days()+1)
+	errs := async.Pie(len(fetched), func(i int) error {
+		dp, err := loadDayPlays(user, first.AddDate(0, 0, i), io, l)
+		fetched[i] = dp
+		return err
+	})
+	if errs != nil {
+		return nil, errs
+	}
+
+	result := make([][]info.Song, days)
+	for i := range result {
+		result[i] = []info.Song{}
+	}
+	seen := map[info.Song]bool{}
+	offset := rsrc.Between(begin, first).Days()
+	for i, dp := range fetched {
+		for _, song := range dp {
+			d := offset + i
+			if song.Time != 0 {
+				// pages of neighbouring days can overlap at midnight
+				if seen[song] {
+					continue
+				}
+				seen[song] = true
+				d = rsrc.Between(begin, rsrc.ToLocalDay(song.Time, loc)).Days()
+			}
+			if d >= 0 && d < days {
+				result[d] = append(result[d], song)
+			}
+		}
+	}
+
+	for i, dp := range result {
+		unpack.WriteDayHistory(dp, user, begin.AddDate(0, 0, i), io)
+	}
+	return result, nil
 }
 
 func loadDayPlays(
@@ -131,7 +192,6 @@ func loadDayPlays(
 	}
 
 	if firstPage.Pages < 2 {
-		unpack.WriteDayHistory(firstPage.Plays, user, time, io)
 		return firstPage.Plays, nil
 	}
 
@@ -155,7 +215,6 @@ func loadDayPlays(
 	plays := []info.Song{}
 	for _, page := range pages {
 		plays = append(plays, page...)
-		unpack.WriteDayHistory(plays, user, time, io)
 	}
 	return plays, err
 }
@@ -185,9 +244,13 @@ func BackupUpdateHistory(userName string, delta int, s io.Store) error {
 	} else if backup, err = unpack.LoadBackupBookmark(userName, s); err != nil {
 		backup = user.Registered
 	}
+	loc, err := LoadTimezone(userName, s)
+	if err != nil {
+		return err
+	}
 	end := bookmark.AddDate(0, 0, -delta)
 	cache := unpack.NewCached(s)
-	if songs, err := loadHistory(userName, backup, end, io.FreshStore(s), cache); err != nil {
+	if songs, err := loadHistory(userName, backup, end, loc, io.FreshStore(s), cache); err != nil {
 		return err
 	} else if len(songs) == 0 {
 		return nil
@@ -197,3 +260,33 @@ func BackupUpdateHistory(userName string, delta int, s io.Store) error {
 		return nil
 	}
 }
+
+// RefetchDays re-fetches the plays of the given days from the most distant
+// layer of the store and overwrites the prepared history of these days.
+func RefetchDays(userName string, days []rsrc.Day, s io.Store) error {
+	loc, err := LoadTimezone(userName, s)
+	if err != nil {
+		return err
+	}
+	cache := unpack.NewCached(s)
+	fresh := io.FreshStore(s)
+	return async.Pie(len(days), func(i int) error {
+		_, err := loadHistory(userName, days[i], days[i].AddDate(0, 0, 1), loc, fresh, cache)
+		return err
+	})
+}
+
+// RebuildHistory sorts the plays of all days up to the bookmark into days again
+// using the cached raw data. It is needed after the time zone was changed.
+func RebuildHistory(userName string, s io.Store) error {
+	if user, err := unpack.LoadUserInfo(userName, unpack.NewCacheless(s)); err != nil {
+		return err
+	} else if bookmark, err := unpack.LoadBookmark(userName, s); err != nil {
+		return err
+	} else if loc, err := LoadTimezone(userName, s); err != nil {
+		return err
+	} else {
+		_, err := loadHistory(userName, user.Registered, bookmark.AddDate(0, 0, 1), loc, s, unpack.NewCached(s))
+		return err
+	}
+}
@@ -126,6 +126,91 @@ func TestLoadHistory(t *testing.T) {
 	}
 }
 
+func TestLoadHistoryTimezone(t *testing.T) {
+	page := func(tracks ...string) []byte {
+		return []byte(fmt.Sprintf(`{"recenttracks":{"track":[%v], "@attr":{"totalPages":"1"}}}`,
+			strings.Join(tracks, ",")))
+	}
+	track := func(artist string, time int64) string {
+		return fmt.Sprintf(`{"artist":{"#text":"%v"},"date":{"uts":"%v"}}`, artist, time)
+	}
+
+	files := map[rsrc.Locator][]byte{
+		rsrc.Settings("TZ"): []byte(`{"timezone":"America/New_York"}`),
+		rsrc.History("TZ", 1, rsrc.ParseDay("2018-01-10")): page(
+			track("A", 1515546000), track("B", 1515578400)),
+		rsrc.History("TZ", 1, rsrc.ParseDay("2018-01-11")): page(
+			track("C", 1515639600), track("D", 1515672000)),
+		rsrc.History("TZ", 1, rsrc.ParseDay("2018-01-12")): page(
+			track("D", 1515672000), track("E", 1515722400)),
+		rsrc.DayHistory("TZ", rsrc.ParseDay("2018-01-10")): nil,
+		rsrc.DayHistory("TZ", rsrc.ParseDay("2018-01-11")): nil,
+	}
+	for _, artist := range []string{"A", "B", "C", "D", "E"} {
+		files[rsrc.TrackInfo(artist, "")] = []byte(`{"track":{"duration":"60000"}}`)
+	}
+
+	io, _ := mock.IO(files, mock.Path)
+	user := unpack.User{Name: "TZ", Registered: rsrc.ParseDay("2018-01-10")}
+	dps, err := organize.LoadHistory(user, rsrc.ParseDay("2018-01-12"), io, unpack.NewCached(io))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expect := [][]info.Song{
+		{{Artist: "B", Duration: 1, Time: 1515578400}, {Artist: "C", Duration: 1, Time: 1515639600}},
+		{{Artist: "D", Duration: 1, Time: 1515672000}, {Artist: "E", Duration: 1, Time: 1515722400}},
+	}
+	if !reflect.DeepEqual(dps, expect) {
+		t.Errorf("wrong data:\nhas:      %v\nexpected: %v", dps, expect)
+	}
+
+	saved, err := unpack.LoadDayHistory("TZ", rsrc.ParseDay("2018-01-11"), io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	} else if !reflect.DeepEqual(saved, expect[1]) {
+		t.Errorf("wrong saved day:\nhas:      %v\nexpected: %v", saved, expect[1])
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		settings []byte
+		offline  bool
+		loc      string
+		ok       bool
+	}{
+		{"no settings", nil, false, "UTC", true},
+		{"no time zone", []byte(`{}`), false, "UTC", true},
+		{"time zone", []byte(`{"timezone":"America/New_York"}`), false, "America/New_York", true},
+		{"corrupt settings", []byte(`{"timezone":`), false, "", false},
+		{"offline without settings", nil, true, "UTC", true},
+		{"offline time zone", []byte(`{"timezone":"America/New_York"}`), true, "America/New_York", true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var io0 rsrc.IO
+			if c.offline {
+				io0 = io.NewOfflineIO()
+			} else {
+				io0, _ = mock.IO(map[rsrc.Locator][]byte{}, mock.URL)
+			}
+			io1, _ := mock.IO(map[rsrc.Locator][]byte{rsrc.Settings("A"): c.settings}, mock.Path)
+			s, _ := io.NewStore([][]rsrc.IO{{io0}, {io1}})
+
+			loc, err := organize.LoadTimezone("A", s)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if err == nil && loc.String() != c.loc {
+				t.Errorf("expected '%v' but got '%v'", c.loc, loc)
+			}
+		})
+	}
+}
+
 func TestUpdateHistory(t *testing.T) {
 	h0 := rsrc.History("AA", 1, rsrc.ParseDay("2018-01-10"))
 	h1 := rsrc.History("AA", 1, rsrc.ParseDay("2018-01-11"))
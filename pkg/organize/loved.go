@@ -0,0 +1,96 @@
+package organize
+
+import (
+	"strings"
+
+	async "github.com/nilsbu/async"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// UpdateLovedTracks downloads all loved tracks of a user and stores them. The
+// stored tracks are replaced, so tracks that were unloved disappear.
+func UpdateLovedTracks(user string, s io.Store) ([]unpack.LovedTrack, error) {
+	l := unpack.NewCacheless(io.FreshStore(s))
+
+	firstPage, err := unpack.LoadLovedTracksPage(user, 1, l)
+	if err != nil {
+		return nil, err
+	}
+
+	n := firstPage.Pages
+	if n < 1 {
+		n = 1
+	}
+	pages := make([][]unpack.LovedTrack, n)
+	pages[0] = firstPage.Tracks
+
+	err = async.Pie(len(pages)-1, func(i int) error {
+		page, err := unpack.LoadLovedTracksPage(user, i+2, l)
+		if err != nil {
+			return err
+		}
+		pages[i+1] = page.Tracks
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := []unpack.LovedTrack{}
+	for _, page := range pages {
+		tracks = append(tracks, page...)
+	}
+
+	return tracks, unpack.WriteLovedTracks(tracks, user, s)
+}
+
+// LovedPlays contains how often a loved track was played before the day it was
+// loved and since then.
+type LovedPlays struct {
+	unpack.LovedTrack
+	Before, After int
+}
+
+// CountLovedPlays counts the plays of loved tracks before and after they were
+// loved. plays contains the plays per day since registered. Tracks are matched
+// by artist and title ignoring case.
+func CountLovedPlays(
+	tracks []unpack.LovedTrack,
+	plays [][]info.Song,
+	registered rsrc.Day,
+) []LovedPlays {
+	counts := make([]LovedPlays, len(tracks))
+	lovedOn := make([]int, len(tracks))
+	idx := map[string][]int{}
+	for i, track := range tracks {
+		counts[i].LovedTrack = track
+		lovedOn[i] = rsrc.Between(registered, track.Loved).Days()
+		key := LovedKey(charts.SongTitle(info.Song{Artist: track.Artist, Title: track.Title}))
+		idx[key] = append(idx[key], i)
+	}
+
+	for d, day := range plays {
+		for _, song := range day {
+			for _, i := range idx[LovedKey(charts.SongTitle(song))] {
+				if d < lovedOn[i] {
+					counts[i].Before++
+				} else {
+					counts[i].After++
+				}
+			}
+		}
+	}
+
+	return counts
+}
+
+// LovedKey returns the key by which titles are matched with loved tracks. The
+// case is ignored.
+func LovedKey(title charts.Title) string {
+	return strings.ToLower(title.Key())
+}
@@ -0,0 +1,76 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestUpdateLovedTracks(t *testing.T) {
+	web, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.LovedTracks("user", 1): []byte(`{"lovedtracks":{"track":[{"artist":{"name":"A"},"name":"a","date":{"uts":"86400"}}],"@attr":{"totalPages":"2"}}}`),
+		rsrc.LovedTracks("user", 2): []byte(`{"lovedtracks":{"track":{"artist":{"name":"B"},"name":"b","date":{"uts":"0"}},"@attr":{"totalPages":"2"}}}`),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+	files, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.LovedTracks("user", 1): nil,
+		rsrc.LovedTracks("user", 2): nil,
+		rsrc.Loved("user"):          nil,
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+	store, _ := io.NewStore([][]rsrc.IO{{web}, {files}})
+
+	tracks, err := organize.UpdateLovedTracks("user", store)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := []unpack.LovedTrack{
+		{Artist: "A", Title: "a", Loved: rsrc.ParseDay("1970-01-02")},
+		{Artist: "B", Title: "b", Loved: rsrc.ParseDay("1970-01-01")},
+	}
+	if !reflect.DeepEqual(tracks, expected) {
+		t.Errorf("wrong tracks:\n has:  %v\nwant: %v", tracks, expected)
+	}
+
+	stored, err := unpack.LoadLovedTracks("user", files)
+	if err != nil {
+		t.Fatal("loved tracks were not stored:", err)
+	}
+	if !reflect.DeepEqual(stored, expected) {
+		t.Errorf("wrong stored tracks:\n has:  %v\nwant: %v", stored, expected)
+	}
+}
+
+func TestCountLovedPlays(t *testing.T) {
+	tracks := []unpack.LovedTrack{
+		{Artist: "A", Title: "a", Loved: rsrc.ParseDay("2018-01-02")},
+		{Artist: "B", Title: "b", Loved: rsrc.ParseDay("2018-01-01")},
+		{Artist: "C", Title: "c", Loved: rsrc.ParseDay("2018-01-01")},
+	}
+	plays := [][]info.Song{
+		{{Artist: "A", Title: "a"}, {Artist: "A", Title: "a"}, {Artist: "B", Title: "b"}},
+		{{Artist: "a", Title: "A"}, {Artist: "A", Title: "x"}},
+		{{Artist: "B", Title: "b"}},
+	}
+
+	counts := organize.CountLovedPlays(tracks, plays, rsrc.ParseDay("2018-01-01"))
+	expected := []organize.LovedPlays{
+		{LovedTrack: tracks[0], Before: 2, After: 1},
+		{LovedTrack: tracks[1], Before: 0, After: 2},
+		{LovedTrack: tracks[2], Before: 0, After: 0},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("wrong counts:\n has:  %v\nwant: %v", counts, expected)
+	}
+}
@@ -0,0 +1,100 @@
+package organize
+
+import (
+	"sort"
+
+	async "github.com/nilsbu/async"
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// SimilarityGraph is a directed graph that contains for each artist the
+// artists that Last.fm considers similar to it.
+type SimilarityGraph map[string][]unpack.SimilarArtist
+
+// LoadSimilarityGraph loads the similar artists of all given artists. Artists
+// whose similar artists cannot be loaded are missing from the graph, the
+// errors are returned together with the remaining graph.
+func LoadSimilarityGraph(artists []string, r rsrc.Reader) (SimilarityGraph, error) {
+	loader := unpack.NewCached(r)
+
+	similar := make([][]unpack.SimilarArtist, len(artists))
+	errs := make([]error, len(artists))
+	async.Pi(len(artists), func(i int) {
+		similar[i], errs[i] = unpack.LoadSimilarArtists(artists[i], loader)
+		errs[i] = errors.Wrapf(errs[i], "could not load similar artists of '%v'", artists[i])
+	})
+
+	graph := SimilarityGraph{}
+	err := &async.MultiError{Msg: "could not load similar artists", Errs: []error{}}
+	for i, artist := range artists {
+		if errs[i] != nil {
+			err.Errs = append(err.Errs, errs[i])
+		} else {
+			graph[artist] = similar[i]
+		}
+	}
+
+	if len(err.Errs) > 0 {
+		return graph, err
+	}
+	return graph, nil
+}
+
+// Neighbors returns the artists that are connected to artist in either
+// direction, i.e. the artists that are similar to it and the artists it is
+// similar to. If an artist is connected in both directions, the higher match
+// is used.
+func (g SimilarityGraph) Neighbors(artist string) map[string]float64 {
+	neighbors := map[string]float64{}
+	for _, s := range g[artist] {
+		neighbors[s.Name] = s.Match
+	}
+
+	for other, similar := range g {
+		for _, s := range similar {
+			if s.Name == artist && s.Match > neighbors[other] {
+				neighbors[other] = s.Match
+			}
+		}
+	}
+	delete(neighbors, artist)
+
+	return neighbors
+}
+
+// Recommend finds artists that are similar to the ones in plays but were
+// played at most maxPlays times. Each artist in plays recommends the artists
+// similar to it with its number of plays times their match. The sum of these
+// scores is returned in descending order.
+func (g SimilarityGraph) Recommend(plays map[string]float64, maxPlays float64) []Score {
+	scores := map[string]float64{}
+	for artist, n := range plays {
+		for _, s := range g[artist] {
+			if plays[s.Name] <= maxPlays {
+				scores[s.Name] += n * s.Match
+			}
+		}
+	}
+
+	ranking := make([]Score, 0, len(scores))
+	for name, score := range scores {
+		ranking = append(ranking, Score{Name: name, Score: score})
+	}
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Score != ranking[j].Score {
+			return ranking[i].Score > ranking[j].Score
+		}
+		return ranking[i].Name < ranking[j].Name
+	})
+
+	return ranking
+}
+
+// Score assigns a name a value.
+type Score struct {
+	Name  string
+	Score float64
+}
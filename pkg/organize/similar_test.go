@@ -0,0 +1,58 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestLoadSimilarityGraph(t *testing.T) {
+	io, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.ArtistSimilar("A"): []byte(`{"similarartists":{"artist":[{"name":"B","match":"0.5"}]}}`),
+		rsrc.ArtistSimilar("B"): []byte(`{"similarartists":{"artist":[]}}`),
+		rsrc.ArtistSimilar("C"): []byte(`{"error":6,"message":"The artist you supplied could not be found"}`),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	graph, err := organize.LoadSimilarityGraph([]string{"A", "B", "C"}, io)
+	if err == nil {
+		t.Error("expected error for unknown artist")
+	}
+
+	expected := organize.SimilarityGraph{
+		"A": {{Name: "B", Match: 0.5}},
+		"B": {},
+	}
+	if !reflect.DeepEqual(graph, expected) {
+		t.Errorf("wrong graph:\n has:  %v\nwant: %v", graph, expected)
+	}
+}
+
+func TestSimilarityGraph(t *testing.T) {
+	graph := organize.SimilarityGraph{
+		"A": {{Name: "B", Match: 0.5}, {Name: "X", Match: 1}, {Name: "Y", Match: 0.5}},
+		"B": {{Name: "A", Match: 0.75}, {Name: "Y", Match: 0.5}},
+		"C": {{Name: "B", Match: 0.25}},
+	}
+
+	neighbors := graph.Neighbors("B")
+	expectedNeighbors := map[string]float64{"A": 0.75, "Y": 0.5, "C": 0.25}
+	if !reflect.DeepEqual(neighbors, expectedNeighbors) {
+		t.Errorf("wrong neighbors:\n has:  %v\nwant: %v", neighbors, expectedNeighbors)
+	}
+
+	plays := map[string]float64{"A": 100, "B": 10, "C": 5, "Y": 2}
+	scores := graph.Recommend(plays, 5)
+	expectedScores := []organize.Score{
+		{Name: "X", Score: 100},
+		{Name: "Y", Score: 55},
+	}
+	if !reflect.DeepEqual(scores, expectedScores) {
+		t.Errorf("wrong recommendations:\n has:  %v\nwant: %v", scores, expectedScores)
+	}
+}
@@ -0,0 +1,79 @@
+package organize
+
+import (
+	"fmt"
+
+	"github.com/nilsbu/lastfm/config"
+	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// TagConfig is the configuration that is used to partition artists by their
+// tags. Supertags and Countries map lower case tags to the partitions they
+// belong to, Genres maps genres to their parent genres and the tags in
+// Blacklist are ignored.
+type TagConfig struct {
+	Supertags map[string]string
+	Countries map[string]string
+	Genres    map[string]string
+	Blacklist []string
+}
+
+// LoadTagConfig loads the tag configuration from the data root. The defaults
+// in package config are used for files that don't exist. An error is returned
+// if a file exists but is not valid.
+func LoadTagConfig(r rsrc.Reader) (*TagConfig, error) {
+	tc := &TagConfig{
+		Supertags: copyTagMap(config.Supertags),
+		Countries: copyTagMap(config.Countries),
+		Genres:    copyTagMap(config.Genres),
+		Blacklist: append([]string{}, config.BlacklistedTags...),
+	}
+
+	var err error
+	if _, rerr := r.Read(rsrc.Supertags()); rerr == nil {
+		if tc.Supertags, err = unpack.LoadSupertags(r); err != nil {
+			return nil, fmt.Errorf("invalid supertags: %v", err)
+		}
+	}
+	if _, rerr := r.Read(rsrc.Countries()); rerr == nil {
+		if tc.Countries, err = unpack.LoadCountries(r); err != nil {
+			return nil, fmt.Errorf("invalid countries: %v", err)
+		}
+	}
+	if _, rerr := r.Read(rsrc.Genres()); rerr == nil {
+		if tc.Genres, err = unpack.LoadGenres(r); err != nil {
+			return nil, fmt.Errorf("invalid genres: %v", err)
+		}
+	}
+	if _, err := tc.GenreTree(); err != nil {
+		return nil, fmt.Errorf("invalid genres: %v", err)
+	}
+	if _, rerr := r.Read(rsrc.Blacklist()); rerr == nil {
+		if tc.Blacklist, err = unpack.LoadBlacklist(r); err != nil {
+			return nil, fmt.Errorf("invalid blacklist: %v", err)
+		}
+	}
+
+	return tc, nil
+}
+
+// BlacklistSet returns the set of tags that are ignored when artists are
+// partitioned by their tags, including nationalities and country names.
+func (tc *TagConfig) BlacklistSet() map[string]interface{} {
+	return config.NewBlacklist(tc.Blacklist, tc.Countries)
+}
+
+// GenreTree returns the hierarchy of genres.
+func (tc *TagConfig) GenreTree() (*charts.GenreTree, error) {
+	return charts.NewGenreTree(tc.Genres)
+}
+
+func copyTagMap(tags map[string]string) map[string]string {
+	c := make(map[string]string, len(tags))
+	for k, v := range tags {
+		c[k] = v
+	}
+	return c
+}
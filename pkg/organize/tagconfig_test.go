@@ -0,0 +1,60 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/config"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestLoadTagConfig(t *testing.T) {
+	io, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.Supertags(): nil,
+		rsrc.Countries(): nil,
+		rsrc.Genres():    nil,
+		rsrc.Blacklist(): []byte(`{"tags":["seen live"]}`),
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+
+	tc, err := organize.LoadTagConfig(io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(tc.Supertags, config.Supertags) {
+		t.Error("supertags don't match the defaults")
+	}
+	if !reflect.DeepEqual(tc.Blacklist, []string{"seen live"}) {
+		t.Errorf("wrong blacklist: %v", tc.Blacklist)
+	}
+	if _, ok := tc.BlacklistSet()["german"]; !ok {
+		t.Error("nationalities must be blacklisted")
+	}
+
+	// the defaults must be valid once they are written
+	if err := unpack.WriteSupertags(tc.Supertags, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+	if err := unpack.WriteCountries(tc.Countries, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+	if err := unpack.WriteGenres(tc.Genres, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+	if _, err := organize.LoadTagConfig(io); err != nil {
+		t.Error("defaults are not valid:", err)
+	}
+
+	// genres must not be subgenres of themselves
+	if err := unpack.WriteGenres(map[string]string{"a": "b", "b": "a"}, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+	if _, err := organize.LoadTagConfig(io); err == nil {
+		t.Error("expected error for cyclic genres")
+	}
+}
@@ -0,0 +1,130 @@
+package organize
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	async "github.com/nilsbu/async"
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+// Mismatch is a UTC day for which the number of plays in the prepared history
+// differs from the total that Last.fm currently reports.
+type Mismatch struct {
+	Day              rsrc.Day
+	Stored, Reported int
+}
+
+// VerifyHistory compares the plays in the prepared history of a user with the
+// totals that Last.fm reports for each day from the registration until before
+// the bookmark. The totals are fetched from Last.fm without touching stored
+// pages, since these are what the prepared history was built from. Last.fm is queried by UTC
+// days, so the prepared plays are counted by the UTC day of their time.
+func VerifyHistory(user string, s io.Store) ([]Mismatch, error) {
+	info, err := unpack.LoadUserInfo(user, unpack.NewCacheless(s))
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify history of '%v': %v", user, err)
+	}
+	bookmark, err := unpack.LoadBookmark(user, s)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify history of '%v': %v", user, err)
+	}
+
+	days := rsrc.Between(info.Registered, bookmark).Days()
+	if days <= 0 {
+		return []Mismatch{}, nil
+	}
+
+	stored, err := countPlays(user, info.Registered, days, s)
+	if err != nil {
+		return nil, err
+	}
+
+	reported := make([]int, days)
+	fresh := unpack.NewCacheless(io.FetchedStore(s))
+	err = async.Pie(days, func(i int) error {
+		page, err := unpack.LoadHistoryDayPage(user, 1, info.Registered.AddDate(0, 0, i), fresh)
+		if err != nil {
+			return err
+		}
+		reported[i] = page.Total
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mismatches := []Mismatch{}
+	for i := range reported {
+		if stored[i] != reported[i] {
+			mismatches = append(mismatches, Mismatch{
+				Day:      info.Registered.AddDate(0, 0, i),
+				Stored:   stored[i],
+				Reported: reported[i],
+			})
+		}
+	}
+	return mismatches, nil
+}
+
+// countPlays counts the plays in the prepared history by the UTC day of their
+// time, beginning at begin. Plays without time were prepared on the day they
+// were fetched for. Since the prepared days are in the user's time zone, the
+// neighbouring days are read as well. Days without prepared history have no
+// plays.
+func countPlays(user string, begin rsrc.Day, days int, r rsrc.Reader) ([]int, error) {
+	prepared := make([][]info.Song, days+2)
+	err := async.Pie(len(prepared), func(i int) error {
+		songs, err := unpack.LoadDayHistory(user, begin.AddDate(0, 0, i-1), r)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		prepared[i] = songs
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, days)
+	for i, songs := range prepared {
+		for _, song := range songs {
+			d := i - 1
+			if song.Time != 0 {
+				d = rsrc.Between(begin, rsrc.ToDay(song.Time)).Days()
+			}
+			if d >= 0 && d < days {
+				counts[d]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// RepairHistory re-fetches the raw pages of the mismatched days and prepares
+// the history of all days in the user's time zone that contain plays of them.
+func RepairHistory(user string, mismatches []Mismatch, s io.Store) error {
+	loc, err := LoadTimezone(user, s)
+	if err != nil {
+		return err
+	}
+
+	days := []rsrc.Day{}
+	seen := map[string]bool{}
+	for _, m := range mismatches {
+		first := rsrc.ToLocalDay(m.Day.Midnight(), loc)
+		last := rsrc.ToLocalDay(m.Day.AddDate(0, 0, 1).Midnight()-1, loc)
+		for day := first; rsrc.Between(day, last).Days() >= 0; day = day.AddDate(0, 0, 1) {
+			if !seen[day.String()] {
+				seen[day.String()] = true
+				days = append(days, day)
+			}
+		}
+	}
+
+	return RefetchDays(user, days, s)
+}
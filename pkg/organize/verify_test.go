@@ -0,0 +1,99 @@
+package organize_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/info"
+	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/organize"
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestVerifyAndRepairHistory(t *testing.T) {
+	day := rsrc.ParseDay("2018-01-10")
+
+	local := map[rsrc.Locator][]byte{
+		rsrc.UserInfo("A"):                         []byte(`{"user":{"name":"A","registered":{"unixtime":1515542400}}}`),
+		rsrc.Bookmark("A"):                         []byte(`{"nextday":"2018-01-13"}`),
+		rsrc.History("A", 1, day):                  nil,
+		rsrc.History("A", 1, day.AddDate(0, 0, 1)): nil,
+		rsrc.History("A", 2, day.AddDate(0, 0, 1)): nil,
+		rsrc.History("A", 1, day.AddDate(0, 0, 2)): nil,
+		rsrc.DayHistory("A", day):                  nil,
+		rsrc.DayHistory("A", day.AddDate(0, 0, 1)): nil,
+		rsrc.DayHistory("A", day.AddDate(0, 0, 2)): nil,
+		rsrc.TrackInfo("X", ""):                    []byte(`{"track":{"duration":"60000"}}`),
+		rsrc.TrackInfo("Y", ""):                    []byte(`{"track":{"duration":"60000"}}`),
+	}
+	remote := map[rsrc.Locator][]byte{
+		rsrc.History("A", 1, day): []byte(
+			`{"recenttracks":{"track":[{"artist":{"#text":"X"}}],"@attr":{"totalPages":"1","total":"1"}}}`),
+		rsrc.History("A", 1, day.AddDate(0, 0, 1)): []byte(
+			`{"recenttracks":{"track":[{"artist":{"#text":"X"}}],"@attr":{"totalPages":"2","total":"2"}}}`),
+		rsrc.History("A", 2, day.AddDate(0, 0, 1)): []byte(
+			`{"recenttracks":{"track":[{"artist":{"#text":"Y"}}],"@attr":{"totalPages":"2","total":"2"}}}`),
+		rsrc.History("A", 1, day.AddDate(0, 0, 2)): []byte(
+			`{"recenttracks":{"track":[{"artist":{"#text":"Y"}}],"@attr":{"totalPages":"1","total":"1"}}}`),
+	}
+
+	io0, err := mock.IO(remote, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+	io1, err := mock.IO(local, mock.Path)
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+	s, err := io.NewStore([][]rsrc.IO{{io0}, {io1}})
+	if err != nil {
+		t.Fatal("setup error:", err)
+	}
+
+	// the stored raw pages agree with the prepared history, only Last.fm has
+	// more plays by now
+	unpack.WriteDayHistory([]info.Song{{Artist: "X", Duration: 1}}, "A", day, s)
+	unpack.WriteDayHistory([]info.Song{{Artist: "X", Duration: 1}}, "A", day.AddDate(0, 0, 1), s)
+
+	mismatches, err := organize.VerifyHistory("A", s)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	expected := []organize.Mismatch{
+		{Day: day.AddDate(0, 0, 1), Stored: 1, Reported: 2},
+		{Day: day.AddDate(0, 0, 2), Stored: 0, Reported: 1},
+	}
+	if !reflect.DeepEqual(mismatches, expected) {
+		t.Errorf("wrong mismatches\nwant: %v\nhas:  %v", expected, mismatches)
+	}
+
+	// verifying must not write the fetched pages
+	if _, err := io1.Read(rsrc.History("A", 1, day.AddDate(0, 0, 1))); err == nil {
+		t.Error("fetched page was written during verification")
+	}
+
+	if err := organize.RepairHistory("A", mismatches, s); err != nil {
+		t.Fatal("unexpected error in repair:", err)
+	}
+
+	plays, err := unpack.LoadDayHistory("A", day.AddDate(0, 0, 1), s)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	want := []info.Song{{Artist: "X", Duration: 1}, {Artist: "Y", Duration: 1}}
+	if !reflect.DeepEqual(plays, want) {
+		t.Errorf("wrong repaired plays\nwant: %v\nhas:  %v", want, plays)
+	}
+
+	plays, err = unpack.LoadDayHistory("A", day.AddDate(0, 0, 2), s)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	want = []info.Song{{Artist: "Y", Duration: 1}}
+	if !reflect.DeepEqual(plays, want) {
+		t.Errorf("wrong repaired plays\nwant: %v\nhas:  %v", want, plays)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"sync"
 
 	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/metrics"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
 
+var lookups = metrics.Default.Counter("lastfm_pipeline_cache_lookups_total",
+	"Number of lookups of pipeline steps in the cache by result.", "result")
+
 type graph struct {
 	root    *node
 	counter int
@@ -36,8 +40,10 @@ func (c *graph) get(steps []string) (charts.Charts, rsrc.Day) {
 	c.prune()
 
 	if n != nil {
+		lookups.Inc("hit")
 		return n.charts, n.registered
 	} else {
+		lookups.Inc("miss")
 		return nil, nil
 	}
 }
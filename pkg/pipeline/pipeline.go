@@ -1,12 +1,12 @@
 package pipeline
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
 
 	async "github.com/nilsbu/async"
-	"github.com/nilsbu/lastfm/config"
 	"github.com/nilsbu/lastfm/pkg/charts"
 	"github.com/nilsbu/lastfm/pkg/info"
 	"github.com/nilsbu/lastfm/pkg/io"
@@ -17,24 +17,26 @@ import (
 )
 
 type dynamic interface {
-	Exec() (interface{}, error)
+	Exec(ctx context.Context) (interface{}, error)
 }
 
 type once struct {
-	f      func() (interface{}, error)
+	f      func(ctx context.Context) (interface{}, error)
 	ran    bool
 	result interface{}
 	err    error
 }
 
-func newDynamic(f func() (interface{}, error)) dynamic {
+func newDynamic(f func(ctx context.Context) (interface{}, error)) dynamic {
 	return &once{f: f}
 }
 
-func (d *once) Exec() (interface{}, error) {
+// Exec runs f the first time it is called and returns the cached result
+// afterwards. A run whose context was cancelled is repeated on the next call.
+func (d *once) Exec(ctx context.Context) (interface{}, error) {
 	if !d.ran {
-		d.result, d.err = d.f()
-		d.ran = true
+		d.result, d.err = d.f(ctx)
+		d.ran = ctx.Err() == nil
 	}
 	return d.result, d.err
 }
@@ -42,15 +44,17 @@ func (d *once) Exec() (interface{}, error) {
 // TODO test Pipeline
 // TODO cleanup Pipeline
 type Pipeline interface {
-	Execute(steps []string) (charts.Charts, error)
+	Execute(ctx context.Context, steps []string) (charts.Charts, error)
 	Registered() rsrc.Day
 	Session() *unpack.SessionInfo
+	Plays(ctx context.Context) ([][]info.Song, error)
 }
 
 type pipeline struct {
 	graph     graph
 	bookmarks map[string][]string
 	vars      dynamic
+	tags      dynamic
 	session   *unpack.SessionInfo
 	store     io.Store
 }
@@ -70,14 +74,17 @@ func New(session *unpack.SessionInfo, s io.Store) Pipeline {
 		store:     s,
 	}
 
-	pl.vars = newDynamic(func() (interface{}, error) {
-		return pl.load()
+	pl.vars = newDynamic(func(ctx context.Context) (interface{}, error) {
+		return pl.load(ctx)
+	})
+	pl.tags = newDynamic(func(ctx context.Context) (interface{}, error) {
+		return organize.LoadTagConfig(io.WithContext(ctx, s))
 	})
 	return pl
 }
 
 func (w *pipeline) Registered() rsrc.Day {
-	v, err := w.vars.Exec()
+	v, err := w.vars.Exec(context.Background())
 	if err != nil {
 		return nil
 	} else {
@@ -89,23 +96,41 @@ func (w *pipeline) Session() *unpack.SessionInfo {
 	return w.session
 }
 
-func (w *pipeline) Execute(steps []string) (charts.Charts, error) {
+// Plays returns the user's plays per day since registration with corrected
+// artist names, without excluded plays and with manual changes. They are the
+// same plays the charts are built from.
+func (w *pipeline) Plays(ctx context.Context) ([][]info.Song, error) {
+	v, err := w.vars.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return v.(*vars).plays, nil
+}
+
+// Execute builds the charts described by steps. Loading the data and building
+// the steps stops once ctx is done. Since the resulting charts are cached and
+// shared between calls, ctx does not apply to their evaluation. Use the context
+// that is passed to Charts.Data() for that.
+func (w *pipeline) Execute(ctx context.Context, steps []string) (charts.Charts, error) {
 	if w.session.User == "" {
 		return nil, fmt.Errorf("no user name given, session might not be properly initialized")
 	}
 
-	// Ensure that gaussian exists, might be needed for year partition
-	w.bookmarks["gaussian"] = []string{steps[0], "gaussian", "cache"}
-	_, err := w.runSteps(w.bookmarks["gaussian"])
+	// Ensure that the smoothed charts exist, might be needed for year partition
+	smoothing, err := w.smoothing()
 	if err != nil {
 		return nil, err
 	}
+	w.bookmarks["smoothing"] = []string{steps[0], smoothing, "cache"}
+	if _, err := w.runSteps(ctx, w.bookmarks["smoothing"]); err != nil {
+		return nil, err
+	}
 
-	return w.runSteps(steps)
+	return w.runSteps(ctx, steps)
 }
 
-func (w *pipeline) runSteps(steps []string) (charts.Charts, error) {
-	if _, err := w.vars.Exec(); err != nil {
+func (w *pipeline) runSteps(ctx context.Context, steps []string) (charts.Charts, error) {
+	if _, err := w.vars.Exec(ctx); err != nil {
 		return nil, err
 	}
 
@@ -119,11 +144,11 @@ func (w *pipeline) runSteps(steps []string) (charts.Charts, error) {
 			registered = reg
 		} else {
 			if i == 0 {
-				parent, err = w.root(steps[0])
+				parent, err = w.root(ctx, steps[0])
 				registered = w.Registered()
 			} else {
 				var day rsrc.Day
-				parent, day, err = w.step(step, parent, registered)
+				parent, day, err = w.step(ctx, step, parent, registered)
 				if day != nil {
 					registered = day
 				}
@@ -138,27 +163,42 @@ func (w *pipeline) runSteps(steps []string) (charts.Charts, error) {
 	return parent, err
 }
 
-func (w *pipeline) load() (*vars, error) {
+func (w *pipeline) load(ctx context.Context) (*vars, error) {
 	v := &vars{}
+	s := io.WithContext(ctx, w.store)
+	var excluded map[unpack.ExcludedPlay]bool
+	var manual map[string]*unpack.ManualPlays
 
 	err := async.Pe([]func() error{
 		func() error {
 			var err error
-			v.user, err = unpack.LoadUserInfo(w.session.User, unpack.NewCacheless(w.store))
+			v.user, err = unpack.LoadUserInfo(w.session.User, unpack.NewCacheless(s))
 			return errors.Wrap(err, "failed to load user info")
 		},
 		func() error {
 			var err error
-			v.corrections, err = unpack.LoadArtistCorrections(w.session.User, w.store)
+			v.corrections, err = unpack.LoadArtistCorrections(w.session.User, s)
 			return err
 		},
 		func() error {
 			var err error
-			v.bookmark, err = unpack.LoadBookmark(w.session.User, w.store)
+			v.bookmark, err = unpack.LoadBookmark(w.session.User, s)
+			return err
+		},
+		func() error {
+			var err error
+			excluded, err = charts.LoadExcluded(w.session.User, s)
+			return err
+		},
+		func() error {
+			var err error
+			manual, err = charts.LoadManual(w.session.User, s)
 			return err
 		},
 	})
-	if err != nil {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -166,7 +206,9 @@ func (w *pipeline) load() (*vars, error) {
 	v.plays = make([][]info.Song, days)
 	err = async.Pie(days, func(i int) error {
 		day := v.user.Registered.AddDate(0, 0, i)
-		if songs, err := unpack.LoadDayHistory(v.user.Name, day, w.store); err == nil {
+		if songs, err := unpack.LoadDayHistory(v.user.Name, day, s); err == nil {
+			songs = charts.ExcludePlays(songs, excluded)
+			songs = charts.ApplyManualPlays(songs, manual[day.String()])
 			for j, song := range songs {
 				if c, ok := v.corrections[song.Artist]; ok {
 					songs[j].Artist = c
@@ -178,25 +220,66 @@ func (w *pipeline) load() (*vars, error) {
 			return err
 		}
 	})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
 	return v, err
 }
 
-func (w *pipeline) root(s string) (charts.Charts, error) {
+// root builds the charts from the plays that were loaded with ctx, so that
+// no loading happens lazily outside of Execute(). The root step is the kind
+// of charts optionally followed by options, e.g. "artists,mbid". The option
+// "mbid" groups plays by MusicBrainz ID where it is known. The options "feat"
+// and "featshare" split collaborations in artist charts into the credited
+// artists, which get the full play or an equal share of it respectively.
+func (w *pipeline) root(ctx context.Context, s string) (charts.Charts, error) {
+	vv, err := w.vars.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plays := vv.(*vars).plays
+
+	split := strings.Split(s, ",")
+	var credits *charts.Credits
+	for _, opt := range split[1:] {
+		switch opt {
+		case "mbid":
+			plays = charts.IdentifyByMBID(plays)
+		case "feat", "featshare":
+			if strings.HasPrefix(split[0], "songs") {
+				return nil, fmt.Errorf("option '%v' only applies to artist charts", opt)
+			}
+			exceptions, err := unpack.LoadCreditExceptions(
+				w.session.User, io.WithContext(ctx, w.store))
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to load credit exceptions")
+			}
+			c := charts.NewCredits(exceptions, opt == "featshare")
+			credits = &c
+		default:
+			return nil, fmt.Errorf("unknown option '%v' for '%v'", opt, split[0])
+		}
+	}
+
 	var c charts.Charts
-	switch s {
-	case "songsduration":
-		c = charts.LoadSongsDuration(w.session.User, w.store)
-	case "songs":
-		c = charts.LoadSongs(w.session.User, w.store)
-	case "artistsduration":
-		c = charts.LoadArtistsDuration(w.session.User, w.store)
+	switch {
+	case split[0] == "songsduration":
+		c = charts.SongsDuration(plays)
+	case split[0] == "songs":
+		c = charts.Songs(plays)
+	case split[0] == "artistsduration" && credits != nil:
+		c = charts.ArtistsDurationCredited(plays, *credits)
+	case split[0] == "artistsduration":
+		c = charts.ArtistsDuration(plays)
+	case credits != nil:
+		c = charts.ArtistsCredited(plays, *credits)
 	default:
-		c = charts.LoadArtists(w.session.User, w.store)
+		c = charts.Artists(plays)
 	}
 	return w.graph.set([]string{s}, c, w.Registered()), nil
 }
 
-func (w *pipeline) step(step string, parent charts.Charts, registered rsrc.Day) (charts.Charts, rsrc.Day, error) {
+func (w *pipeline) step(ctx context.Context, step string, parent charts.Charts, registered rsrc.Day) (charts.Charts, rsrc.Day, error) {
 	split := strings.Split(step, ",")
 	switch split[0] {
 	case "id":
@@ -214,8 +297,12 @@ func (w *pipeline) step(step string, parent charts.Charts, registered rsrc.Day)
 	case "normalize":
 		return charts.Normalize(parent), nil, nil
 
-	case "gaussian":
-		return charts.Gaussian(parent, 7, 2*7+1, true, false), nil, nil
+	case "gaussian", "triangular", "box", "ema":
+		kernel, err := parseKernel(step)
+		if err != nil {
+			return nil, nil, err
+		}
+		return kernel(parent), nil, nil
 	case "fade":
 		hl, _ := strconv.ParseFloat(split[1], 64)
 		return charts.Fade(parent, hl), nil, nil
@@ -225,8 +312,8 @@ func (w *pipeline) step(step string, parent charts.Charts, registered rsrc.Day)
 		return charts.Multiply(parent, s), nil, nil
 
 	case "group":
-		gaussian, _ := w.runSteps(w.bookmarks["gaussian"])
-		partition, err := w.getPartition(split[1], gaussian, parent)
+		smoothed, _ := w.runSteps(ctx, w.bookmarks["smoothing"])
+		partition, err := w.getPartition(ctx, split[1], smoothed, parent)
 		if err != nil {
 			return nil, nil, err
 		} else {
@@ -234,8 +321,8 @@ func (w *pipeline) step(step string, parent charts.Charts, registered rsrc.Day)
 		}
 
 	case "split":
-		gaussian, _ := w.runSteps(w.bookmarks["gaussian"])
-		partition, err := w.getPartition(split[1], gaussian, parent)
+		smoothed, _ := w.runSteps(ctx, w.bookmarks["smoothing"])
+		partition, err := w.getPartition(ctx, split[1], smoothed, parent)
 		if err != nil {
 			return nil, nil, err
 		} else {
@@ -287,16 +374,36 @@ func (w *pipeline) step(step string, parent charts.Charts, registered rsrc.Day)
 
 	case "top":
 		n, _ := strconv.Atoi(split[1])
-		titles, _ := charts.Top(parent, n)
+		titles, _ := charts.Top(ctx, parent, n)
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
 		return charts.Only(parent, titles), nil, nil
 
 	case "column":
 		i, _ := strconv.Atoi(split[1])
 		return charts.Column(parent, i), nil, nil
 
+	case "diversity":
+		n := 10
+		if len(split) > 1 {
+			var err error
+			if n, err = strconv.Atoi(split[1]); err != nil || n < 1 {
+				return nil, nil, fmt.Errorf("'%v' is no valid number of top titles", split[1])
+			}
+		}
+		return charts.Diversity(parent, n), nil, nil
+
+	case "loved":
+		titles, err := w.lovedTitles(ctx, parent)
+		if err != nil {
+			return nil, nil, err
+		}
+		return charts.Only(parent, titles), nil, nil
+
 	case "offset":
-		gaussian, _ := w.runSteps(w.bookmarks["gaussian"])
-		entries, err := charts.EntryDates(gaussian, parent)
+		smoothed, _ := w.runSteps(ctx, w.bookmarks["smoothing"])
+		entries, err := charts.EntryDates(ctx, smoothed, parent)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -307,6 +414,117 @@ func (w *pipeline) step(step string, parent charts.Charts, registered rsrc.Day)
 	}
 }
 
+// smoothing returns the step that smoothes the charts that year partitions and
+// offsets are based on. It is set by the session option "smoothing" and
+// defaults to "gaussian".
+func (w *pipeline) smoothing() (string, error) {
+	smoothing := w.session.Options["smoothing"]
+	if smoothing == "" {
+		return "gaussian", nil
+	}
+	if _, err := parseKernel(smoothing); err != nil {
+		return "", errors.Wrap(err, "invalid smoothing in session options")
+	}
+	return smoothing, nil
+}
+
+// CheckSmoothing returns an error if step is no valid smoothing step.
+func CheckSmoothing(step string) error {
+	_, err := parseKernel(step)
+	return err
+}
+
+// parseKernel parses a smoothing step. They are "gaussian[,sigma[,width]]",
+// "triangular[,width]", "box[,width]" and "ema[,span]", all of which default to
+// 7 days. The width of a Gaussian defaults to 2*sigma+1.
+func parseKernel(step string) (func(charts.Charts) charts.Charts, error) {
+	split := strings.Split(step, ",")
+	args := make([]float64, len(split)-1)
+	for i, arg := range split[1:] {
+		v, err := strconv.ParseFloat(arg, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("'%v' is no valid argument of '%v'", arg, split[0])
+		}
+		args[i] = v
+	}
+
+	maxArgs := 1
+	if split[0] == "gaussian" {
+		maxArgs = 2
+	}
+	if len(args) > maxArgs {
+		return nil, fmt.Errorf("'%v' takes at most %v arguments", split[0], maxArgs)
+	}
+	if len(args) == 0 {
+		args = append(args, 7)
+	}
+
+	switch split[0] {
+	case "gaussian":
+		sigma := args[0]
+		if sigma == 0 {
+			return nil, errors.New("sigma of 'gaussian' must be positive")
+		}
+		width := int(2*sigma) + 1
+		if len(args) > 1 {
+			width = int(args[1])
+		}
+		return func(parent charts.Charts) charts.Charts {
+			return charts.Gaussian(parent, sigma, width, true, false)
+		}, nil
+	case "triangular":
+		return func(parent charts.Charts) charts.Charts {
+			return charts.Triangular(parent, int(args[0]), true, false)
+		}, nil
+	case "box":
+		return func(parent charts.Charts) charts.Charts {
+			return charts.Box(parent, int(args[0]), true, false)
+		}, nil
+	case "ema":
+		if args[0] == 0 {
+			return nil, errors.New("span of 'ema' must be positive")
+		}
+		return func(parent charts.Charts) charts.Charts {
+			return charts.EMA(parent, args[0])
+		}, nil
+	default:
+		return nil, fmt.Errorf("'%v' is no smoothing kernel", split[0])
+	}
+}
+
+// lovedTitles returns the titles of parent that are loved songs or artists of
+// loved songs. They are matched like in organize.CountLovedPlays.
+func (w *pipeline) lovedTitles(ctx context.Context, parent charts.Charts) ([]charts.Title, error) {
+	vv, err := w.vars.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	corrections := vv.(*vars).corrections
+
+	loved, err := unpack.LoadLovedTracks(w.session.User, io.WithContext(ctx, w.store))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load loved tracks, run 'update loved' first")
+	}
+
+	keys := map[string]bool{}
+	for _, track := range loved {
+		artist := track.Artist
+		if c, ok := corrections[artist]; ok {
+			artist = c
+		}
+		keys[organize.LovedKey(charts.ArtistTitle(artist))] = true
+		keys[organize.LovedKey(charts.SongTitle(info.Song{Artist: artist, Title: track.Title}))] = true
+	}
+
+	titles := []charts.Title{}
+	for _, title := range parent.Titles() {
+		if keys[organize.LovedKey(title)] {
+			titles = append(titles, title)
+		}
+	}
+	return titles, nil
+}
+
 func partitionContains(partition charts.Partition, name string) bool {
 	found := false
 
@@ -320,49 +538,253 @@ func partitionContains(partition charts.Partition, name string) bool {
 	return found
 }
 
+// tagConfig returns the configuration of supertags, countries and blacklisted
+// tags. It is loaded once per pipeline, a new pipeline picks up changes.
+func (w *pipeline) tagConfig(ctx context.Context) (*organize.TagConfig, error) {
+	tc, err := w.tags.Exec(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load tag configuration")
+	}
+	return tc.(*organize.TagConfig), nil
+}
+
+// genrePartition partitions by the genres below a genre. The step is "genre"
+// for the top of the hierarchy or "genre:" followed by a path of genres
+// separated by ":", e.g. "genre:metal:black metal". Only the last genre of the
+// path is used.
+func (w *pipeline) genrePartition(ctx context.Context, step string, parent charts.Charts,
+) (charts.Partition, error) {
+	tc, err := w.tagConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := tc.GenreTree()
+	if err != nil {
+		return nil, err
+	}
+
+	path := strings.Split(step, ":")
+	root := path[len(path)-1]
+	if len(path) == 1 {
+		root = ""
+	} else if !tree.Contains(root) {
+		return nil, fmt.Errorf("'%v' is no genre", root)
+	}
+
+	s := io.WithContext(ctx, w.store)
+	corrections, _ := unpack.LoadSupertagCorrections(w.session.User, s)
+	return charts.GenrePartition(parent, tree, root, corrections, s), nil
+}
+
+// rulePartition partitions by the rules in the user's rules file. Tags and
+// countries are only loaded if a rule needs them.
+func (w *pipeline) rulePartition(ctx context.Context, parent charts.Charts,
+) (charts.Partition, error) {
+	s := io.WithContext(ctx, w.store)
+	rules, err := unpack.LoadRules(w.session.User, s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load rules for custom partition")
+	}
+	vv, err := w.vars.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	v := vv.(*vars)
+
+	titles := parent.Titles()
+	facts := playFacts(titles, v.plays, v.user.Registered)
+
+	needsTags := false
+	for _, rule := range rules {
+		needsTags = needsTags || len(rule.Tags) > 0 || rule.Country != ""
+	}
+	if needsTags {
+		tc, err := w.tagConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		artists := make([]string, len(titles))
+		for i, title := range titles {
+			artists[i] = title.Artist()
+		}
+		at, _ := organize.LoadArtistTags(artists, s)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		corrections, _ := unpack.LoadCountryCorrections(w.session.User, s)
+
+		for i, artist := range artists {
+			for _, tag := range at[artist] {
+				name := strings.ToLower(tag.Name)
+				facts[i].Tags = append(facts[i].Tags, name)
+				if country, ok := tc.Countries[name]; ok && facts[i].Country == "" {
+					facts[i].Country = country
+				}
+			}
+			if country, ok := corrections[artist]; ok {
+				facts[i].Country = country
+			}
+		}
+	}
+
+	return charts.RulePartition(titles, facts, rules)
+}
+
+// playFacts collects the songs, albums and year of the first listen of titles
+// from the plays. Both artist and song titles are recognized.
+func playFacts(titles []charts.Title, plays [][]info.Song, registered rsrc.Day,
+) []charts.TitleFacts {
+	first, _ := playStats(titles, plays, registered)
+
+	facts := make([]charts.TitleFacts, len(titles))
+	for i := range titles {
+		if first[i] != nil {
+			facts[i].FirstYear = first[i].Time().Year()
+		}
+	}
+
+	idxs := titleIdxs(titles)
+	seen := map[string]bool{}
+	for _, songs := range plays {
+		for _, song := range songs {
+			for _, key := range songKeys(song) {
+				i, ok := idxs[key]
+				if !ok {
+					continue
+				}
+				f := &facts[i]
+				if !seen[key+"\ns\n"+song.Title] {
+					seen[key+"\ns\n"+song.Title] = true
+					f.Songs = append(f.Songs, song.Title)
+				}
+				if song.Album != "" && !seen[key+"\na\n"+song.Album] {
+					seen[key+"\na\n"+song.Album] = true
+					f.Albums = append(f.Albums, song.Album)
+				}
+			}
+		}
+	}
+	return facts
+}
+
+// playStats returns the day of the first play and the number of plays of each
+// title. The day is nil for titles that were never played. Both artist and
+// song titles are recognized.
+func playStats(titles []charts.Title, plays [][]info.Song, registered rsrc.Day,
+) (first []rsrc.Day, counts []float64) {
+	idxs := titleIdxs(titles)
+	first = make([]rsrc.Day, len(titles))
+	counts = make([]float64, len(titles))
+	for day, songs := range plays {
+		for _, song := range songs {
+			for _, key := range songKeys(song) {
+				if i, ok := idxs[key]; ok {
+					if first[i] == nil {
+						first[i] = registered.AddDate(0, 0, day)
+					}
+					counts[i]++
+				}
+			}
+		}
+	}
+	return first, counts
+}
+
+func titleIdxs(titles []charts.Title) map[string]int {
+	idxs := map[string]int{}
+	for i, title := range titles {
+		idxs[title.Key()] = i
+	}
+	return idxs
+}
+
+func songKeys(song info.Song) []string {
+	return []string{
+		charts.ArtistTitle(song.Artist).Key(),
+		charts.SongTitle(song).Key(),
+	}
+}
+
 func (w *pipeline) getPartition(
+	ctx context.Context,
 	step string,
-	gaussian, parent charts.Charts,
+	smoothed, parent charts.Charts,
 ) (charts.Partition, error) {
+	if step == "genre" || strings.HasPrefix(step, "genre:") {
+		return w.genrePartition(ctx, step, parent)
+	}
+
 	switch step {
 	case "all":
 		return nil, nil
 	case "year":
-		vv, err := w.vars.Exec()
+		vv, err := w.vars.Exec(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		return charts.YearPartition(gaussian, parent, vv.(*vars).user.Registered)
+		return charts.YearPartition(ctx, smoothed, parent, vv.(*vars).user.Registered)
 	case "total":
 		return charts.TotalPartition(parent.Titles()), nil
 	case "super":
-		corrections, _ := unpack.LoadSupertagCorrections(w.session.User, w.store)
-		return charts.TagPartition(parent, config.Supertags, corrections, w.store), nil
+		tc, err := w.tagConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s := io.WithContext(ctx, w.store)
+		corrections, _ := unpack.LoadSupertagCorrections(w.session.User, s)
+		return charts.TagPartition(parent, tc.Supertags, corrections, s), nil
 	case "country":
-		corrections, _ := unpack.LoadCountryCorrections(w.session.User, w.store)
-		return charts.TagPartition(parent, config.Countries, corrections, w.store), nil
+		tc, err := w.tagConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s := io.WithContext(ctx, w.store)
+		corrections, _ := unpack.LoadCountryCorrections(w.session.User, s)
+		return charts.TagPartition(parent, tc.Countries, corrections, s), nil
 	case "tags":
+		tc, err := w.tagConfig(ctx)
+		if err != nil {
+			return nil, err
+		}
+
 		titles := parent.Titles()
 		artists := make([]string, len(titles))
 		for i := range titles {
 			artists[i] = titles[i].Artist()
 		}
 
-		at, _ := organize.LoadArtistTags(artists, w.store)
+		at, _ := organize.LoadArtistTags(artists, io.WithContext(ctx, w.store))
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		tags := make([][]info.Tag, len(titles))
 		for i, title := range titles {
 			tags[i] = at[title.String()]
 		}
 
-		return charts.TagWeightPartition(titles, tags, config.Blacklist()), nil
+		return charts.TagWeightPartition(titles, tags, tc.BlacklistSet()), nil
 	case "groups":
-		replacements, err := unpack.LoadGroups(w.session.User, w.store)
+		replacements, err := unpack.LoadGroups(w.session.User, io.WithContext(ctx, w.store))
 		if err != nil {
 			return nil, err
 		}
 
 		return charts.PartialReplacements(parent.Titles(), replacements), nil
+	case "custom":
+		return w.rulePartition(ctx, parent)
+	case "firstyear", "firstquarter", "tier":
+		vv, err := w.vars.Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		titles := parent.Titles()
+		first, counts := playStats(titles, vv.(*vars).plays, vv.(*vars).user.Registered)
+		if step == "tier" {
+			return charts.TierPartition(titles, counts), nil
+		}
+		return charts.FirstPlayPartition(titles, first, step == "firstquarter"), nil
 	default:
 		return nil, fmt.Errorf("chart type '%v' not supported", step)
 	}
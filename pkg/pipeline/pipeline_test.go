@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/charts"
+)
+
+func TestParseKernel(t *testing.T) {
+	root := charts.FromMap(map[string][]float64{
+		"A": {0, 0, 1, 0, 0},
+	})
+
+	for _, c := range []struct {
+		step   string
+		expect []float64
+		ok     bool
+	}{
+		{"box,1", []float64{0, 1.0 / 3, 1.0 / 3, 1.0 / 3, 0}, true},
+		{"triangular,1", []float64{0, .25, .5, .25, 0}, true},
+		{"ema,3", []float64{0, 0, .5, .25, .125}, true},
+		{"gaussian,1,0", []float64{0, 0, 0.3989422804014327, 0, 0}, true},
+		{"gaussian,1,2,3", nil, false},
+		{"box,1,1", nil, false},
+		{"box,x", nil, false},
+		{"ema,-1", nil, false},
+		{"ema,0", nil, false},
+		{"gaussian,0", nil, false},
+		{"median", nil, false},
+	} {
+		t.Run(c.step, func(t *testing.T) {
+			kernel, err := parseKernel(c.step)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if !c.ok {
+				return
+			}
+
+			data, err := kernel(root).Data(context.Background(), root.Titles(), 0, root.Len())
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			for i, v := range c.expect {
+				if d := data[0][i] - v; d > 1e-9 || d < -1e-9 {
+					t.Errorf("expected %v but got %v", c.expect, data[0])
+					break
+				}
+			}
+		})
+	}
+}
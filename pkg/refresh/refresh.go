@@ -1,16 +1,22 @@
 package refresh
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/nilsbu/lastfm/pkg/charts"
+	"github.com/nilsbu/lastfm/pkg/info"
 	"github.com/nilsbu/lastfm/pkg/io"
+	"github.com/nilsbu/lastfm/pkg/metrics"
 	"github.com/nilsbu/lastfm/pkg/pipeline"
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 	"github.com/nilsbu/lastfm/pkg/unpack"
 )
 
+var durations = metrics.Default.Histogram("lastfm_refresh_duration_seconds",
+	"Duration of the phases of the periodic refresh.", metrics.DefBuckets, "phase")
+
 type Trigger interface {
 	Refresh()
 }
@@ -32,10 +38,10 @@ func (t *refreshTrigger) Refresh() {
 	t.pipeline.pipeline = pipeline.New(t.pipeline.session, t.pipeline.store)
 }
 
-func (rp *refreshPipeline) Execute(steps []string) (charts.Charts, error) {
+func (rp *refreshPipeline) Execute(ctx context.Context, steps []string) (charts.Charts, error) {
 	rp.mtx.RLock()
 	defer rp.mtx.RUnlock()
-	return rp.pipeline.Execute(steps)
+	return rp.pipeline.Execute(ctx, steps)
 }
 
 func (rp *refreshPipeline) Registered() rsrc.Day {
@@ -50,6 +56,12 @@ func (rp *refreshPipeline) Session() *unpack.SessionInfo {
 	return rp.pipeline.Session()
 }
 
+func (rp *refreshPipeline) Plays(ctx context.Context) ([][]info.Song, error) {
+	rp.mtx.RLock()
+	defer rp.mtx.RUnlock()
+	return rp.pipeline.Plays(ctx)
+}
+
 func WrapRefresh(s io.Store, pl pipeline.Pipeline, session *unpack.SessionInfo) (pipeline.Pipeline, Trigger) {
 	rpl := &refreshPipeline{
 		store:    s,
@@ -77,12 +89,18 @@ func PeriodicRefresh(trigger Trigger, h, m, s int, pre, post func()) {
 		time.Sleep(duration)
 
 		// Call the pre function
-		pre()
+		timed("pre", pre)
 
 		// Call the Refresh method on the provided Trigger object
-		trigger.Refresh()
+		timed("refresh", trigger.Refresh)
 
 		// Call the post function
-		post()
+		timed("post", post)
 	}
 }
+
+func timed(phase string, f func()) {
+	start := time.Now()
+	f()
+	durations.Observe(time.Since(start).Seconds(), phase)
+}
@@ -6,7 +6,9 @@ import (
 )
 
 // Day represents a day from midnight to midnight at Greenwich.
-// Days before 1970-01-01 are considered undefined.
+// Days before 1970-01-01 are considered undefined. The days of a user's
+// prepared history may refer to the midnights of another time zone, see
+// ToLocalDay and LocalMidnight.
 //
 // Midnight returns the beginning of the day as a Unix time stamp.
 // Time converts the Day to a time.Time object.
@@ -29,6 +31,19 @@ func ToDay(timestamp int64) Day {
 	return date(time.Unix(midnight, 0).UTC())
 }
 
+// ToLocalDay converts a Unix timestamp into the Day on which it lies in the
+// time zone loc.
+func ToLocalDay(timestamp int64, loc *time.Location) Day {
+	return DayFromTime(time.Unix(timestamp, 0).In(loc))
+}
+
+// LocalMidnight returns the Unix timestamp at which a Day begins in the time
+// zone loc.
+func LocalMidnight(d Day, loc *time.Location) int64 {
+	t := d.Time()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Unix()
+}
+
 // ParseDay parses a date from a string in the format YYYY-MM-DD. It returns nil
 // if the string is not valid.
 func ParseDay(str string) Day {
@@ -28,6 +28,36 @@ func TestToDay(t *testing.T) {
 	}
 }
 
+func TestLocalDay(t *testing.T) {
+	east := time.FixedZone("UTC+2", 2*3600)
+	west := time.FixedZone("UTC-5", -5*3600)
+
+	cases := []struct {
+		timestamp int64
+		loc       *time.Location
+		day       string
+		midnight  int64
+	}{
+		{1491264000, time.UTC, "2017-04-04", 1491264000},
+		{1491264000 - 3600, east, "2017-04-04", 1491264000 - 7200},
+		{1491264000 - 3600, time.UTC, "2017-04-03", 1491264000 - 86400},
+		{1491264000 + 3600, west, "2017-04-03", 1491264000 - 86400 + 5*3600},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			day := rsrc.ToLocalDay(c.timestamp, c.loc)
+			if day.String() != c.day {
+				t.Errorf("got day '%v', expected '%v'", day, c.day)
+			}
+
+			if midnight := rsrc.LocalMidnight(day, c.loc); midnight != c.midnight {
+				t.Errorf("got midnight '%v', expected '%v'", midnight, c.midnight)
+			}
+		})
+	}
+}
+
 func TestParseDay(t *testing.T) {
 	cases := []struct {
 		str      string
@@ -1,10 +1,42 @@
 package rsrc
 
+import (
+	"context"
+	"errors"
+)
+
+// ErrOffline is returned by readers that would need network access when the
+// network must not be used.
+var ErrOffline = errors.New("resource is not available offline")
+
+// ErrNoURL is returned by locators of resources that only exist locally when a
+// URL is requested.
+var ErrNoURL = errors.New("cannot be used as a URL")
+
 // Reader is an interface for reading resources.
 type Reader interface {
 	Read(loc Locator) (data []byte, err error)
 }
 
+// ContextReader is a Reader whose reads can be cancelled. Once ctx is done,
+// ReadContext() returns an error as soon as possible.
+type ContextReader interface {
+	Reader
+	ReadContext(ctx context.Context, loc Locator) (data []byte, err error)
+}
+
+// ReadContext reads loc with r. If r is a ContextReader the read can be
+// cancelled through ctx, otherwise ctx is only checked before reading.
+func ReadContext(ctx context.Context, r Reader, loc Locator) ([]byte, error) {
+	if cr, ok := r.(ContextReader); ok {
+		return cr.ReadContext(ctx, loc)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.Read(loc)
+}
+
 // Writer is an interface for writing resources.
 type Writer interface {
 	Write(data []byte, loc Locator) error
@@ -6,19 +6,42 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
+// Root is the directory relative to which all resources are stored.
+const Root = ".lastfm"
+
 type Locator interface {
 	URL(apiKey string) (string, error)
 	Path() (string, error)
 }
 
+// Kind returns the kind of resource a locator refers to. For Last.fm resources
+// it is the name of the API method, e.g. "artist.getTopTags". Locators of
+// unknown type have the kind "".
+func Kind(loc Locator) string {
+	switch loc := loc.(type) {
+	case *lastFM:
+		return loc.method
+	case *util:
+		return loc.method
+	case *userData:
+		return loc.method
+	case *configFile:
+		return loc.name
+	default:
+		return ""
+	}
+}
+
 type lastFM struct {
 	method   string
 	nameType string
 	name     string
 	track    string
+	album    string
 	page     int
 	day      Day
 	limit    int
@@ -31,6 +54,7 @@ func UserInfo(user string) Locator {
 		nameType: "user",
 		name:     user,
 		track:    "",
+		album:    "",
 		page:     -1,
 		day:      nil,
 		limit:    -1,
@@ -43,12 +67,28 @@ func History(user string, page int, day Day) Locator {
 		nameType: "user",
 		name:     user,
 		track:    "",
+		album:    "",
 		page:     page,
 		day:      day,
 		limit:    200,
 	}
 }
 
+// LovedTracks returns a locator for a page of the Last.fm API call
+// "user.getLovedTracks".
+func LovedTracks(user string, page int) Locator {
+	return &lastFM{
+		method:   "user.getLovedTracks",
+		nameType: "user",
+		name:     user,
+		track:    "",
+		album:    "",
+		page:     page,
+		day:      nil,
+		limit:    200,
+	}
+}
+
 // ArtistInfo returns a locator for the Last.fm API call "artist.getInfo".
 func ArtistInfo(artist string) Locator {
 	return &lastFM{
@@ -56,6 +96,7 @@ func ArtistInfo(artist string) Locator {
 		nameType: "artist",
 		name:     artist,
 		track:    "",
+		album:    "",
 		page:     -1,
 		day:      nil,
 		limit:    -1,
@@ -69,6 +110,21 @@ func ArtistTags(artist string) Locator {
 		nameType: "artist",
 		name:     artist,
 		track:    "",
+		album:    "",
+		page:     -1,
+		day:      nil,
+		limit:    -1,
+	}
+}
+
+// ArtistSimilar returns a locator for the Last.fm API call "artist.getSimilar".
+func ArtistSimilar(artist string) Locator {
+	return &lastFM{
+		method:   "artist.getSimilar",
+		nameType: "artist",
+		name:     artist,
+		track:    "",
+		album:    "",
 		page:     -1,
 		day:      nil,
 		limit:    -1,
@@ -82,6 +138,7 @@ func TagInfo(tag string) Locator {
 		nameType: "tag",
 		name:     tag,
 		track:    "",
+		album:    "",
 		page:     -1,
 		day:      nil,
 		limit:    -1,
@@ -95,12 +152,108 @@ func TrackInfo(artist, track string) Locator {
 		nameType: "artist",
 		name:     artist,
 		track:    track,
+		album:    "",
 		page:     -1,
 		day:      nil,
 		limit:    -1,
 	}
 }
 
+// AlbumInfo returns a locator for the Last.fm API call "album.getInfo".
+func AlbumInfo(artist, album string) Locator {
+	return &lastFM{
+		method:   "album.getInfo",
+		nameType: "artist",
+		name:     artist,
+		track:    "",
+		album:    album,
+		page:     -1,
+		day:      nil,
+		limit:    -1,
+	}
+}
+
+// ParseLocator creates a Last.fm locator from the name of its API method and a
+// parameter string. The parameter is the name of the user, artist or tag. For
+// "user.getRecentTracks" it has the form "<user> <page> <YYYY-MM-DD>", for
+// "user.getLovedTracks" "<user> <page>", for
+// "track.getInfo" it is "<artist>^<track>" and for "album.getInfo"
+// "<artist>^<album>".
+func ParseLocator(method, param string) (Locator, error) {
+	switch method {
+	case "user.getInfo":
+		return UserInfo(param), nil
+	case "user.getRecentTracks":
+		strs := strings.Split(param, " ")
+		if len(strs) != 3 {
+			return nil, fmt.Errorf("'%v' is no valid history parameter", param)
+		}
+		page, err := strconv.Atoi(strs[1])
+		if err != nil {
+			return nil, err
+		}
+		day := ParseDay(strs[2])
+		if day == nil {
+			return nil, fmt.Errorf("'%v' is no valid day", strs[2])
+		}
+		return History(strs[0], page, day), nil
+	case "user.getLovedTracks":
+		strs := strings.Split(param, " ")
+		if len(strs) != 2 {
+			return nil, fmt.Errorf("'%v' is no valid loved tracks parameter", param)
+		}
+		page, err := strconv.Atoi(strs[1])
+		if err != nil {
+			return nil, err
+		}
+		return LovedTracks(strs[0], page), nil
+	case "artist.getInfo":
+		return ArtistInfo(param), nil
+	case "artist.getTopTags":
+		return ArtistTags(param), nil
+	case "artist.getSimilar":
+		return ArtistSimilar(param), nil
+	case "tag.getInfo":
+		return TagInfo(param), nil
+	case "track.getInfo":
+		strs := strings.SplitN(param, "^", 2)
+		if len(strs) != 2 {
+			return nil, fmt.Errorf("'%v' is no valid track parameter", param)
+		}
+		return TrackInfo(strs[0], strs[1]), nil
+	case "album.getInfo":
+		strs := strings.SplitN(param, "^", 2)
+		if len(strs) != 2 {
+			return nil, fmt.Errorf("'%v' is no valid album parameter", param)
+		}
+		return AlbumInfo(strs[0], strs[1]), nil
+	default:
+		return nil, fmt.Errorf("locator '%v' does not exist", method)
+	}
+}
+
+// DescribeLocator is the inverse of ParseLocator. It fails for locators that
+// don't refer to Last.fm.
+func DescribeLocator(loc Locator) (method, param string, err error) {
+	l, ok := loc.(*lastFM)
+	if !ok {
+		return "", "", errors.New("locator does not refer to Last.fm")
+	}
+
+	switch l.method {
+	case "user.getRecentTracks":
+		return l.method, fmt.Sprintf("%v %v %v", l.name, l.page, l.day), nil
+	case "user.getLovedTracks":
+		return l.method, fmt.Sprintf("%v %v", l.name, l.page), nil
+	case "track.getInfo":
+		return l.method, fmt.Sprintf("%v^%v", l.name, l.track), nil
+	case "album.getInfo":
+		return l.method, fmt.Sprintf("%v^%v", l.name, l.album), nil
+	default:
+		return l.method, l.name, nil
+	}
+}
+
 func (loc *lastFM) URL(apiKey string) (string, error) {
 	if err := CheckAPIKey(apiKey); err != nil {
 		return "", err
@@ -110,6 +263,7 @@ func (loc *lastFM) URL(apiKey string) (string, error) {
 
 	name := strings.Replace(url.PathEscape(loc.name), "&", "%26", -1)
 	track := strings.Replace(url.PathEscape(loc.track), "&", "%26", -1)
+	album := strings.Replace(url.PathEscape(loc.album), "&", "%26", -1)
 	url := base + fmt.Sprintf(params, apiKey,
 		loc.method, loc.nameType, name)
 
@@ -117,6 +271,10 @@ func (loc *lastFM) URL(apiKey string) (string, error) {
 		url += fmt.Sprintf("&track=%s", track)
 	}
 
+	if loc.album != "" {
+		url += fmt.Sprintf("&album=%s", album)
+	}
+
 	if loc.page > 0 {
 		url += fmt.Sprintf("&page=%d", loc.page)
 	}
@@ -164,18 +322,25 @@ func (loc *lastFM) Path() (string, error) {
 			t.Year(), int(t.Month()), t.Day(),
 			t.Hour(), t.Minute(), t.Second(),
 			loc.page)
+	case "user.getLovedTracks":
+		path = fmt.Sprintf("%v/%v", loc.name, loc.page)
 	case "track.getInfo":
 		key := fmt.Sprintf("%v\t%v", loc.name, loc.track)
 		h8 := sha256.Sum256([]byte(key))
 		hash := hex.EncodeToString(h8[:])
 		path = fmt.Sprintf("%v/%v/%v", hash[0:2], hash[2:4], hash[4:])
+	case "album.getInfo":
+		key := fmt.Sprintf("%v\t%v", loc.name, loc.album)
+		h8 := sha256.Sum256([]byte(key))
+		hash := hex.EncodeToString(h8[:])
+		path = fmt.Sprintf("%v/%v/%v", hash[0:2], hash[2:4], hash[4:])
 	default:
 		h8 := sha256.Sum256([]byte(loc.name))
 		hash := hex.EncodeToString(h8[:])
 		path = fmt.Sprintf("%v/%v/%v", hash[0:2], hash[2:4], hash[4:])
 	}
 
-	return fmt.Sprintf(".lastfm/raw/%v/%v.json", loc.method, path), nil
+	return fmt.Sprintf("%v/raw/%v/%v.json", Root, loc.method, path), nil
 }
 
 // TODO docu
@@ -198,12 +363,59 @@ func SessionInfo() Locator {
 	}
 }
 
+// MissingResources returns a locator for the list of resources that could not
+// be loaded while working offline.
+func MissingResources() Locator {
+	return &util{
+		method: "missing",
+		public: false,
+	}
+}
+
 func (u util) URL(apiKey string) (string, error) {
-	return "", fmt.Errorf("'%v' cannot be used as a URL", u.method)
+	return "", fmt.Errorf("'%v' %w", u.method, ErrNoURL)
 }
 
 func (u util) Path() (string, error) {
-	return fmt.Sprintf(".lastfm/util/%v.json", u.method), nil
+	return fmt.Sprintf("%v/util/%v.json", Root, u.method), nil
+}
+
+// configFile is a configuration file in the data root that is shared by all
+// users and meant to be edited by hand.
+type configFile struct {
+	name string
+}
+
+// Supertags returns a locator for the configuration that maps tags to the
+// supertags they belong to.
+func Supertags() Locator {
+	return &configFile{name: "supertags"}
+}
+
+// Countries returns a locator for the configuration that maps nationalities
+// to country names.
+func Countries() Locator {
+	return &configFile{name: "countries"}
+}
+
+// Genres returns a locator for the configuration that maps genres to their
+// parent genres.
+func Genres() Locator {
+	return &configFile{name: "genres"}
+}
+
+// Blacklist returns a locator for the configuration of tags that are ignored
+// when artists are partitioned by their tags.
+func Blacklist() Locator {
+	return &configFile{name: "blacklist"}
+}
+
+func (c configFile) URL(apiKey string) (string, error) {
+	return "", fmt.Errorf("'%v' %w", c.name, ErrNoURL)
+}
+
+func (c configFile) Path() (string, error) {
+	return fmt.Sprintf("%v/config/%v.json", Root, c.name), nil
 }
 
 type userData struct {
@@ -248,6 +460,14 @@ func DayHistory(user string, day Day) Locator {
 	}
 }
 
+// Loved returns a locator for the loved tracks of a user.
+func Loved(user string) Locator {
+	return &userData{
+		method: "loved",
+		name:   user,
+	}
+}
+
 func ArtistCorrections(user string) Locator {
 	return &userData{
 		method: "artistcorrections",
@@ -255,6 +475,41 @@ func ArtistCorrections(user string) Locator {
 	}
 }
 
+// CreditExceptions returns a locator for the artist names that are never split
+// into several credited artists, e.g. bands whose names contain "&".
+func CreditExceptions(user string) Locator {
+	return &userData{
+		method: "creditexceptions",
+		name:   user,
+	}
+}
+
+// ExcludedPlays returns a locator for the plays of a user that are left out of
+// the charts without modifying the history.
+func ExcludedPlays(user string) Locator {
+	return &userData{
+		method: "excluded",
+		name:   user,
+	}
+}
+
+// ManualPlays returns a locator for the plays of a user that were added,
+// removed or edited by hand.
+func ManualPlays(user string) Locator {
+	return &userData{
+		method: "manual",
+		name:   user,
+	}
+}
+
+// Settings returns a locator for the settings of a user, e.g. the time zone.
+func Settings(user string) Locator {
+	return &userData{
+		method: "settings",
+		name:   user,
+	}
+}
+
 func SupertagCorrections(user string) Locator {
 	return &userData{
 		method: "supertagcorrections",
@@ -276,13 +531,46 @@ func Groups(user string) Locator {
 	}
 }
 
+// Correlations returns a locator for the cached correlations between a user's
+// top artists.
+func Correlations(user string) Locator {
+	return &userData{
+		method: "correlations",
+		name:   user,
+	}
+}
+
+// Rules returns a locator for the rules of a user's custom partitions.
+func Rules(user string) Locator {
+	return &userData{
+		method: "rules",
+		name:   user,
+	}
+}
+
 func (u userData) URL(apiKey string) (string, error) {
-	return "", fmt.Errorf("'%v' cannot be used as a URL", u.method)
+	return "", fmt.Errorf("'%v' %w", u.method, ErrNoURL)
 }
 
 func (u userData) Path() (string, error) {
 	if u.method == "days" {
-		return fmt.Sprintf(".lastfm/user/%v/history/%v.json", u.name, u.day), nil
+		return fmt.Sprintf("%v/user/%v/history/%v.json", Root, u.name, u.day), nil
 	}
-	return fmt.Sprintf(".lastfm/user/%v/%v.json", u.name, u.method), nil
+	return fmt.Sprintf("%v/user/%v/%v.json", Root, u.name, u.method), nil
+}
+
+type file string
+
+// File returns a locator for a stored file that is only known by its path, e.g.
+// when iterating over the data root. It cannot be used as a URL.
+func File(path string) Locator {
+	return file(path)
+}
+
+func (f file) URL(apiKey string) (string, error) {
+	return "", fmt.Errorf("file '%v' %w", string(f), ErrNoURL)
+}
+
+func (f file) Path() (string, error) {
+	return string(f), nil
 }
@@ -25,6 +25,11 @@ func TestLastFMURL(t *testing.T) {
 			base + "api_key=a3ee123098128acf29ca9f0cf29ca9f0&method=artist.getTopTags&artist=dido",
 			true,
 		},
+		{ // ok
+			ArtistSimilar("dido"), "a3ee123098128acf29ca9f0cf29ca9f0",
+			base + "api_key=a3ee123098128acf29ca9f0cf29ca9f0&method=artist.getSimilar&artist=dido",
+			true,
+		},
 		{ // ok
 			TagInfo("blub"), "a3ee123098128acf29ca9f0cf29ca9f0",
 			base + "api_key=a3ee123098128acf29ca9f0cf29ca9f0&method=tag.getInfo&tag=blub",
@@ -60,6 +65,16 @@ func TestLastFMURL(t *testing.T) {
 			base + "api_key=a3ee123098128acf29ca9f0cf29ca9f0&method=track.getInfo&artist=A&track=B%C3%B6%20A",
 			true,
 		},
+		{ // ok
+			LovedTracks("abc", 2), "a3ee123098128acf29ca9f0cf29ca9f0",
+			base + "api_key=a3ee123098128acf29ca9f0cf29ca9f0&method=user.getLovedTracks&user=abc&page=2&limit=200",
+			true,
+		},
+		{ // ok
+			AlbumInfo("A", "X & Y"), "a3ee123098128acf29ca9f0cf29ca9f0",
+			base + "api_key=a3ee123098128acf29ca9f0cf29ca9f0&method=album.getInfo&artist=A&album=X%20%26%20Y",
+			true,
+		},
 	}
 
 	for _, c := range cases {
@@ -112,6 +127,14 @@ func TestLastFMPath(t *testing.T) {
 			TrackInfo("A", "C"),
 			".lastfm/raw/track.getInfo/23/06/1f13a4dcc11f9df1a088748772f1a2136994d3aced93b64ff99f19c53e38.json",
 		},
+		{
+			LovedTracks("abc", 3),
+			".lastfm/raw/user.getLovedTracks/abc/3.json",
+		},
+		{
+			AlbumInfo("A", "X"),
+			".lastfm/raw/album.getInfo/66/7a/21f65776e722d3eebeb887127e082a856c166b2c0267c87128fe92a02741.json",
+		},
 	}
 
 	for _, c := range cases {
@@ -137,6 +160,7 @@ func TestUtilURL(t *testing.T) {
 	}{
 		{APIKey()},
 		{SessionInfo()},
+		{Supertags()},
 	}
 
 	for _, c := range cases {
@@ -156,6 +180,10 @@ func TestUtilPath(t *testing.T) {
 	}{
 		{APIKey(), ".lastfm/util/apikey.json"},
 		{SessionInfo(), ".lastfm/util/session.json"},
+		{Supertags(), ".lastfm/config/supertags.json"},
+		{Countries(), ".lastfm/config/countries.json"},
+		{Genres(), ".lastfm/config/genres.json"},
+		{Blacklist(), ".lastfm/config/blacklist.json"},
 	}
 
 	for _, c := range cases {
@@ -206,6 +234,12 @@ func TestUserDataPath(t *testing.T) {
 		{SupertagCorrections("user1"), ".lastfm/user/user1/supertagcorrections.json"},
 		{CountryCorrections("user1"), ".lastfm/user/user1/countrycorrections.json"},
 		{Groups("user1"), ".lastfm/user/user1/groups.json"},
+		{CreditExceptions("user1"), ".lastfm/user/user1/creditexceptions.json"},
+		{ExcludedPlays("user1"), ".lastfm/user/user1/excluded.json"},
+		{ManualPlays("user1"), ".lastfm/user/user1/manual.json"},
+		{Settings("user1"), ".lastfm/user/user1/settings.json"},
+		{Rules("user1"), ".lastfm/user/user1/rules.json"},
+		{Correlations("user1"), ".lastfm/user/user1/correlations.json"},
 	}
 
 	for _, c := range cases {
@@ -223,3 +257,84 @@ func TestUserDataPath(t *testing.T) {
 		})
 	}
 }
+
+func TestKind(t *testing.T) {
+	cases := []struct {
+		loc  Locator
+		kind string
+	}{
+		{ArtistTags("X"), "artist.getTopTags"},
+		{TrackInfo("X", "x"), "track.getInfo"},
+		{SessionInfo(), "session"},
+		{Bookmark("user"), "bookmark"},
+		{Blacklist(), "blacklist"},
+		{File("a/b.json"), ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.kind, func(t *testing.T) {
+			if kind := Kind(c.loc); kind != c.kind {
+				t.Errorf("got kind '%v', expected '%v'", kind, c.kind)
+			}
+		})
+	}
+}
+
+func TestParseLocator(t *testing.T) {
+	cases := []struct {
+		method string
+		param  string
+		loc    Locator
+		ok     bool
+	}{
+		{"user.getInfo", "U", UserInfo("U"), true},
+		{"user.getRecentTracks", "U 2 2018-01-10", History("U", 2, ParseDay("2018-01-10")), true},
+		{"user.getRecentTracks", "U 2", nil, false},
+		{"user.getRecentTracks", "U x 2018-01-10", nil, false},
+		{"user.getRecentTracks", "U 2 yesterday", nil, false},
+		{"user.getLovedTracks", "U 3", LovedTracks("U", 3), true},
+		{"user.getLovedTracks", "U", nil, false},
+		{"artist.getTopTags", "A B", ArtistTags("A B"), true},
+		{"artist.getSimilar", "A", ArtistSimilar("A"), true},
+		{"track.getInfo", "A^T^x", TrackInfo("A", "T^x"), true},
+		{"track.getInfo", "A", nil, false},
+		{"album.getInfo", "A^B", AlbumInfo("A", "B"), true},
+		{"album.getInfo", "A", nil, false},
+		{"unknown", "A", nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.method+" "+c.param, func(t *testing.T) {
+			loc, err := ParseLocator(c.method, c.param)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if !c.ok {
+				return
+			}
+
+			path, _ := loc.Path()
+			expected, _ := c.loc.Path()
+			if path != expected {
+				t.Errorf("wrong path: has '%v', expected '%v'", path, expected)
+			}
+
+			method, param, err := DescribeLocator(loc)
+			if err != nil {
+				t.Fatal("unexpected error in DescribeLocator:", err)
+			}
+			if method != c.method || param != c.param {
+				t.Errorf("described as '%v' '%v', expected '%v' '%v'",
+					method, param, c.method, c.param)
+			}
+		})
+	}
+}
+
+func TestDescribeLocatorFails(t *testing.T) {
+	if _, _, err := DescribeLocator(Bookmark("U")); err == nil {
+		t.Error("expected error but none occurred")
+	}
+}
@@ -0,0 +1,141 @@
+package unpack
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// CheckFile validates stored data against the schema it is unpacked with. The
+// kind of resource is derived from the path, which has to be of the form that
+// rsrc.Locator.Path() returns. An error is returned if the data cannot be
+// unpacked or if the path does not belong to a known resource.
+func CheckFile(path string, data []byte) error {
+	ds, err := checkers(path)
+	if err != nil {
+		return err
+	}
+
+	// the data is valid if any of the alternative schemas accepts it
+	for _, d := range ds {
+		if _, err = deserialize(d, data); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func checkers(path string) ([]deserializer, error) {
+	parts := strings.Split(strings.TrimPrefix(path, rsrc.Root+"/"), "/")
+	if len(parts) < 2 || !strings.HasSuffix(path, ".json") {
+		return nil, fmt.Errorf("'%v' is no known resource", path)
+	}
+	name := strings.TrimSuffix(parts[len(parts)-1], ".json")
+
+	switch {
+	case parts[0] == "raw":
+		switch parts[1] {
+		case "user.getInfo":
+			return []deserializer{&obUserInfo{}}, nil
+		case "user.getRecentTracks":
+			return []deserializer{&obHistory{}, &obHistorySingle{}}, nil
+		case "user.getLovedTracks":
+			return []deserializer{&obLovedTracks{}}, nil
+		case "artist.getInfo":
+			return []deserializer{&obArtistInfo{}}, nil
+		case "artist.getTopTags":
+			return []deserializer{&obArtistTags{}}, nil
+		case "artist.getSimilar":
+			return []deserializer{&obSimilarArtists{}}, nil
+		case "tag.getInfo":
+			return []deserializer{&obTagInfo{}}, nil
+		case "track.getInfo":
+			return []deserializer{&obTrackInfo{}}, nil
+		case "album.getInfo":
+			return []deserializer{&obAlbumInfo{}}, nil
+		}
+	case parts[0] == "util" && len(parts) == 2:
+		switch name {
+		case "apikey":
+			return []deserializer{obAPIKey{}}, nil
+		case "session":
+			return []deserializer{obSessionInfo{}}, nil
+		case "missing":
+			return []deserializer{obMissingResources{}}, nil
+		}
+	case parts[0] == "config" && len(parts) == 2:
+		switch name {
+		case "supertags", "countries", "genres":
+			return []deserializer{obTagMap{}}, nil
+		case "blacklist":
+			return []deserializer{obBlacklist{}}, nil
+		}
+	case parts[0] == "user" && len(parts) == 4 && parts[2] == "history":
+		return []deserializer{obCheckedDayHistory{}}, nil
+	case parts[0] == "user" && len(parts) == 3:
+		switch name {
+		case "bookmark", "bookmark2":
+			return []deserializer{obCheckedBookmark{}}, nil
+		case "alldayplays":
+			return []deserializer{obAllDayPlays{}}, nil
+		case "history":
+			return []deserializer{obSongHistory{}}, nil
+		case "loved":
+			return []deserializer{obLoved{}}, nil
+		case "artistcorrections", "supertagcorrections", "countrycorrections", "groups":
+			return []deserializer{obCorrections{}}, nil
+		case "creditexceptions":
+			return []deserializer{obCreditExceptions{}}, nil
+		case "excluded":
+			return []deserializer{obExcludedPlays{}}, nil
+		case "manual":
+			return []deserializer{obManualPlays{}}, nil
+		case "settings":
+			return []deserializer{obSettings{}}, nil
+		case "rules":
+			return []deserializer{obRules{}}, nil
+		case "correlations":
+			return []deserializer{obCorrelations{}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("'%v' is no known resource", path)
+}
+
+type obCheckedDayHistory struct {
+	obDayHistory
+}
+
+func (o obCheckedDayHistory) interpret(raw interface{}) (interface{}, error) {
+	songs := *raw.(*[][]string)
+	for i, song := range songs {
+		if len(song) != 4 && len(song) != 7 && len(song) != 8 {
+			return nil, fmt.Errorf("play %v has %v fields, expected 4, 7 or 8", i, len(song))
+		}
+		if _, err := strconv.ParseFloat(song[3], 64); err != nil {
+			return nil, errors.Wrapf(err, "play %v has no valid duration", i)
+		}
+		if len(song) == 8 {
+			if _, err := strconv.ParseInt(song[7], 10, 64); err != nil {
+				return nil, errors.Wrapf(err, "play %v has no valid time", i)
+			}
+		}
+	}
+	return songs, nil
+}
+
+type obCheckedBookmark struct {
+	obBookmark
+}
+
+func (o obCheckedBookmark) interpret(raw interface{}) (interface{}, error) {
+	bookmark := raw.(*jsonBookmark)
+	if day := rsrc.ParseDay(bookmark.NextDay); day != nil {
+		return day, nil
+	}
+	return nil, fmt.Errorf("'%v' is no valid day", bookmark.NextDay)
+}
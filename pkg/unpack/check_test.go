@@ -0,0 +1,184 @@
+package unpack_test
+
+import (
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+)
+
+func TestCheckFile(t *testing.T) {
+	path := func(loc rsrc.Locator) string {
+		p, _ := loc.Path()
+		return p
+	}
+
+	cases := []struct {
+		name string
+		path string
+		data string
+		ok   bool
+	}{
+		{
+			"valid day history",
+			path(rsrc.DayHistory("user", rsrc.ParseDay("2019-01-01"))),
+			`[["A","a","x","3.000000"]]`,
+			true,
+		},
+		{
+			"day history with MBIDs",
+			path(rsrc.DayHistory("user", rsrc.ParseDay("2019-01-01"))),
+			`[["A","a","x","3.000000"],["A","b","x","3.000000","a1","","x1"]]`,
+			true,
+		},
+		{
+			"day history with times",
+			path(rsrc.DayHistory("user", rsrc.ParseDay("2019-01-01"))),
+			`[["A","a","x","3.000000","","","","1546300800"]]`,
+			true,
+		},
+		{
+			"day history with invalid time",
+			path(rsrc.DayHistory("user", rsrc.ParseDay("2019-01-01"))),
+			`[["A","a","x","3.000000","","","","noon"]]`,
+			false,
+		},
+		{
+			"truncated day history",
+			path(rsrc.DayHistory("user", rsrc.ParseDay("2019-01-01"))),
+			`[["A","a","x","3.00`,
+			false,
+		},
+		{
+			"day history without duration",
+			path(rsrc.DayHistory("user", rsrc.ParseDay("2019-01-01"))),
+			`[["A","a","x"]]`,
+			false,
+		},
+		{
+			"valid song history",
+			path(rsrc.SongHistory("user")),
+			`[[{"Artist":"A","Title":"a","Duration":3}],[]]`,
+			true,
+		},
+		{
+			"truncated song history",
+			path(rsrc.SongHistory("user")),
+			`[[{"Artist":"A"`,
+			false,
+		},
+		{
+			"valid bookmark",
+			path(rsrc.Bookmark("user")),
+			`{"nextday":"2019-01-01"}`,
+			true,
+		},
+		{
+			"invalid bookmark",
+			path(rsrc.BackupBookmark("user")),
+			`{"nextday":"2019-13-01"}`,
+			false,
+		},
+		{
+			"history page",
+			path(rsrc.History("user", 1, rsrc.ParseDay("2019-01-01"))),
+			`{"recenttracks":{"track":[{"artist":{"#text":"A"}}],"@attr":{"totalPages":"1"}}}`,
+			true,
+		},
+		{
+			"history page with single track",
+			path(rsrc.History("user", 1, rsrc.ParseDay("2019-01-01"))),
+			`{"recenttracks":{"track":{"artist":{"#text":"A"}},"@attr":{"totalPages":"1"}}}`,
+			true,
+		},
+		{
+			"broken track info",
+			path(rsrc.TrackInfo("A", "a")),
+			`{"track":{"duration":12}}`,
+			false,
+		},
+		{
+			"corrections",
+			path(rsrc.ArtistCorrections("user")),
+			`{"corrections":{"a":"A"}}`,
+			true,
+		},
+		{
+			"excluded plays",
+			path(rsrc.ExcludedPlays("user")),
+			`{"plays":[{"artist":"A","title":"a","time":86400}]}`,
+			true,
+		},
+		{
+			"manual plays",
+			path(rsrc.ManualPlays("user")),
+			`{"added":[{"day":"2018-01-01","artist":"A","title":"a"}],"removed":[],"edited":[]}`,
+			true,
+		},
+		{
+			"manual plays with invalid day",
+			path(rsrc.ManualPlays("user")),
+			`{"removed":[{"day":"yesterday","artist":"A","title":"a"}]}`,
+			false,
+		},
+		{
+			"supertags",
+			path(rsrc.Supertags()),
+			`{"tags":{"metal":"metal"}}`,
+			true,
+		},
+		{
+			"invalid supertags",
+			path(rsrc.Supertags()),
+			`{"tags":{" metal":"metal"}}`,
+			false,
+		},
+		{
+			"blacklist",
+			path(rsrc.Blacklist()),
+			`{"tags":["seen live"]}`,
+			true,
+		},
+		{
+			"settings",
+			path(rsrc.Settings("user")),
+			`{"timezone":"Europe/Berlin"}`,
+			true,
+		},
+		{
+			"rules",
+			path(rsrc.Rules("user")),
+			`{"rules":[{"name":"90s metal","tags":["metal"],"from":1990,"to":1999}]}`,
+			true,
+		},
+		{
+			"invalid rule",
+			path(rsrc.Rules("user")),
+			`{"rules":[{"name":"broken","artist":"("}]}`,
+			false,
+		},
+		{
+			"credit exceptions",
+			path(rsrc.CreditExceptions("user")),
+			`{"exceptions":["A & B"]}`,
+			true,
+		},
+		{
+			"unknown file",
+			rsrc.Root + "/user/user/bookmark.json.tmp123",
+			`{"nextday":"2019-01-01"}`,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := unpack.CheckFile(c.path, []byte(c.data))
+			if err != nil && c.ok {
+				t.Error("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Error("expected error but none occurred")
+			}
+		})
+	}
+}
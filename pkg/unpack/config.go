@@ -0,0 +1,145 @@
+package unpack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+type obTagMap struct {
+	loc rsrc.Locator
+}
+
+// LoadSupertags loads the configuration that maps tags to supertags. The keys
+// are lower case tags.
+func LoadSupertags(r rsrc.Reader) (map[string]string, error) {
+	return loadTagMap(rsrc.Supertags(), r)
+}
+
+// WriteSupertags writes the configuration that maps tags to supertags.
+func WriteSupertags(supertags map[string]string, w rsrc.Writer) error {
+	return deposit(supertags, obTagMap{rsrc.Supertags()}, w)
+}
+
+// LoadCountries loads the configuration that maps nationalities to country
+// names. The keys are lower case tags.
+func LoadCountries(r rsrc.Reader) (map[string]string, error) {
+	return loadTagMap(rsrc.Countries(), r)
+}
+
+// WriteCountries writes the configuration that maps nationalities to country
+// names.
+func WriteCountries(countries map[string]string, w rsrc.Writer) error {
+	return deposit(countries, obTagMap{rsrc.Countries()}, w)
+}
+
+// LoadGenres loads the configuration that maps genres to their parent genres.
+// The keys are lower case tags.
+func LoadGenres(r rsrc.Reader) (map[string]string, error) {
+	return loadTagMap(rsrc.Genres(), r)
+}
+
+// WriteGenres writes the configuration that maps genres to their parent
+// genres.
+func WriteGenres(genres map[string]string, w rsrc.Writer) error {
+	return deposit(genres, obTagMap{rsrc.Genres()}, w)
+}
+
+func loadTagMap(loc rsrc.Locator, r rsrc.Reader) (map[string]string, error) {
+	data, err := obtain(obTagMap{loc}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.(map[string]string), nil
+}
+
+func (o obTagMap) locator() rsrc.Locator {
+	return o.loc
+}
+
+func (o obTagMap) deserializer() interface{} {
+	return &jsonTagMap{}
+}
+
+func (o obTagMap) interpret(raw interface{}) (interface{}, error) {
+	tags := raw.(*jsonTagMap).Tags
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	lower := make(map[string]string, len(tags))
+	for tag, value := range tags {
+		if err := checkTag(tag); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(value) == "" {
+			return nil, fmt.Errorf("tag '%v' has no value", tag)
+		}
+
+		key := strings.ToLower(tag)
+		if prev, ok := lower[key]; ok && prev != value {
+			return nil, fmt.Errorf("tag '%v' has different values", key)
+		}
+		lower[key] = value
+	}
+	return lower, nil
+}
+
+func (o obTagMap) raw(obj interface{}) interface{} {
+	return jsonTagMap{Tags: obj.(map[string]string)}
+}
+
+type obBlacklist struct{}
+
+// LoadBlacklist loads the lower case tags that are ignored when artists are
+// partitioned by their tags.
+func LoadBlacklist(r rsrc.Reader) ([]string, error) {
+	data, err := obtain(obBlacklist{}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]string), nil
+}
+
+// WriteBlacklist writes the tags that are ignored when artists are partitioned
+// by their tags.
+func WriteBlacklist(tags []string, w rsrc.Writer) error {
+	return deposit(tags, obBlacklist{}, w)
+}
+
+func (o obBlacklist) locator() rsrc.Locator {
+	return rsrc.Blacklist()
+}
+
+func (o obBlacklist) deserializer() interface{} {
+	return &jsonTagList{}
+}
+
+func (o obBlacklist) interpret(raw interface{}) (interface{}, error) {
+	tags := raw.(*jsonTagList).Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	for i, tag := range tags {
+		if err := checkTag(tag); err != nil {
+			return nil, err
+		}
+		tags[i] = strings.ToLower(tag)
+	}
+	return tags, nil
+}
+
+func (o obBlacklist) raw(obj interface{}) interface{} {
+	return jsonTagList{Tags: obj.([]string)}
+}
+
+// checkTag ensures that a tag can be matched. Tags from Last.fm are compared in
+// lower case, so tags are converted to lower case when they are loaded.
+func checkTag(tag string) error {
+	if strings.TrimSpace(tag) == "" {
+		return fmt.Errorf("empty tag")
+	} else if tag != strings.TrimSpace(tag) {
+		return fmt.Errorf("tag '%v' has leading or trailing spaces", tag)
+	}
+	return nil
+}
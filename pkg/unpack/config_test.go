@@ -0,0 +1,117 @@
+package unpack_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestLoadSupertags(t *testing.T) {
+	cases := []struct {
+		name      string
+		json      []byte
+		supertags map[string]string
+		ok        bool
+	}{
+		{
+			"missing",
+			nil, nil, false,
+		},
+		{
+			"valid",
+			[]byte(`{"tags":{"metal":"metal","death metal":"metal"}}`),
+			map[string]string{"metal": "metal", "death metal": "metal"},
+			true,
+		},
+		{
+			"empty",
+			[]byte(`{}`),
+			map[string]string{},
+			true,
+		},
+		{
+			"upper case",
+			[]byte(`{"tags":{"Metal":"metal","metal":"metal"}}`),
+			map[string]string{"metal": "metal"},
+			true,
+		},
+		{
+			"upper case with different value",
+			[]byte(`{"tags":{"Metal":"rock","metal":"metal"}}`),
+			nil, false,
+		},
+		{
+			"spaces",
+			[]byte(`{"tags":{" metal":"metal"}}`),
+			nil, false,
+		},
+		{
+			"no value",
+			[]byte(`{"tags":{"metal":" "}}`),
+			nil, false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			io, err := mock.IO(map[rsrc.Locator][]byte{rsrc.Supertags(): c.json}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			supertags, err := unpack.LoadSupertags(io)
+			if err != nil && c.ok {
+				t.Error("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Error("expected error but none occurred")
+			}
+			if err == nil && !reflect.DeepEqual(supertags, c.supertags) {
+				t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", supertags, c.supertags)
+			}
+		})
+	}
+}
+
+func TestTagConfigs(t *testing.T) {
+	countries := map[string]string{"german": "Germany"}
+	genres := map[string]string{"black metal": "metal"}
+	blacklist := []string{"seen live", "80s"}
+
+	io, err := mock.IO(map[rsrc.Locator][]byte{
+		rsrc.Countries(): nil,
+		rsrc.Genres():    nil,
+		rsrc.Blacklist(): nil,
+	}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if err := unpack.WriteCountries(countries, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+	if err := unpack.WriteGenres(genres, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+	if err := unpack.WriteBlacklist(blacklist, io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	if loaded, err := unpack.LoadCountries(io); err != nil {
+		t.Error("unexpected error:", err)
+	} else if !reflect.DeepEqual(loaded, countries) {
+		t.Errorf("wrong countries\nhas:  '%v'\nwant: '%v'", loaded, countries)
+	}
+	if loaded, err := unpack.LoadGenres(io); err != nil {
+		t.Error("unexpected error:", err)
+	} else if !reflect.DeepEqual(loaded, genres) {
+		t.Errorf("wrong genres\nhas:  '%v'\nwant: '%v'", loaded, genres)
+	}
+	if loaded, err := unpack.LoadBlacklist(io); err != nil {
+		t.Error("unexpected error:", err)
+	} else if !reflect.DeepEqual(loaded, blacklist) {
+		t.Errorf("wrong blacklist\nhas:  '%v'\nwant: '%v'", loaded, blacklist)
+	}
+}
@@ -0,0 +1,86 @@
+package unpack
+
+import (
+	"fmt"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// Correlations are the correlations between the plays of titles in bins of
+// Step days, computed from the plays up to Bookmark. Values[i][j] is the
+// correlation between Titles[i] and Titles[j]. Fingerprints[i] is a hash of the
+// daily plays of Titles[i] that the correlations were computed from.
+type Correlations struct {
+	Bookmark     rsrc.Day
+	Step         int
+	Titles       []string
+	Fingerprints []uint64
+	Values       [][]float64
+}
+
+type obCorrelations struct {
+	user string
+}
+
+// LoadCorrelations loads cached correlations between a user's top artists.
+func LoadCorrelations(user string, r rsrc.Reader) (*Correlations, error) {
+	data, err := obtain(obCorrelations{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.(*Correlations), nil
+}
+
+// WriteCorrelations writes correlations between a user's top artists.
+func WriteCorrelations(corr *Correlations, user string, w rsrc.Writer) error {
+	return deposit(corr, obCorrelations{user}, w)
+}
+
+func (o obCorrelations) locator() rsrc.Locator {
+	return rsrc.Correlations(o.user)
+}
+
+func (o obCorrelations) deserializer() interface{} {
+	return &jsonCorrelations{}
+}
+
+func (o obCorrelations) interpret(raw interface{}) (interface{}, error) {
+	corr := raw.(*jsonCorrelations)
+	if len(corr.Fingerprints) != len(corr.Titles) {
+		return nil, fmt.Errorf("correlations have %v fingerprints for %v titles",
+			len(corr.Fingerprints), len(corr.Titles))
+	}
+	if len(corr.Values) != len(corr.Titles) {
+		return nil, fmt.Errorf("correlations have %v rows for %v titles",
+			len(corr.Values), len(corr.Titles))
+	}
+	for i, row := range corr.Values {
+		if len(row) != len(corr.Titles) {
+			return nil, fmt.Errorf("row %v of correlations has %v values for %v titles",
+				i, len(row), len(corr.Titles))
+		}
+	}
+	bookmark := rsrc.ParseDay(corr.Bookmark)
+	if bookmark == nil {
+		return nil, fmt.Errorf("'%v' is no valid bookmark", corr.Bookmark)
+	}
+
+	return &Correlations{
+		Bookmark:     bookmark,
+		Step:         corr.Step,
+		Titles:       corr.Titles,
+		Fingerprints: corr.Fingerprints,
+		Values:       corr.Values,
+	}, nil
+}
+
+func (o obCorrelations) raw(obj interface{}) interface{} {
+	corr := obj.(*Correlations)
+	return jsonCorrelations{
+		Bookmark:     corr.Bookmark.String(),
+		Step:         corr.Step,
+		Titles:       corr.Titles,
+		Fingerprints: corr.Fingerprints,
+		Values:       corr.Values,
+	}
+}
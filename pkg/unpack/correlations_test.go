@@ -0,0 +1,87 @@
+package unpack_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestCorrelations(t *testing.T) {
+	cases := []struct {
+		name string
+		json []byte
+		corr *unpack.Correlations
+		ok   bool
+	}{
+		{
+			"ok",
+			[]byte(`{"bookmark":"2018-01-02","step":7,"titles":["A","B"],"fingerprints":[1,18446744073709551615],` +
+				`"values":[[1,0.5],[0.5,1]]}`),
+			&unpack.Correlations{
+				Bookmark:     rsrc.ParseDay("2018-01-02"),
+				Step:         7,
+				Titles:       []string{"A", "B"},
+				Fingerprints: []uint64{1, 18446744073709551615},
+				Values:       [][]float64{{1, .5}, {.5, 1}},
+			},
+			true,
+		},
+		{
+			"missing row",
+			[]byte(`{"bookmark":"2018-01-02","step":7,"titles":["A","B"],"fingerprints":[1,2],"values":[[1,0.5]]}`),
+			nil, false,
+		},
+		{
+			"short row",
+			[]byte(`{"bookmark":"2018-01-02","step":7,"titles":["A","B"],"fingerprints":[1,2],"values":[[1,0.5],[1]]}`),
+			nil, false,
+		},
+		{
+			"missing fingerprints",
+			[]byte(`{"bookmark":"2018-01-02","step":7,"titles":["A","B"],"values":[[1,0.5],[0.5,1]]}`),
+			nil, false,
+		},
+		{
+			"invalid bookmark",
+			[]byte(`{"bookmark":"x","step":7,"titles":[],"values":[]}`),
+			nil, false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			io, err := mock.IO(
+				map[rsrc.Locator][]byte{rsrc.Correlations("user"): c.json}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			corr, err := unpack.LoadCorrelations("user", io)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if !c.ok {
+				return
+			}
+			if !reflect.DeepEqual(corr, c.corr) {
+				t.Errorf("wrong data\nhas:  %v\nwant: %v", corr, c.corr)
+			}
+
+			if err := unpack.WriteCorrelations(corr, "user", io); err != nil {
+				t.Fatal("unexpected error during write:", err)
+			}
+			reloaded, err := unpack.LoadCorrelations("user", io)
+			if err != nil {
+				t.Fatal("unexpected error after write:", err)
+			}
+			if !reflect.DeepEqual(reloaded, c.corr) {
+				t.Errorf("wrong data after write\nhas:  %v\nwant: %v", reloaded, c.corr)
+			}
+		})
+	}
+}
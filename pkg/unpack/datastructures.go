@@ -1,5 +1,7 @@
 package unpack
 
+import "encoding/json"
+
 type jsonError struct {
 	Error   int    `json:"error"`
 	Message string `json:"message"`
@@ -59,7 +61,8 @@ type jsonTrack struct {
 	Album  jsonText      `json:"album"`
 	Date   jsonDate      `json:"date"`
 	Attr   jsonTrackAttr `json:"@attr"`
-	// Not included: streamable, mbid, url, image
+	MBID   string        `json:"mbid"`
+	// Not included: streamable, url, image
 }
 
 type jsonTrackAttr struct {
@@ -67,8 +70,61 @@ type jsonTrackAttr struct {
 }
 
 type jsonText struct {
-	Str string `json:"#text"`
-	// Not included: mbid
+	Str  string `json:"#text"`
+	MBID string `json:"mbid"`
+}
+
+type jsonUserLovedTracks struct {
+	LovedTracks jsonLovedTracks `json:"lovedtracks"`
+}
+
+type jsonLovedTracks struct {
+	Track jsonLovedTrackList   `json:"track"`
+	Attr  jsonRecentTracksAttr `json:"@attr"`
+}
+
+// jsonLovedTrackList is a list of loved tracks. Like for albums, a single
+// track is sent as an object instead of an array.
+type jsonLovedTrackList []jsonLovedTrack
+
+type jsonLovedTrack struct {
+	Artist jsonName `json:"artist"`
+	Name   string   `json:"name"`
+	Date   jsonDate `json:"date"`
+	// Not included: mbid, url, image, streamable
+}
+
+type jsonName struct {
+	Name string `json:"name"`
+	// Not included: mbid, url
+}
+
+func (l *jsonLovedTrackList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var track jsonLovedTrack
+		if err := json.Unmarshal(data, &track); err != nil {
+			return err
+		}
+		*l = jsonLovedTrackList{track}
+		return nil
+	}
+
+	var tracks []jsonLovedTrack
+	if err := json.Unmarshal(data, &tracks); err != nil {
+		return err
+	}
+	*l = tracks
+	return nil
+}
+
+type jsonLoved struct {
+	Tracks []jsonLovedEntry `json:"tracks"`
+}
+
+type jsonLovedEntry struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Loved  string `json:"loved"`
 }
 
 type jsonArtistInfo struct {
@@ -103,6 +159,64 @@ type jsonTopTagAttr struct {
 	Artist string `json:"artist"`
 }
 
+type jsonSimilarArtists struct {
+	SimilarArtists jsonSimilarArtistList `json:"similarartists"`
+}
+
+type jsonSimilarArtistList struct {
+	Artists []jsonSimilarArtist `json:"artist"`
+	Attr    jsonTopTagAttr      `json:"@attr"`
+}
+
+type jsonSimilarArtist struct {
+	Name  string  `json:"name"`
+	Match float64 `json:"match,string"`
+	// Not included: mbid, url, image, streamable
+}
+
+type jsonAlbumInfo struct {
+	Album jsonAlbumAlbum `json:"album"`
+}
+
+type jsonAlbumAlbum struct {
+	Name   string          `json:"name"`
+	Artist string          `json:"artist"`
+	Tracks jsonAlbumTracks `json:"tracks"`
+	// Not included: mbid, url, image, listeners, playcount, tags, wiki
+}
+
+type jsonAlbumTracks struct {
+	Track jsonAlbumTrackList `json:"track"`
+}
+
+// jsonAlbumTrackList is a list of tracks. Last.fm sends albums with a single
+// track as an object instead of an array.
+type jsonAlbumTrackList []jsonAlbumTrack
+
+type jsonAlbumTrack struct {
+	Name     string `json:"name"`
+	Duration int    `json:"duration"`
+	// Not included: url, streamable, artist, @attr
+}
+
+func (l *jsonAlbumTrackList) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		var track jsonAlbumTrack
+		if err := json.Unmarshal(data, &track); err != nil {
+			return err
+		}
+		*l = jsonAlbumTrackList{track}
+		return nil
+	}
+
+	var tracks []jsonAlbumTrack
+	if err := json.Unmarshal(data, &tracks); err != nil {
+		return err
+	}
+	*l = tracks
+	return nil
+}
+
 type jsonTagInfo struct {
 	Tag jsonTagTag `json:"tag"`
 }
@@ -143,6 +257,90 @@ type jsonCorrections struct {
 	Corrections map[string]string `json:"corrections"`
 }
 
+type jsonExcludedPlays struct {
+	Plays []jsonExcludedPlay `json:"plays"`
+}
+
+type jsonExcludedPlay struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Time   int64  `json:"time"`
+}
+
+type jsonManualPlays struct {
+	Added   []jsonManualPlay `json:"added"`
+	Removed []jsonManualPlay `json:"removed"`
+	Edited  []jsonManualEdit `json:"edited"`
+}
+
+type jsonManualPlay struct {
+	Day      string  `json:"day"`
+	Artist   string  `json:"artist"`
+	Title    string  `json:"title"`
+	Album    string  `json:"album,omitempty"`
+	Duration float64 `json:"duration,omitempty"`
+	Time     int64   `json:"time,omitempty"`
+}
+
+type jsonManualEdit struct {
+	jsonManualPlay
+	New jsonManualChange `json:"new"`
+}
+
+type jsonManualChange struct {
+	Artist string `json:"artist,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Album  string `json:"album,omitempty"`
+}
+
+type jsonSettings struct {
+	Timezone string `json:"timezone,omitempty"`
+}
+
+type jsonRules struct {
+	Rules []jsonRule `json:"rules"`
+}
+
+type jsonRule struct {
+	Name     string   `json:"name"`
+	Artist   string   `json:"artist,omitempty"`
+	Song     string   `json:"song,omitempty"`
+	Album    string   `json:"album,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Country  string   `json:"country,omitempty"`
+	FromYear int      `json:"from,omitempty"`
+	ToYear   int      `json:"to,omitempty"`
+}
+
+type jsonCorrelations struct {
+	Bookmark     string      `json:"bookmark"`
+	Step         int         `json:"step"`
+	Titles       []string    `json:"titles"`
+	Fingerprints []uint64    `json:"fingerprints"`
+	Values       [][]float64 `json:"values"`
+}
+
+type jsonTagMap struct {
+	Tags map[string]string `json:"tags"`
+}
+
+type jsonTagList struct {
+	Tags []string `json:"tags"`
+}
+
+type jsonCreditExceptions struct {
+	Exceptions []string `json:"exceptions"`
+}
+
 type jsonBookmark struct {
 	NextDay string `json:"nextday"`
 }
+
+type jsonResources struct {
+	Resources []jsonResource `json:"resources"`
+}
+
+type jsonResource struct {
+	Method string `json:"method"`
+	Param  string `json:"param"`
+}
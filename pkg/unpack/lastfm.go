@@ -86,10 +86,12 @@ func (o *obUserInfo) raw(obj interface{}) interface{} {
 	return js
 }
 
-// HistoryDayPage is a single page of a day of a user's played tracks.
+// HistoryDayPage is a single page of a day of a user's played tracks. Total is
+// the number of plays on all pages of the day as reported by Last.fm.
 type HistoryDayPage struct {
 	Plays []info.Song
 	Pages int
+	Total int
 }
 
 type obHistory struct {
@@ -129,7 +131,8 @@ func (o *obHistory) interpret(raw interface{}) (interface{}, error) {
 
 	return &HistoryDayPage{
 		countPlays(data),
-		data.RecentTracks.Attr.TotalPages}, nil
+		data.RecentTracks.Attr.TotalPages,
+		data.RecentTracks.Attr.Total}, nil
 }
 
 func countPlays(urt *jsonUserRecentTracks) []info.Song {
@@ -138,9 +141,13 @@ func countPlays(urt *jsonUserRecentTracks) []info.Song {
 		if !track.Attr.NowPlaying {
 
 			plays = append(plays, info.Song{
-				Artist: track.Artist.Str,
-				Title:  track.Name,
-				Album:  track.Album.Str,
+				Artist:     track.Artist.Str,
+				Title:      track.Name,
+				Album:      track.Album.Str,
+				ArtistMBID: track.Artist.MBID,
+				TrackMBID:  track.MBID,
+				AlbumMBID:  track.Album.MBID,
+				Time:       track.Date.UTC,
 			})
 		}
 	}
@@ -162,7 +169,61 @@ func (o *obHistorySingle) interpret(raw interface{}) (interface{}, error) {
 
 	return &HistoryDayPage{
 		countPlays(urt),
-		data.RecentTracks.Attr.TotalPages}, nil
+		data.RecentTracks.Attr.TotalPages,
+		data.RecentTracks.Attr.Total}, nil
+}
+
+// LovedTrack is a track that a user loved on a certain day.
+type LovedTrack struct {
+	Artist, Title string
+	Loved         rsrc.Day
+}
+
+// LovedTracksPage is a page of a user's loved tracks as it is returned by
+// Last.fm.
+type LovedTracksPage struct {
+	Tracks []LovedTrack
+	Pages  int
+}
+
+type obLovedTracks struct {
+	user string
+	page int
+}
+
+// LoadLovedTracksPage loads a page of a user's loved tracks.
+func LoadLovedTracksPage(user string, page int, l Loader) (*LovedTracksPage, error) {
+	data, err := l.load(&obLovedTracks{user, page})
+	if err != nil {
+		return nil, err
+	}
+	return data.(*LovedTracksPage), nil
+}
+
+func (o *obLovedTracks) locator() rsrc.Locator {
+	return rsrc.LovedTracks(o.user, o.page)
+}
+
+func (o *obLovedTracks) deserializer() interface{} {
+	return &jsonUserLovedTracks{}
+}
+
+func (o *obLovedTracks) interpret(raw interface{}) (interface{}, error) {
+	data := raw.(*jsonUserLovedTracks).LovedTracks
+
+	tracks := make([]LovedTrack, len(data.Track))
+	for i, track := range data.Track {
+		tracks[i] = LovedTrack{
+			Artist: track.Artist.Name,
+			Title:  track.Name,
+			Loved:  rsrc.ToDay(track.Date.UTC),
+		}
+	}
+
+	return &LovedTracksPage{
+		Tracks: tracks,
+		Pages:  data.Attr.TotalPages,
+	}, nil
 }
 
 // ArtistInfo contains information about an artist
@@ -262,6 +323,62 @@ func (o *obArtistTags) raw(obj interface{}) interface{} {
 	return js
 }
 
+// SimilarArtist is an artist that is similar to another one. Match is between
+// 0 and 1, higher values mean more similarity.
+type SimilarArtist struct {
+	Name  string
+	Match float64
+}
+
+type obSimilarArtists struct {
+	name string
+}
+
+// LoadSimilarArtists reads the artists that are similar to an artist.
+func LoadSimilarArtists(artist string, l Loader) ([]SimilarArtist, error) {
+	data, err := l.load(&obSimilarArtists{artist})
+	if err != nil {
+		return nil, err
+	}
+	return data.([]SimilarArtist), nil
+}
+
+// WriteSimilarArtists writes the artists that are similar to an artist.
+func WriteSimilarArtists(artist string, similar []SimilarArtist, w rsrc.Writer) error {
+	return deposit(similar, &obSimilarArtists{name: artist}, w)
+}
+
+func (o *obSimilarArtists) locator() rsrc.Locator {
+	return rsrc.ArtistSimilar(o.name)
+}
+
+func (o *obSimilarArtists) deserializer() interface{} {
+	return &jsonSimilarArtists{}
+}
+
+func (o *obSimilarArtists) interpret(raw interface{}) (interface{}, error) {
+	sa := raw.(*jsonSimilarArtists)
+
+	similar := make([]SimilarArtist, len(sa.SimilarArtists.Artists))
+	for i, artist := range sa.SimilarArtists.Artists {
+		similar[i] = SimilarArtist(artist)
+	}
+	return similar, nil
+}
+
+func (o *obSimilarArtists) raw(obj interface{}) interface{} {
+	similar := obj.([]SimilarArtist)
+	jsArtists := []jsonSimilarArtist{}
+	for _, artist := range similar {
+		jsArtists = append(jsArtists, jsonSimilarArtist(artist))
+	}
+
+	return jsonSimilarArtists{SimilarArtists: jsonSimilarArtistList{
+		Artists: jsArtists,
+		Attr:    jsonTopTagAttr{Artist: o.name},
+	}}
+}
+
 type obTagInfo struct {
 	name string
 }
@@ -366,3 +483,73 @@ func (o *obTrackInfo) raw(obj interface{}) interface{} {
 	}}
 	return js
 }
+
+// AlbumInfo contains the tracklist of an album.
+type AlbumInfo struct {
+	Artist string
+	Name   string
+	Tracks []AlbumTrack
+}
+
+// AlbumTrack is a track on an album. Duration is in seconds, it is 0 if it is
+// not known.
+type AlbumTrack struct {
+	Title    string
+	Duration int
+}
+
+type obAlbumInfo struct {
+	artist string
+	album  string
+}
+
+// LoadAlbumInfo reads the tracklist of an album.
+func LoadAlbumInfo(artist, album string, l Loader) (AlbumInfo, error) {
+	data, err := l.load(&obAlbumInfo{artist, album})
+	if err != nil {
+		return AlbumInfo{}, err
+	}
+	return data.(AlbumInfo), nil
+}
+
+// WriteAlbumInfo writes the tracklist of an album.
+func WriteAlbumInfo(artist, album string, info AlbumInfo, w rsrc.Writer) error {
+	return deposit(info, &obAlbumInfo{artist: artist, album: album}, w)
+}
+
+func (o *obAlbumInfo) locator() rsrc.Locator {
+	return rsrc.AlbumInfo(o.artist, o.album)
+}
+
+func (o *obAlbumInfo) deserializer() interface{} {
+	return &jsonAlbumInfo{}
+}
+
+func (o *obAlbumInfo) interpret(raw interface{}) (interface{}, error) {
+	ai := raw.(*jsonAlbumInfo).Album
+
+	tracks := make([]AlbumTrack, len(ai.Tracks.Track))
+	for i, track := range ai.Tracks.Track {
+		tracks[i] = AlbumTrack{Title: track.Name, Duration: track.Duration}
+	}
+
+	return AlbumInfo{
+		Artist: ai.Artist,
+		Name:   ai.Name,
+		Tracks: tracks,
+	}, nil
+}
+
+func (o *obAlbumInfo) raw(obj interface{}) interface{} {
+	info := obj.(AlbumInfo)
+	tracks := jsonAlbumTrackList{}
+	for _, track := range info.Tracks {
+		tracks = append(tracks, jsonAlbumTrack{Name: track.Title, Duration: track.Duration})
+	}
+
+	return jsonAlbumInfo{Album: jsonAlbumAlbum{
+		Name:   info.Name,
+		Artist: info.Artist,
+		Tracks: jsonAlbumTracks{Track: tracks},
+	}}
+}
@@ -123,7 +123,7 @@ func TestWriteUserInfo(t *testing.T) {
 
 func TestLoadHistoryDayPage(t *testing.T) {
 	song1 := `{"artist":{"#text":"ASDF"},"name":"x","album":{"#text":"q"}}`
-	song2 := `{"artist":{"#text":"ASDF"},"name":"y","album":{"#text":"q"}}`
+	song2 := `{"artist":{"#text":"ASDF","mbid":"a1"},"name":"y","mbid":"t1","album":{"#text":"q","mbid":""},"date":{"uts":"86500"}}`
 
 	cases := []struct {
 		json []byte
@@ -140,7 +140,7 @@ func TestLoadHistoryDayPage(t *testing.T) {
 			false,
 		},
 		{
-			[]byte(`{"recenttracks":{"track":[` + song1 + `,` + song2 + `], "@attr":{"totalPages":"1"}}}`),
+			[]byte(`{"recenttracks":{"track":[` + song1 + `,` + song2 + `], "@attr":{"totalPages":"1","total":"2"}}}`),
 			"user", rsrc.ToDay(86400), 1,
 			&unpack.HistoryDayPage{
 				[]info.Song{
@@ -150,18 +150,21 @@ func TestLoadHistoryDayPage(t *testing.T) {
 						Album:  "q",
 					},
 					{
-						Artist: "ASDF",
-						Title:  "y",
-						Album:  "q",
+						Artist:     "ASDF",
+						Title:      "y",
+						Album:      "q",
+						ArtistMBID: "a1",
+						TrackMBID:  "t1",
+						Time:       86500,
 					},
-				}, 1},
+				}, 1, 2},
 			true,
 		},
 		{
 			[]byte(`{"recenttracks":{"@attr":{"page":"1","total":"0","user":"NBooN","perPage":"200","totalPages":"0"},"track":{"artist":{"mbid":"846e89f6-6257-4371-a26d-de960a60bec5","#text":"The Coup"},"@attr":{"nowplaying":"true"},"mbid":"293b4bc9-95c3-3032-a59f-53d6dfba5263","album":{"mbid":"e2f0f87f-763a-498e-9823-decef2cf62b3","#text":"Pick A Bigger Weapon"},"streamable":"0","url":"https:\/\/www.last.fm\/music\/The+Coup\/_\/My+Favorite+Mutiny","name":"My Favorite Mutiny"}}}`),
 			"user", rsrc.ToDay(86400), 1,
 			&unpack.HistoryDayPage{
-				[]info.Song{}, 0},
+				[]info.Song{}, 0, 0},
 			true,
 		},
 	}
@@ -192,6 +195,63 @@ func TestLoadHistoryDayPage(t *testing.T) {
 	}
 }
 
+func TestLoadLovedTracksPage(t *testing.T) {
+	cases := []struct {
+		json []byte
+		page *unpack.LovedTracksPage
+		ok   bool
+	}{
+		{nil, nil, false},
+		{
+			[]byte(`{"lovedtracks":{"track":[{"artist":{"name":"A","mbid":""},"name":"a","date":{"uts":"86400","#text":"02 Jan 1970, 00:00"}},{"artist":{"name":"B"},"name":"b","date":{"uts":"172810"}}],"@attr":{"user":"user","totalPages":"2"}}}`),
+			&unpack.LovedTracksPage{
+				Tracks: []unpack.LovedTrack{
+					{Artist: "A", Title: "a", Loved: rsrc.ParseDay("1970-01-02")},
+					{Artist: "B", Title: "b", Loved: rsrc.ParseDay("1970-01-03")},
+				},
+				Pages: 2,
+			},
+			true,
+		},
+		{
+			[]byte(`{"lovedtracks":{"track":{"artist":{"name":"A"},"name":"a","date":{"uts":"86400"}},"@attr":{"totalPages":"1"}}}`),
+			&unpack.LovedTracksPage{
+				Tracks: []unpack.LovedTrack{
+					{Artist: "A", Title: "a", Loved: rsrc.ParseDay("1970-01-02")},
+				},
+				Pages: 1,
+			},
+			true,
+		},
+		{
+			[]byte(`{"lovedtracks":{"track":[],"@attr":{"totalPages":"0"}}}`),
+			&unpack.LovedTracksPage{Tracks: []unpack.LovedTrack{}, Pages: 0},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			io, err := mock.IO(
+				map[rsrc.Locator][]byte{rsrc.LovedTracks("user", 1): c.json}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			page, err := unpack.LoadLovedTracksPage("user", 1, unpack.NewCacheless(io))
+			if err != nil && c.ok {
+				t.Error("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Error("expected error")
+			}
+
+			if err == nil && !reflect.DeepEqual(page, c.page) {
+				t.Errorf("wrong data:\n has:  %v\nwant: %v", page, c.page)
+			}
+		})
+	}
+}
+
 func TestLoadArtistInfo(t *testing.T) {
 	cases := []struct {
 		files     map[rsrc.Locator][]byte
@@ -336,6 +396,63 @@ func TestWriteLoadArtistTags(t *testing.T) {
 	}
 }
 
+func TestLoadSimilarArtists(t *testing.T) {
+	cases := []struct {
+		json    []byte
+		similar []unpack.SimilarArtist
+		ok      bool
+	}{
+		{nil, nil, false},
+		{[]byte(`{"similarartists":{"artist":[{"name":"A","match":"1"},{"name":"B","match":"0.25","mbid":"x"}],"@attr":{"artist":"xy"}}}`),
+			[]unpack.SimilarArtist{{"A", 1}, {"B", 0.25}}, true},
+		{[]byte(`{"similarartists":{"artist":[{"name":"A","match":"high"}]}}`), nil, false},
+		{[]byte(`{"error":6,"message":"The artist you supplied could not be found"}`), nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			io, err := mock.IO(
+				map[rsrc.Locator][]byte{rsrc.ArtistSimilar("xy"): c.json}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			similar, err := unpack.LoadSimilarArtists("xy", unpack.NewCacheless(io))
+			if err != nil && c.ok {
+				t.Error("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Error("expected error")
+			}
+
+			if err == nil && !reflect.DeepEqual(similar, c.similar) {
+				t.Errorf("wrong data:\n has:  %v\nwant: %v", similar, c.similar)
+			}
+		})
+	}
+}
+
+func TestWriteLoadSimilarArtists(t *testing.T) {
+	similar := []unpack.SimilarArtist{{"A", 1}, {"B", 0.125}}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.ArtistSimilar("xy"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if err := unpack.WriteSimilarArtists("xy", similar, io); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	loaded, err := unpack.LoadSimilarArtists("xy", unpack.NewCacheless(io))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, similar) {
+		t.Errorf("wrong data:\n has:  %v\nwant: %v", loaded, similar)
+	}
+}
+
 func TestLoadTagInfo(t *testing.T) {
 	cases := []struct {
 		files map[rsrc.Locator][]byte
@@ -590,3 +707,80 @@ func TestWriteLoadTrackInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadAlbumInfo(t *testing.T) {
+	cases := []struct {
+		json []byte
+		info unpack.AlbumInfo
+		ok   bool
+	}{
+		{nil, unpack.AlbumInfo{}, false},
+		{[]byte(`{"album":{"name":"X","artist":"xy","tracks":{"track":[{"name":"a","duration":120,"@attr":{"rank":1}},{"name":"b","duration":null}]}}}`),
+			unpack.AlbumInfo{
+				Artist: "xy",
+				Name:   "X",
+				Tracks: []unpack.AlbumTrack{{"a", 120}, {"b", 0}},
+			}, true},
+		{[]byte(`{"album":{"name":"X","artist":"xy","tracks":{"track":{"name":"a","duration":60}}}}`),
+			unpack.AlbumInfo{
+				Artist: "xy",
+				Name:   "X",
+				Tracks: []unpack.AlbumTrack{{"a", 60}},
+			}, true},
+		{[]byte(`{"album":{"name":"X","artist":"xy"}}`),
+			unpack.AlbumInfo{
+				Artist: "xy",
+				Name:   "X",
+				Tracks: []unpack.AlbumTrack{},
+			}, true},
+		{[]byte(`{"album":{"name":"X","artist":"xy","tracks":{"track":"a"}}}`), unpack.AlbumInfo{}, false},
+		{[]byte(`{"error":6,"message":"Album not found"}`), unpack.AlbumInfo{}, false},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			io, err := mock.IO(
+				map[rsrc.Locator][]byte{rsrc.AlbumInfo("xy", "X"): c.json}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			info, err := unpack.LoadAlbumInfo("xy", "X", unpack.NewCacheless(io))
+			if err != nil && c.ok {
+				t.Error("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Error("expected error")
+			}
+
+			if err == nil && !reflect.DeepEqual(info, c.info) {
+				t.Errorf("wrong data:\n has:  %v\nwant: %v", info, c.info)
+			}
+		})
+	}
+}
+
+func TestWriteLoadAlbumInfo(t *testing.T) {
+	info := unpack.AlbumInfo{
+		Artist: "xy",
+		Name:   "X",
+		Tracks: []unpack.AlbumTrack{{"a", 120}, {"b", 0}},
+	}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.AlbumInfo("xy", "X"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if err := unpack.WriteAlbumInfo("xy", "X", info, io); err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	loaded, err := unpack.LoadAlbumInfo("xy", "X", unpack.NewCacheless(io))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, info) {
+		t.Errorf("wrong data:\n has:  %v\nwant: %v", loaded, info)
+	}
+}
@@ -118,6 +118,12 @@ func (l *cached) worker() {
 }
 
 func isFatal(err error) bool {
+	// missing resources while offline are expected and must not stop the
+	// loading of resources that are available
+	if errors.Is(err, rsrc.ErrOffline) {
+		return false
+	}
+
 	switch err := err.(type) {
 	case *LastfmError:
 		return err.IsFatal()
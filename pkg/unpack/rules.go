@@ -0,0 +1,111 @@
+package unpack
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+)
+
+// PartitionRule assigns titles to the partition Name if all of its conditions
+// hold. Artist, Song and Album are regular expressions, Tags must all be tags
+// of the artist, Country is the artist's country and FromYear and ToYear limit
+// the year of the first listen. Conditions that are empty or zero always hold.
+type PartitionRule struct {
+	Name             string
+	Artist           string
+	Song             string
+	Album            string
+	Tags             []string
+	Country          string
+	FromYear, ToYear int
+}
+
+type obRules struct {
+	user string
+}
+
+// LoadRules loads the rules of a user's custom partitions. The order of the
+// rules is preserved since the first matching rule decides the partition.
+func LoadRules(user string, r rsrc.Reader) ([]PartitionRule, error) {
+	data, err := obtain(obRules{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]PartitionRule), nil
+}
+
+// WriteRules writes the rules of a user's custom partitions.
+func WriteRules(rules []PartitionRule, user string, w rsrc.Writer) error {
+	return deposit(rules, obRules{user}, w)
+}
+
+func (o obRules) locator() rsrc.Locator {
+	return rsrc.Rules(o.user)
+}
+
+func (o obRules) deserializer() interface{} {
+	return &jsonRules{}
+}
+
+func (o obRules) interpret(raw interface{}) (interface{}, error) {
+	rules := make([]PartitionRule, len(raw.(*jsonRules).Rules))
+	for i, rule := range raw.(*jsonRules).Rules {
+		if err := checkRule(rule); err != nil {
+			return nil, fmt.Errorf("rule %v is invalid: %v", i+1, err)
+		}
+
+		tags := make([]string, len(rule.Tags))
+		for j, tag := range rule.Tags {
+			tags[j] = strings.ToLower(strings.TrimSpace(tag))
+		}
+
+		rules[i] = PartitionRule{
+			Name:     rule.Name,
+			Artist:   rule.Artist,
+			Song:     rule.Song,
+			Album:    rule.Album,
+			Tags:     tags,
+			Country:  rule.Country,
+			FromYear: rule.FromYear,
+			ToYear:   rule.ToYear,
+		}
+	}
+	return rules, nil
+}
+
+func checkRule(rule jsonRule) error {
+	if strings.TrimSpace(rule.Name) == "" {
+		return fmt.Errorf("rule has no name")
+	} else if rule.Name == "-" {
+		return fmt.Errorf("'-' is reserved for titles that match no rule")
+	}
+	for _, expr := range []string{rule.Artist, rule.Song, rule.Album} {
+		if _, err := regexp.Compile(expr); err != nil {
+			return err
+		}
+	}
+	if rule.FromYear != 0 && rule.ToYear != 0 && rule.FromYear > rule.ToYear {
+		return fmt.Errorf("year range %v-%v is empty", rule.FromYear, rule.ToYear)
+	}
+	return nil
+}
+
+func (o obRules) raw(obj interface{}) interface{} {
+	rules := obj.([]PartitionRule)
+	js := jsonRules{Rules: make([]jsonRule, len(rules))}
+	for i, rule := range rules {
+		js.Rules[i] = jsonRule{
+			Name:     rule.Name,
+			Artist:   rule.Artist,
+			Song:     rule.Song,
+			Album:    rule.Album,
+			Tags:     rule.Tags,
+			Country:  rule.Country,
+			FromYear: rule.FromYear,
+			ToYear:   rule.ToYear,
+		}
+	}
+	return js
+}
@@ -0,0 +1,95 @@
+package unpack_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nilsbu/lastfm/pkg/rsrc"
+	"github.com/nilsbu/lastfm/pkg/unpack"
+	"github.com/nilsbu/lastfm/test/mock"
+)
+
+func TestRules(t *testing.T) {
+	cases := []struct {
+		name  string
+		json  []byte
+		rules []unpack.PartitionRule
+		ok    bool
+	}{
+		{
+			"empty",
+			[]byte(`{"rules":[]}`),
+			[]unpack.PartitionRule{},
+			true,
+		},
+		{
+			"rules keep their order",
+			[]byte(`{"rules":[` +
+				`{"name":"90s Swedish metal","tags":["Metal"],"country":"Sweden","from":1990,"to":1999},` +
+				`{"name":"ABBA","artist":"^ABBA$","album":"Gold"}]}`),
+			[]unpack.PartitionRule{
+				{Name: "90s Swedish metal", Tags: []string{"metal"}, Country: "Sweden", FromYear: 1990, ToYear: 1999},
+				{Name: "ABBA", Artist: "^ABBA$", Album: "Gold", Tags: []string{}},
+			},
+			true,
+		},
+		{
+			"no name",
+			[]byte(`{"rules":[{"artist":"A"}]}`),
+			nil,
+			false,
+		},
+		{
+			"reserved name",
+			[]byte(`{"rules":[{"name":"-","artist":"A"}]}`),
+			nil,
+			false,
+		},
+		{
+			"invalid regex",
+			[]byte(`{"rules":[{"name":"x","song":"[a"}]}`),
+			nil,
+			false,
+		},
+		{
+			"empty year range",
+			[]byte(`{"rules":[{"name":"x","from":2000,"to":1990}]}`),
+			nil,
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			io, err := mock.IO(
+				map[rsrc.Locator][]byte{rsrc.Rules("user"): c.json}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			rules, err := unpack.LoadRules("user", io)
+			if err != nil && c.ok {
+				t.Fatal("unexpected error:", err)
+			} else if err == nil && !c.ok {
+				t.Fatal("expected error but none occurred")
+			}
+			if !c.ok {
+				return
+			}
+			if !reflect.DeepEqual(rules, c.rules) {
+				t.Errorf("wrong data\nhas:  %v\nwant: %v", rules, c.rules)
+			}
+
+			if err := unpack.WriteRules(rules, "user", io); err != nil {
+				t.Fatal("unexpected error during write:", err)
+			}
+			reloaded, err := unpack.LoadRules("user", io)
+			if err != nil {
+				t.Fatal("unexpected error after write:", err)
+			}
+			if !reflect.DeepEqual(reloaded, c.rules) {
+				t.Errorf("wrong data after write\nhas:  %v\nwant: %v", reloaded, c.rules)
+			}
+		})
+	}
+}
@@ -48,6 +48,321 @@ func (o obBookmark) raw(obj interface{}) interface{} {
 	return js
 }
 
+type obLoved struct {
+	user string
+}
+
+// WriteLovedTracks writes the loved tracks of a user.
+func WriteLovedTracks(tracks []LovedTrack, user string, w rsrc.Writer) error {
+	return deposit(tracks, obLoved{user}, w)
+}
+
+// LoadLovedTracks loads the loved tracks of a user that were written before.
+func LoadLovedTracks(user string, r rsrc.Reader) ([]LovedTrack, error) {
+	data, err := obtain(obLoved{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]LovedTrack), nil
+}
+
+func (o obLoved) locator() rsrc.Locator {
+	return rsrc.Loved(o.user)
+}
+
+func (o obLoved) deserializer() interface{} {
+	return &jsonLoved{}
+}
+
+func (o obLoved) interpret(raw interface{}) (interface{}, error) {
+	loved := raw.(*jsonLoved)
+
+	tracks := make([]LovedTrack, len(loved.Tracks))
+	for i, track := range loved.Tracks {
+		day := rsrc.ParseDay(track.Loved)
+		if day == nil {
+			return nil, fmt.Errorf("'%v' is no valid day", track.Loved)
+		}
+		tracks[i] = LovedTrack{
+			Artist: track.Artist,
+			Title:  track.Title,
+			Loved:  day,
+		}
+	}
+	return tracks, nil
+}
+
+func (o obLoved) raw(obj interface{}) interface{} {
+	tracks := obj.([]LovedTrack)
+
+	js := jsonLoved{Tracks: make([]jsonLovedEntry, len(tracks))}
+	for i, track := range tracks {
+		js.Tracks[i] = jsonLovedEntry{
+			Artist: track.Artist,
+			Title:  track.Title,
+			Loved:  track.Loved.String(),
+		}
+	}
+	return js
+}
+
+// ExcludedPlay identifies a play that is left out of the charts. The artist is
+// the name as it was scrobbled, i.e. before corrections are applied.
+type ExcludedPlay struct {
+	Artist, Title string
+	Time          int64
+}
+
+type obExcludedPlays struct {
+	user string
+}
+
+// WriteExcludedPlays writes the plays of a user that are left out of the
+// charts.
+func WriteExcludedPlays(plays []ExcludedPlay, user string, w rsrc.Writer) error {
+	return deposit(plays, obExcludedPlays{user}, w)
+}
+
+// LoadExcludedPlays loads the plays of a user that are left out of the charts.
+func LoadExcludedPlays(user string, r rsrc.Reader) ([]ExcludedPlay, error) {
+	data, err := obtain(obExcludedPlays{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]ExcludedPlay), nil
+}
+
+func (o obExcludedPlays) locator() rsrc.Locator {
+	return rsrc.ExcludedPlays(o.user)
+}
+
+func (o obExcludedPlays) deserializer() interface{} {
+	return &jsonExcludedPlays{}
+}
+
+func (o obExcludedPlays) interpret(raw interface{}) (interface{}, error) {
+	excluded := raw.(*jsonExcludedPlays)
+
+	plays := make([]ExcludedPlay, len(excluded.Plays))
+	for i, play := range excluded.Plays {
+		plays[i] = ExcludedPlay{
+			Artist: play.Artist,
+			Title:  play.Title,
+			Time:   play.Time,
+		}
+	}
+	return plays, nil
+}
+
+func (o obExcludedPlays) raw(obj interface{}) interface{} {
+	plays := obj.([]ExcludedPlay)
+
+	js := jsonExcludedPlays{Plays: make([]jsonExcludedPlay, len(plays))}
+	for i, play := range plays {
+		js.Plays[i] = jsonExcludedPlay{
+			Artist: play.Artist,
+			Title:  play.Title,
+			Time:   play.Time,
+		}
+	}
+	return js
+}
+
+// PlayRef identifies a play in the history of a day. The artist is the name
+// as it was scrobbled. If Time is 0, the first play of the song on that day is
+// meant.
+type PlayRef struct {
+	Day           rsrc.Day
+	Artist, Title string
+	Time          int64
+}
+
+// PlayAddition is a play that is added to the history of a day.
+type PlayAddition struct {
+	Day  rsrc.Day
+	Song info.Song
+}
+
+// PlayEdit changes the artist, title and album of a play. Empty fields are
+// left unchanged.
+type PlayEdit struct {
+	Ref                  PlayRef
+	Artist, Title, Album string
+}
+
+// ManualPlays are plays that were added, removed or edited by hand. They are
+// kept apart from the history so that they survive re-fetching it.
+type ManualPlays struct {
+	Added   []PlayAddition
+	Removed []PlayRef
+	Edited  []PlayEdit
+}
+
+type obManualPlays struct {
+	user string
+}
+
+// WriteManualPlays writes the plays of a user that were changed by hand.
+func WriteManualPlays(plays *ManualPlays, user string, w rsrc.Writer) error {
+	return deposit(plays, obManualPlays{user}, w)
+}
+
+// LoadManualPlays loads the plays of a user that were changed by hand.
+func LoadManualPlays(user string, r rsrc.Reader) (*ManualPlays, error) {
+	data, err := obtain(obManualPlays{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.(*ManualPlays), nil
+}
+
+func (o obManualPlays) locator() rsrc.Locator {
+	return rsrc.ManualPlays(o.user)
+}
+
+func (o obManualPlays) deserializer() interface{} {
+	return &jsonManualPlays{}
+}
+
+func (o obManualPlays) interpret(raw interface{}) (interface{}, error) {
+	js := raw.(*jsonManualPlays)
+
+	plays := &ManualPlays{
+		Added:   make([]PlayAddition, len(js.Added)),
+		Removed: make([]PlayRef, len(js.Removed)),
+		Edited:  make([]PlayEdit, len(js.Edited)),
+	}
+	for i, play := range js.Added {
+		ref, err := interpretPlayRef(play)
+		if err != nil {
+			return nil, err
+		}
+		plays.Added[i] = PlayAddition{
+			Day: ref.Day,
+			Song: info.Song{
+				Artist:   play.Artist,
+				Title:    play.Title,
+				Album:    play.Album,
+				Duration: play.Duration,
+				Time:     play.Time,
+			},
+		}
+	}
+	for i, play := range js.Removed {
+		ref, err := interpretPlayRef(play)
+		if err != nil {
+			return nil, err
+		}
+		plays.Removed[i] = ref
+	}
+	for i, edit := range js.Edited {
+		ref, err := interpretPlayRef(edit.jsonManualPlay)
+		if err != nil {
+			return nil, err
+		}
+		plays.Edited[i] = PlayEdit{
+			Ref:    ref,
+			Artist: edit.New.Artist,
+			Title:  edit.New.Title,
+			Album:  edit.New.Album,
+		}
+	}
+	return plays, nil
+}
+
+func interpretPlayRef(play jsonManualPlay) (PlayRef, error) {
+	day := rsrc.ParseDay(play.Day)
+	if day == nil {
+		return PlayRef{}, fmt.Errorf("'%v' is no valid day", play.Day)
+	}
+	return PlayRef{Day: day, Artist: play.Artist, Title: play.Title, Time: play.Time}, nil
+}
+
+func rawPlayRef(ref PlayRef) jsonManualPlay {
+	return jsonManualPlay{
+		Day:    ref.Day.String(),
+		Artist: ref.Artist,
+		Title:  ref.Title,
+		Time:   ref.Time,
+	}
+}
+
+func (o obManualPlays) raw(obj interface{}) interface{} {
+	plays := obj.(*ManualPlays)
+
+	js := jsonManualPlays{
+		Added:   make([]jsonManualPlay, len(plays.Added)),
+		Removed: make([]jsonManualPlay, len(plays.Removed)),
+		Edited:  make([]jsonManualEdit, len(plays.Edited)),
+	}
+	for i, play := range plays.Added {
+		js.Added[i] = jsonManualPlay{
+			Day:      play.Day.String(),
+			Artist:   play.Song.Artist,
+			Title:    play.Song.Title,
+			Album:    play.Song.Album,
+			Duration: play.Song.Duration,
+			Time:     play.Song.Time,
+		}
+	}
+	for i, ref := range plays.Removed {
+		js.Removed[i] = rawPlayRef(ref)
+	}
+	for i, edit := range plays.Edited {
+		js.Edited[i] = jsonManualEdit{
+			jsonManualPlay: rawPlayRef(edit.Ref),
+			New: jsonManualChange{
+				Artist: edit.Artist,
+				Title:  edit.Title,
+				Album:  edit.Album,
+			},
+		}
+	}
+	return js
+}
+
+// Settings are the settings of a user. Timezone is the name of the time zone in
+// which plays are sorted into days, it is empty for UTC.
+type Settings struct {
+	Timezone string
+}
+
+type obSettings struct {
+	user string
+}
+
+// WriteSettings writes the settings of a user.
+func WriteSettings(settings *Settings, user string, w rsrc.Writer) error {
+	return deposit(settings, obSettings{user}, w)
+}
+
+// LoadSettings loads the settings of a user.
+func LoadSettings(user string, r rsrc.Reader) (*Settings, error) {
+	data, err := obtain(obSettings{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.(*Settings), nil
+}
+
+func (o obSettings) locator() rsrc.Locator {
+	return rsrc.Settings(o.user)
+}
+
+func (o obSettings) deserializer() interface{} {
+	return &jsonSettings{}
+}
+
+func (o obSettings) interpret(raw interface{}) (interface{}, error) {
+	settings := raw.(*jsonSettings)
+	return &Settings{Timezone: settings.Timezone}, nil
+}
+
+func (o obSettings) raw(obj interface{}) interface{} {
+	settings := obj.(*Settings)
+	return jsonSettings{Timezone: settings.Timezone}
+}
+
 type obBackupBookmark struct {
 	user string
 }
@@ -139,6 +454,24 @@ func (o obAllDayPlays) interpret(raw interface{}) (interface{}, error) {
 	return *raw.(*[]map[string]float64), nil
 }
 
+// obSongHistory is the history of all plays of a user, stored as a list of
+// days with their plays.
+type obSongHistory struct {
+	user string
+}
+
+func (o obSongHistory) locator() rsrc.Locator {
+	return rsrc.SongHistory(o.user)
+}
+
+func (o obSongHistory) deserializer() interface{} {
+	return &[][]info.Song{}
+}
+
+func (o obSongHistory) interpret(raw interface{}) (interface{}, error) {
+	return *raw.(*[][]info.Song), nil
+}
+
 func (o obAllDayPlays) raw(obj interface{}) interface{} {
 	return obj
 }
@@ -149,6 +482,8 @@ type obDayHistory struct {
 }
 
 // LoadDayHistory loads the pre-processed history of a user for a single day, called history.
+// Each play is stored as artist, title, album and duration, optionally
+// followed by the MBIDs of artist, track and album and the time of the play.
 func LoadDayHistory(user string, day rsrc.Day, r rsrc.Reader) ([]info.Song, error) {
 	data, err := obtain(obDayHistory{user, day}, r)
 	if err != nil {
@@ -159,6 +494,9 @@ func LoadDayHistory(user string, day rsrc.Day, r rsrc.Reader) ([]info.Song, erro
 	outSongs := make([]info.Song, len(inSongs))
 
 	for i, song := range inSongs {
+		if len(song) != 4 && len(song) != 7 && len(song) != 8 {
+			return nil, fmt.Errorf("play %v has %v fields, expected 4, 7 or 8", i, len(song))
+		}
 		duration, err := strconv.ParseFloat(song[3], 64)
 		if err != nil {
 			return nil, err
@@ -169,16 +507,34 @@ func LoadDayHistory(user string, day rsrc.Day, r rsrc.Reader) ([]info.Song, erro
 			Album:    song[2],
 			Duration: duration,
 		}
+		if len(song) >= 7 {
+			outSongs[i].ArtistMBID = song[4]
+			outSongs[i].TrackMBID = song[5]
+			outSongs[i].AlbumMBID = song[6]
+		}
+		if len(song) == 8 {
+			outSongs[i].Time, err = strconv.ParseInt(song[7], 10, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return outSongs, nil
 }
 
 // WritDayHistory write the pre-processed history of a user for a single day.
+// MBIDs are only written for plays that have at least one or a known time.
 func WriteDayHistory(songs []info.Song, user string, day rsrc.Day, w rsrc.Writer) error {
 	outSongs := make([][]string, len(songs))
 	for i, song := range songs {
 		outSongs[i] = []string{song.Artist, song.Title, song.Album, fmt.Sprintf("%f", song.Duration)}
+		if song.ArtistMBID != "" || song.TrackMBID != "" || song.AlbumMBID != "" || song.Time != 0 {
+			outSongs[i] = append(outSongs[i], song.ArtistMBID, song.TrackMBID, song.AlbumMBID)
+		}
+		if song.Time != 0 {
+			outSongs[i] = append(outSongs[i], strconv.FormatInt(song.Time, 10))
+		}
 	}
 
 	return deposit(outSongs, obDayHistory{user, day}, w)
@@ -205,6 +561,13 @@ type obCorrections struct {
 	fn   func(string) rsrc.Locator
 }
 
+// WriteArtistCorrections writes corrections for artist names. The map has the
+// false names as keys and correct names as values.
+func WriteArtistCorrections(corrections map[string]string, user string, w rsrc.Writer,
+) error {
+	return deposit(corrections, obCorrections{user, rsrc.ArtistCorrections}, w)
+}
+
 // LoadArtistCorrections loads corrections for artist names. The result is a map
 // with the false names as keys and correct names as values.
 func LoadArtistCorrections(user string, r rsrc.Reader,
@@ -218,6 +581,46 @@ func LoadArtistCorrections(user string, r rsrc.Reader,
 	return corr, nil
 }
 
+type obCreditExceptions struct {
+	user string
+}
+
+// WriteCreditExceptions writes the artist names that are not split into
+// several credited artists.
+func WriteCreditExceptions(exceptions []string, user string, w rsrc.Writer) error {
+	return deposit(exceptions, obCreditExceptions{user}, w)
+}
+
+// LoadCreditExceptions loads the artist names that are not split into several
+// credited artists.
+func LoadCreditExceptions(user string, r rsrc.Reader) ([]string, error) {
+	data, err := obtain(obCreditExceptions{user}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]string), nil
+}
+
+func (o obCreditExceptions) locator() rsrc.Locator {
+	return rsrc.CreditExceptions(o.user)
+}
+
+func (o obCreditExceptions) deserializer() interface{} {
+	return &jsonCreditExceptions{}
+}
+
+func (o obCreditExceptions) interpret(raw interface{}) (interface{}, error) {
+	exceptions := raw.(*jsonCreditExceptions).Exceptions
+	if exceptions == nil {
+		exceptions = []string{}
+	}
+	return exceptions, nil
+}
+
+func (o obCreditExceptions) raw(obj interface{}) interface{} {
+	return jsonCreditExceptions{Exceptions: obj.([]string)}
+}
+
 // LoadSupertagCorrections loads corrections for artist's supertags. The result
 // is a map with the artist names as keys and intended supertags as values.
 func LoadSupertagCorrections(user string, r rsrc.Reader,
@@ -266,3 +669,7 @@ func (o obCorrections) interpret(raw interface{}) (interface{}, error) {
 
 	return key.Corrections, nil
 }
+
+func (o obCorrections) raw(obj interface{}) interface{} {
+	return jsonCorrections{Corrections: obj.(map[string]string)}
+}
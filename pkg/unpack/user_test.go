@@ -184,6 +184,22 @@ func TestDayHistory(t *testing.T) {
 			},
 			true, true,
 		},
+		{
+			[]info.Song{
+				{Artist: "ABC", Title: "a", Album: "", Duration: 1.3},
+				{Artist: "ABC", Title: "b", Album: "y", Duration: 4.2,
+					ArtistMBID: "a1", TrackMBID: "", AlbumMBID: "y1"},
+			},
+			true, true,
+		},
+		{
+			[]info.Song{
+				{Artist: "ABC", Title: "a", Album: "", Duration: 1.3, Time: 1577750400},
+				{Artist: "ABC", Title: "b", Album: "y", Duration: 4.2,
+					ArtistMBID: "a1", Time: 1577750700},
+			},
+			true, true,
+		},
 	}
 
 	for _, c := range cases {
@@ -217,6 +233,35 @@ func TestDayHistory(t *testing.T) {
 	}
 }
 
+func TestLovedTracks(t *testing.T) {
+	tracks := []unpack.LovedTrack{
+		{Artist: "A", Title: "a", Loved: rsrc.ParseDay("2019-12-31")},
+		{Artist: "B", Title: "b", Loved: rsrc.ParseDay("2020-01-02")},
+	}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.Loved("user"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if _, err := unpack.LoadLovedTracks("user", io); err == nil {
+		t.Error("expected error before loved tracks were written")
+	}
+
+	if err := unpack.WriteLovedTracks(tracks, "user", io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	loaded, err := unpack.LoadLovedTracks("user", io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, tracks) {
+		t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", loaded, tracks)
+	}
+}
+
 func TestLoadArtistCorrections(t *testing.T) {
 	cases := []struct {
 		json        []byte
@@ -259,6 +304,147 @@ func TestLoadArtistCorrections(t *testing.T) {
 	}
 }
 
+func TestArtistCorrections(t *testing.T) {
+	corrections := map[string]string{"abc": "x", "yy": "x"}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.ArtistCorrections("user"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if err := unpack.WriteArtistCorrections(corrections, "user", io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	loaded, err := unpack.LoadArtistCorrections("user", io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, corrections) {
+		t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", loaded, corrections)
+	}
+}
+
+func TestExcludedPlays(t *testing.T) {
+	plays := []unpack.ExcludedPlay{
+		{Artist: "A", Title: "a", Time: 86400},
+		{Artist: "B", Title: "b", Time: 86500},
+	}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.ExcludedPlays("user"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if _, err := unpack.LoadExcludedPlays("user", io); err == nil {
+		t.Error("expected error before excluded plays were written")
+	}
+
+	if err := unpack.WriteExcludedPlays(plays, "user", io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	loaded, err := unpack.LoadExcludedPlays("user", io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, plays) {
+		t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", loaded, plays)
+	}
+}
+
+func TestManualPlays(t *testing.T) {
+	plays := &unpack.ManualPlays{
+		Added: []unpack.PlayAddition{{
+			Day:  rsrc.ParseDay("2018-01-01"),
+			Song: info.Song{Artist: "A", Title: "a", Album: "x", Duration: 3.5, Time: 1514808000},
+		}},
+		Removed: []unpack.PlayRef{
+			{Day: rsrc.ParseDay("2018-01-02"), Artist: "B", Title: "b"},
+		},
+		Edited: []unpack.PlayEdit{{
+			Ref:    unpack.PlayRef{Day: rsrc.ParseDay("2018-01-03"), Artist: "C", Title: "c", Time: 1514980800},
+			Artist: "D",
+		}},
+	}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.ManualPlays("user"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if _, err := unpack.LoadManualPlays("user", io); err == nil {
+		t.Error("expected error before manual plays were written")
+	}
+
+	if err := unpack.WriteManualPlays(plays, "user", io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	loaded, err := unpack.LoadManualPlays("user", io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, plays) {
+		t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", loaded, plays)
+	}
+}
+
+func TestSettings(t *testing.T) {
+	settings := &unpack.Settings{Timezone: "Europe/Berlin"}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.Settings("user"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if _, err := unpack.LoadSettings("user", io); err == nil {
+		t.Error("expected error before settings were written")
+	}
+
+	if err := unpack.WriteSettings(settings, "user", io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	loaded, err := unpack.LoadSettings("user", io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, settings) {
+		t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", loaded, settings)
+	}
+}
+
+func TestCreditExceptions(t *testing.T) {
+	exceptions := []string{"Simon & Garfunkel", "Earth, Wind & Fire"}
+
+	io, err := mock.IO(
+		map[rsrc.Locator][]byte{rsrc.CreditExceptions("user"): nil}, mock.Path)
+	if err != nil {
+		t.Fatal("setup error")
+	}
+
+	if _, err := unpack.LoadCreditExceptions("user", io); err == nil {
+		t.Error("expected error before exceptions were written")
+	}
+
+	if err := unpack.WriteCreditExceptions(exceptions, "user", io); err != nil {
+		t.Fatal("unexpected error during write:", err)
+	}
+
+	loaded, err := unpack.LoadCreditExceptions("user", io)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(loaded, exceptions) {
+		t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", loaded, exceptions)
+	}
+}
+
 func TestLoadSupertagCorrections(t *testing.T) {
 	cases := []struct {
 		json        []byte
@@ -97,3 +97,56 @@ func (o obSessionInfo) raw(obj interface{}) interface{} {
 
 	return &jsonSessionInfo{User: session.User, Options: options}
 }
+
+// Resource identifies a Last.fm resource by the name of its API method and a
+// parameter string as accepted by rsrc.ParseLocator().
+type Resource struct {
+	Method string
+	Param  string
+}
+
+type obMissingResources struct{}
+
+// LoadMissingResources loads the list of resources that could not be loaded
+// while working offline.
+func LoadMissingResources(r rsrc.Reader) ([]Resource, error) {
+	data, err := obtain(obMissingResources{}, r)
+	if err != nil {
+		return nil, err
+	}
+	return data.([]Resource), nil
+}
+
+// WriteMissingResources writes the list of resources that could not be loaded
+// while working offline.
+func WriteMissingResources(resources []Resource, w rsrc.Writer) error {
+	return deposit(resources, obMissingResources{}, w)
+}
+
+func (o obMissingResources) locator() rsrc.Locator {
+	return rsrc.MissingResources()
+}
+
+func (o obMissingResources) deserializer() interface{} {
+	return &jsonResources{}
+}
+
+func (o obMissingResources) interpret(raw interface{}) (interface{}, error) {
+	js := raw.(*jsonResources)
+
+	resources := make([]Resource, len(js.Resources))
+	for i, res := range js.Resources {
+		resources[i] = Resource(res)
+	}
+	return resources, nil
+}
+
+func (o obMissingResources) raw(obj interface{}) interface{} {
+	resources := obj.([]Resource)
+
+	js := jsonResources{Resources: make([]jsonResource, len(resources))}
+	for i, res := range resources {
+		js.Resources[i] = jsonResource(res)
+	}
+	return js
+}
@@ -128,3 +128,41 @@ func TestWriteSessionInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestMissingResources(t *testing.T) {
+	cases := []struct {
+		resources []unpack.Resource
+	}{
+		{[]unpack.Resource{}},
+		{[]unpack.Resource{
+			{Method: "artist.getTopTags", Param: "A"},
+			{Method: "track.getInfo", Param: "A^T"},
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run("", func(t *testing.T) {
+			io, err := mock.IO(
+				map[rsrc.Locator][]byte{rsrc.MissingResources(): nil}, mock.Path)
+			if err != nil {
+				t.Fatal("setup error")
+			}
+
+			if _, err := unpack.LoadMissingResources(io); err == nil {
+				t.Error("expected error when loading absent file")
+			}
+
+			if err := unpack.WriteMissingResources(c.resources, io); err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+
+			resources, err := unpack.LoadMissingResources(io)
+			if err != nil {
+				t.Fatal("unexpected error:", err)
+			}
+			if !reflect.DeepEqual(resources, c.resources) {
+				t.Errorf("wrong data\nhas:  '%v'\nwant: '%v'", resources, c.resources)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package mock
 
 import (
 	"fmt"
+	"io/fs"
 
 	"github.com/nilsbu/lastfm/pkg/rsrc"
 )
@@ -69,7 +70,7 @@ func worker(
 			if !ok || data == nil {
 				job.Back <- readResult{
 					Data: nil,
-					Err:  fmt.Errorf("read at '%v' failed", path),
+					Err:  fmt.Errorf("read at '%v' failed: %w", path, fs.ErrNotExist),
 				}
 			} else {
 				job.Back <- readResult{Data: data, Err: nil}